@@ -3,7 +3,15 @@ package main
 import (
 	"fmt"
 	"log"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/app"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/config"
+	memorystorage "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/tracing"
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
@@ -13,14 +21,26 @@ import (
 const (
 	MemoryStorageType = "memory"
 	SQLStorageType    = "sql"
+	SQLiteStorageType = "sqlite"
+
+	NoQueueType   = "none"
+	AMQPQueueType = "amqp"
 )
 
 type Config struct {
 	LogLevel    string     `yaml:"log_level" env:"LOG_LEVEL" env-default:"info"`
 	StorageType string     `yaml:"storage_type" env:"STORAGE_TYPE" env-default:"memory"`
+	AutoMigrate bool       `yaml:"auto_migrate" env:"AUTO_MIGRATE" env-default:"true"`
 	DB          Database   `yaml:"db"`
+	Memory      Memory     `yaml:"memory"`
+	SQLite      SQLite     `yaml:"sqlite"`
+	Queue       Queue      `yaml:"queue"`
+	Events      Events     `yaml:"events"`
 	HTTPServer  HTTPServer `yaml:"http_server" env-prefix:"HTTP_"`
 	GRPCServer  GrpcServer `yaml:"grpc_server" env-prefix:"GRPC_"`
+	Metrics     Metrics    `yaml:"metrics"`
+	Tracing     Tracing    `yaml:"tracing"`
+	Health      Health     `yaml:"health"`
 }
 
 type Database struct {
@@ -29,6 +49,31 @@ type Database struct {
 	Name     string `yaml:"name" env:"DB_NAME" env-default:"postgres"`
 	Username string `yaml:"username" env:"DB_USERNAME" env-default:"postgres"`
 	Password string `yaml:"password" env:"DB_PASSWORD" env-default:""`
+	SSLMode  string `yaml:"sslmode" env:"DB_SSLMODE" env-default:"prefer"`
+}
+
+// Memory configures persistence of the memory storage, it is kept only in memory if Path is empty.
+type Memory struct {
+	Path          string        `yaml:"path" env:"MEMORY_PATH" env-default:""`
+	Sync          string        `yaml:"sync" env:"MEMORY_SYNC" env-default:"always"`
+	SyncInterval  time.Duration `yaml:"sync_interval" env:"MEMORY_SYNC_INTERVAL" env-default:"1s"`
+	SnapshotEvery int           `yaml:"snapshot_every" env:"MEMORY_SNAPSHOT_EVERY" env-default:"1000"`
+}
+
+type SQLite struct {
+	Path string `yaml:"path" env:"SQLITE_PATH" env-default:"calendar.db"`
+}
+
+// Queue is the queue the scheduler publishes notifications to. The calendar does not use it,
+// but with type amqp the calendar is ready only while the broker is reachable.
+type Queue struct {
+	Type         string `yaml:"type" env:"QUEUE_TYPE" env-default:"none"`
+	config.Queue `yaml:",inline"`
+}
+
+type Events struct {
+	OverlapPolicy string `yaml:"overlap_policy" env:"EVENTS_OVERLAP_POLICY" env-default:"reject"`
+	WeekStart     string `yaml:"week_start" env:"EVENTS_WEEK_START" env-default:"monday"`
 }
 
 type HTTPServer struct {
@@ -41,6 +86,35 @@ type GrpcServer struct {
 	Port int    `yaml:"port" env:"PORT" env-default:"8082"`
 }
 
+// Metrics are served by the HTTP server at Path.
+type Metrics struct {
+	Enabled   bool      `yaml:"enabled" env:"METRICS_ENABLED" env-default:"true"`
+	Path      string    `yaml:"path" env:"METRICS_PATH" env-default:"/metrics"`
+	Namespace string    `yaml:"namespace" env:"METRICS_NAMESPACE" env-default:"calendar"`
+	Buckets   []float64 `yaml:"buckets" env:"METRICS_BUCKETS" env-default:"0.005,0.01,0.025,0.05,0.1,0.25,0.5,1,2.5,5"`
+	// PerOwner labels business metrics with owner IDs, which is only feasible for a limited number of owners.
+	PerOwner bool `yaml:"per_owner" env:"METRICS_PER_OWNER" env-default:"false"`
+}
+
+// Tracing configures export of OpenTelemetry spans, Exporter is none, stdout or otlp.
+type Tracing struct {
+	Exporter string `yaml:"exporter" env:"TRACING_EXPORTER" env-default:"none"`
+	// Endpoint is host:port of the OTLP gRPC receiver.
+	Endpoint    string  `yaml:"endpoint" env:"TRACING_ENDPOINT" env-default:"localhost:4317"`
+	Insecure    bool    `yaml:"insecure" env:"TRACING_INSECURE" env-default:"true"`
+	ServiceName string  `yaml:"service_name" env:"TRACING_SERVICE_NAME" env-default:"calendar"`
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" env-default:"1"`
+}
+
+// Health configures readiness checks served at /readyz and by the gRPC health service.
+type Health struct {
+	CheckTimeout time.Duration `yaml:"check_timeout" env:"HEALTH_CHECK_TIMEOUT" env-default:"2s"`
+	// WatchInterval is how often statuses watched over gRPC are checked.
+	WatchInterval time.Duration `yaml:"watch_interval" env:"HEALTH_WATCH_INTERVAL" env-default:"5s"`
+	// ShutdownDelay keeps serving after readiness flipped to NOT_SERVING, so that load balancers notice it.
+	ShutdownDelay time.Duration `yaml:"shutdown_delay" env:"HEALTH_SHUTDOWN_DELAY" env-default:"0s"`
+}
+
 func MustLoad(cfgFilePath string) Config {
 	var cfg Config
 
@@ -50,27 +124,107 @@ func MustLoad(cfgFilePath string) Config {
 	}
 
 	validateStorageType(cfg.StorageType)
+	validateSSLMode(cfg.DB.SSLMode)
+	validateMemorySync(cfg.Memory.Sync)
+	validateQueueType(cfg.Queue.Type)
+	validateOverlapPolicy(cfg.Events.OverlapPolicy)
+	validateWeekStart(cfg.Events.WeekStart)
+	validateMetrics(cfg.Metrics)
+	validateTracing(cfg.Tracing)
+	validateHealth(cfg.Health)
 
 	return cfg
 }
 
 func validateStorageType(storageType string) {
 	switch storageType {
-	case MemoryStorageType, SQLStorageType:
+	case MemoryStorageType, SQLStorageType, SQLiteStorageType:
 		return
 	default:
 		log.Fatalf("unknown storage type: %s", storageType)
 	}
 }
 
+func validateSSLMode(sslMode string) {
+	switch sslMode {
+	case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+		return
+	default:
+		log.Fatalf("unknown database sslmode: %s", sslMode)
+	}
+}
+
+func validateMemorySync(policy string) {
+	if _, err := memorystorage.ParseSyncPolicy(policy); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func validateQueueType(queueType string) {
+	switch queueType {
+	case NoQueueType, AMQPQueueType:
+		return
+	default:
+		log.Fatalf("unknown queue type: %s", queueType)
+	}
+}
+
+func validateOverlapPolicy(policy string) {
+	if _, err := app.ParseOverlapPolicy(policy); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func validateWeekStart(weekStart string) {
+	if _, err := app.ParseWeekday(weekStart); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func validateMetrics(m Metrics) {
+	if !m.Enabled {
+		return
+	}
+
+	if !strings.HasPrefix(m.Path, "/") {
+		log.Fatalf("metrics path must start with /: %s", m.Path)
+	}
+
+	if !sort.Float64sAreSorted(m.Buckets) {
+		log.Fatalf("metrics buckets must be sorted: %v", m.Buckets)
+	}
+}
+
+func validateTracing(t Tracing) {
+	if _, err := tracing.ParseExporter(t.Exporter); err != nil {
+		log.Fatal(err)
+	}
+
+	if t.SampleRatio < 0 || t.SampleRatio > 1 {
+		log.Fatalf("tracing sample ratio must be in [0, 1]: %v", t.SampleRatio)
+	}
+}
+
+func validateHealth(h Health) {
+	if h.CheckTimeout <= 0 || h.WatchInterval <= 0 {
+		log.Fatalf("health check timeout and watch interval must be positive: %s, %s", h.CheckTimeout, h.WatchInterval)
+	}
+
+	if h.ShutdownDelay < 0 {
+		log.Fatalf("health shutdown delay must not be negative: %s", h.ShutdownDelay)
+	}
+}
+
 func (c *Config) MakeDBConnectionString() string {
-	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s",
-		c.DB.Username,
-		c.DB.Password,
-		c.DB.Host,
-		c.DB.Port,
-		c.DB.Name,
-	)
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.DB.Username, c.DB.Password),
+		Host:     fmt.Sprintf("%s:%d", c.DB.Host, c.DB.Port),
+		Path:     c.DB.Name,
+		RawQuery: url.Values{"sslmode": {c.DB.SSLMode}}.Encode(),
+	}
+
+	return u.String()
 }
 
 func (c *Config) MakeHTTPAddr() string {
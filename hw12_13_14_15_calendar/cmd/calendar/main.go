@@ -4,18 +4,20 @@ import (
 	"context"
 	"flag"
 	"log/slog"
+	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
 	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/app"
 	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/handlers/grpc"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/health"
 	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/logger"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/metrics"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/queue/amqp"
 	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/server/grpc"
 	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/server/http"
-	memorystorage "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/memory"
-	sqlstorage "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/sql"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/tracing"
 )
 
 var configFile string
@@ -35,34 +37,84 @@ func main() {
 	cfg := MustLoad(configFile)
 	l := logger.NewLogger(cfg.LogLevel)
 
+	if flag.Arg(0) == "migrate" {
+		if err := runMigrate(context.Background(), cfg, flag.Args()[1:]); err != nil {
+			l.Error("Migration failed", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+		return
+	}
+
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
 	defer cancel()
 
-	var storage app.Storage
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		Exporter:       tracing.Exporter(cfg.Tracing.Exporter),
+		Endpoint:       cfg.Tracing.Endpoint,
+		Insecure:       cfg.Tracing.Insecure,
+		ServiceName:    cfg.Tracing.ServiceName,
+		ServiceVersion: release,
+		SampleRatio:    cfg.Tracing.SampleRatio,
+		Output:         os.Stdout,
+	})
+	if err != nil {
+		l.Error("Unable to set up tracing", slog.String("error", err.Error()))
+		return
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
 
-	switch cfg.StorageType {
-	case MemoryStorageType:
-		storage = memorystorage.NewStorage()
-	case SQLStorageType:
-		dbConnectionString := cfg.MakeDBConnectionString()
-		err := sqlstorage.Migrate(dbConnectionString, false)
-		if err != nil {
-			l.Error("Unable to migrate database", slog.String("error", err.Error()))
-			return
+		if err := shutdownTracing(ctx); err != nil {
+			l.Error("Unable to flush traces", slog.String("error", err.Error()))
+		}
+	}()
+
+	var m *metrics.Metrics
+	if cfg.Metrics.Enabled {
+		m = metrics.New(metrics.Config{
+			Namespace: cfg.Metrics.Namespace,
+			Buckets:   cfg.Metrics.Buckets,
+			PerOwner:  cfg.Metrics.PerOwner,
+		})
+	}
+
+	h := health.New(cfg.Health.CheckTimeout)
+
+	storage, closeStorage, err := openStorage(ctx, cfg, m, h)
+	if err != nil {
+		l.Error("Unable to open storage", slog.String("error", err.Error()))
+		return
+	}
+	defer func() {
+		if err := closeStorage(); err != nil {
+			l.Error("Unable to close storage", slog.String("error", err.Error()))
 		}
-		dbPool, err := pgxpool.New(ctx, dbConnectionString)
+	}()
+
+	if cfg.Queue.Type == AMQPQueueType {
+		q, err := amqpqueue.New(l, cfg.Queue.MakeAMQPConfig())
 		if err != nil {
-			l.Error("Unable to connect to database", slog.String("error", err.Error()))
+			l.Error("Unable to connect to queue", slog.String("error", err.Error()))
 			return
 		}
-		defer dbPool.Close()
-		storage = sqlstorage.New(dbPool)
-	default:
-		l.Error("Unsupported storage type", slog.String("storage_type", cfg.StorageType))
+		defer q.Close()
+
+		h.Add("queue", q)
 	}
 
-	calendar := app.New(l, storage)
-	httpServer := internalhttp.NewServer(l, calendar, cfg.MakeHTTPAddr())
+	storage = tracing.NewStorage(storage)
+	if m != nil {
+		storage = metrics.NewStorage(storage, m)
+	}
+
+	weekStart, _ := app.ParseWeekday(cfg.Events.WeekStart)
+	calendar := app.New(l, storage,
+		app.WithOverlapPolicy(app.OverlapPolicy(cfg.Events.OverlapPolicy)),
+		app.WithWeekStart(weekStart))
+	httpServer := internalhttp.NewServer(l, calendar, cfg.MakeHTTPAddr(),
+		internalhttp.WithMetrics(m, cfg.Metrics.Path),
+		internalhttp.WithHealth(h))
 
 	go func() {
 		if err := httpServer.Start(); err != nil {
@@ -72,7 +124,9 @@ func main() {
 	}()
 
 	grpcHandler := grpchandler.NewEventHandler(calendar)
-	grpcServer := internalgrpc.NewServer(l, grpcHandler)
+	grpcServer := internalgrpc.NewServer(l, grpcHandler,
+		internalgrpc.WithMetrics(m),
+		internalgrpc.WithHealth(h, cfg.Health.WatchInterval))
 
 	go func() {
 		if err := grpcServer.Run(cfg.MakeGRPCAddr()); err != nil {
@@ -82,6 +136,11 @@ func main() {
 
 	<-ctx.Done()
 
+	h.Shutdown()
+	l.Info("Shutting down, readiness is NOT_SERVING",
+		slog.String("delay", cfg.Health.ShutdownDelay.String()))
+	time.Sleep(cfg.Health.ShutdownDelay)
+
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer shutdownCancel()
 
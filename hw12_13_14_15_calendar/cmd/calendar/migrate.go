@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/migrate"
+	sqlstorage "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/sql"
+	sqlitestorage "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/sqlite"
+	"github.com/pressly/goose/v3"
+)
+
+var errMigrateUsage = errors.New("usage: calendar migrate up|down|status|redo|to <version>")
+
+// runMigrate runs `calendar migrate` with the arguments following it.
+func runMigrate(ctx context.Context, cfg Config, args []string) error {
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+
+	db, m, err := openMigrator(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var results []*goose.MigrationResult
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		results, err = m.Up(ctx)
+	case args[0] == "down" && len(args) == 1:
+		results, err = m.Down(ctx)
+	case args[0] == "redo" && len(args) == 1:
+		results, err = m.Redo(ctx)
+	case args[0] == "to" && len(args) == 2:
+		version, parseErr := strconv.ParseInt(args[1], 10, 64)
+		if parseErr != nil {
+			return fmt.Errorf("invalid version %q: %w", args[1], errMigrateUsage)
+		}
+		results, err = m.To(ctx, version)
+	case args[0] == "status" && len(args) == 1:
+		return printMigrationStatus(ctx, m)
+	default:
+		return errMigrateUsage
+	}
+
+	for _, result := range results {
+		fmt.Println(result)
+	}
+	if err != nil {
+		return err
+	}
+
+	version, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Database version:", version)
+
+	return nil
+}
+
+func openMigrator(cfg Config) (*sql.DB, *migrate.Migrator, error) {
+	var (
+		db       *sql.DB
+		err      error
+		migrator func(*sql.DB) (*migrate.Migrator, error)
+	)
+
+	switch cfg.StorageType {
+	case SQLStorageType:
+		db, err = sqlstorage.OpenDB(cfg.MakeDBConnectionString())
+		migrator = sqlstorage.NewMigrator
+	case SQLiteStorageType:
+		db, err = sqlitestorage.Open(cfg.SQLite.Path)
+		migrator = sqlitestorage.NewMigrator
+	default:
+		return nil, nil, fmt.Errorf("storage type %q has no migrations", cfg.StorageType)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m, err := migrator(db)
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+
+	return db, m, nil
+}
+
+func printMigrationStatus(ctx context.Context, m *migrate.Migrator) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%-25s %s\n", "Applied At", "Migration")
+	for _, status := range statuses {
+		appliedAt := "Pending"
+		if status.State == goose.StateApplied {
+			appliedAt = status.AppliedAt.UTC().Format("2006-01-02 15:04:05 MST")
+		}
+		fmt.Printf("%-25s %s\n", appliedAt, path.Base(status.Source.Path))
+	}
+
+	return nil
+}
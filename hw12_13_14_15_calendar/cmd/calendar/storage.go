@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/app"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/health"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/metrics"
+	memorystorage "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/memory"
+	sqlstorage "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/sql"
+	sqlitestorage "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/sqlite"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/tracing"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// openStorage opens the configured storage and returns the function releasing it.
+// Statistics of database connections are registered in m if it is not nil, and the database and its migrations
+// are added to the readiness checks of h.
+func openStorage(
+	ctx context.Context,
+	cfg Config,
+	m *metrics.Metrics,
+	h *health.Health,
+) (app.Storage, func() error, error) {
+	switch cfg.StorageType {
+	case MemoryStorageType:
+		if cfg.Memory.Path == "" {
+			return memorystorage.NewStorage(), func() error { return nil }, nil
+		}
+
+		s, err := memorystorage.Open(memorystorage.Persistence{
+			Dir:           cfg.Memory.Path,
+			Sync:          memorystorage.SyncPolicy(cfg.Memory.Sync),
+			SyncInterval:  cfg.Memory.SyncInterval,
+			SnapshotEvery: cfg.Memory.SnapshotEvery,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open memory storage: %w", err)
+		}
+		return s, s.Close, nil
+	case SQLStorageType:
+		return openSQLStorage(ctx, cfg, m, h)
+	case SQLiteStorageType:
+		return openSQLiteStorage(ctx, cfg, m, h)
+	default:
+		return nil, nil, fmt.Errorf("unsupported storage type: %s", cfg.StorageType)
+	}
+}
+
+func openSQLStorage(
+	ctx context.Context,
+	cfg Config,
+	m *metrics.Metrics,
+	h *health.Health,
+) (app.Storage, func() error, error) {
+	dbConnectionString := cfg.MakeDBConnectionString()
+	if cfg.AutoMigrate {
+		if err := sqlstorage.Migrate(ctx, dbConnectionString); err != nil {
+			return nil, nil, fmt.Errorf("failed to migrate database: %w", err)
+		}
+	}
+
+	poolConfig, err := pgxpool.ParseConfig(dbConnectionString)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse database config: %w", err)
+	}
+	if cfg.Tracing.Exporter != string(tracing.ExporterNone) {
+		poolConfig.ConnConfig.Tracer = tracing.QueryTracer{}
+	}
+
+	dbPool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	if err := m.Register(metrics.NewPoolCollector(cfg.Metrics.Namespace, dbPool)); err != nil {
+		dbPool.Close()
+		return nil, nil, fmt.Errorf("failed to register pool metrics: %w", err)
+	}
+
+	// Migrations are checked over database/sql, borrowing connections from the pool.
+	db := stdlib.OpenDBFromPool(dbPool)
+	migrator, err := sqlstorage.NewMigrator(db)
+	if err != nil {
+		db.Close()
+		dbPool.Close()
+		return nil, nil, err
+	}
+	h.Add("database", health.CheckerFunc(dbPool.Ping))
+	h.Add("migrations", migrator)
+
+	return sqlstorage.New(dbPool), func() error {
+		err := db.Close()
+		dbPool.Close()
+		return err
+	}, nil
+}
+
+func openSQLiteStorage(
+	ctx context.Context,
+	cfg Config,
+	m *metrics.Metrics,
+	h *health.Health,
+) (app.Storage, func() error, error) {
+	db, err := sqlitestorage.Open(cfg.SQLite.Path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if cfg.AutoMigrate {
+		if err := sqlitestorage.Migrate(ctx, db); err != nil {
+			db.Close()
+			return nil, nil, fmt.Errorf("failed to migrate database: %w", err)
+		}
+	}
+	if err := m.Register(collectors.NewDBStatsCollector(db, SQLiteStorageType)); err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("failed to register database metrics: %w", err)
+	}
+
+	migrator, err := sqlitestorage.NewMigrator(db)
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+	h.Add("database", health.CheckerFunc(db.PingContext))
+	h.Add("migrations", migrator)
+
+	return sqlitestorage.New(db), db.Close, nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/config"
+	"github.com/ilyakaznacheev/cleanenv"
+)
+
+// The scheduler runs in its own process, so it supports only storages shared with the API.
+// An in-memory storage would be empty and never produce notifications.
+const (
+	SQLStorageType    = "sql"
+	SQLiteStorageType = "sqlite"
+)
+
+type Config struct {
+	LogLevel    string       `yaml:"log_level" env:"LOG_LEVEL" env-default:"info"`
+	StorageType string       `yaml:"storage_type" env:"STORAGE_TYPE" env-default:"sql"`
+	DB          Database     `yaml:"db"`
+	SQLite      SQLite       `yaml:"sqlite"`
+	Queue       config.Queue `yaml:"queue"`
+	Scheduler   Scheduler    `yaml:"scheduler" env-prefix:"SCHEDULER_"`
+	Retention   Retention    `yaml:"retention" env-prefix:"RETENTION_"`
+}
+
+type Database struct {
+	Host     string `yaml:"host" env:"DB_HOST" env-default:"localhost"`
+	Port     int    `yaml:"port" env:"DB_PORT" env-default:"5432"`
+	Name     string `yaml:"name" env:"DB_NAME" env-default:"postgres"`
+	Username string `yaml:"username" env:"DB_USERNAME" env-default:"postgres"`
+	Password string `yaml:"password" env:"DB_PASSWORD" env-default:""`
+	SSLMode  string `yaml:"sslmode" env:"DB_SSLMODE" env-default:"prefer"`
+}
+
+type SQLite struct {
+	Path string `yaml:"path" env:"SQLITE_PATH" env-default:"calendar.db"`
+}
+
+type Scheduler struct {
+	ScanInterval time.Duration `yaml:"scan_interval" env:"SCAN_INTERVAL" env-default:"1m"`
+}
+
+type Retention struct {
+	Period      time.Duration `yaml:"period" env:"PERIOD" env-default:"8760h"`
+	TrashPeriod time.Duration `yaml:"trash_period" env:"TRASH_PERIOD" env-default:"720h"`
+	Interval    time.Duration `yaml:"interval" env:"INTERVAL" env-default:"24h"`
+}
+
+func MustLoad(cfgFilePath string) Config {
+	var cfg Config
+
+	err := cleanenv.ReadConfig(cfgFilePath, &cfg)
+	if err != nil {
+		log.Fatalf("failed to read config: %v", err)
+	}
+
+	validateStorageType(cfg.StorageType)
+	validateSSLMode(cfg.DB.SSLMode)
+
+	if cfg.Scheduler.ScanInterval <= 0 {
+		log.Fatalf("scan interval must be positive, got %s", cfg.Scheduler.ScanInterval)
+	}
+
+	if cfg.Retention.Period <= 0 || cfg.Retention.Interval <= 0 {
+		log.Fatalf("retention period and interval must be positive, got %s and %s",
+			cfg.Retention.Period, cfg.Retention.Interval)
+	}
+
+	if cfg.Retention.TrashPeriod <= 0 {
+		log.Fatalf("retention trash period must be positive, got %s", cfg.Retention.TrashPeriod)
+	}
+
+	return cfg
+}
+
+func validateStorageType(storageType string) {
+	switch storageType {
+	case SQLStorageType, SQLiteStorageType:
+		return
+	default:
+		log.Fatalf("unknown storage type: %s", storageType)
+	}
+}
+
+func validateSSLMode(sslMode string) {
+	switch sslMode {
+	case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+		return
+	default:
+		log.Fatalf("unknown database sslmode: %s", sslMode)
+	}
+}
+
+func (c *Config) MakeDBConnectionString() string {
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.DB.Username, c.DB.Password),
+		Host:     fmt.Sprintf("%s:%d", c.DB.Host, c.DB.Port),
+		Path:     c.DB.Name,
+		RawQuery: url.Values{"sslmode": {c.DB.SSLMode}}.Encode(),
+	}
+
+	return u.String()
+}
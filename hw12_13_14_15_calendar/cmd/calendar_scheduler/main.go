@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/app"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/logger"
+	amqpqueue "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/queue/amqp"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/scheduler"
+	sqlstorage "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/sql"
+	sqlitestorage "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/sqlite"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var configFile string
+
+func init() {
+	flag.StringVar(&configFile, "config", "configs/scheduler_config.yaml", "Path to configuration file")
+}
+
+func main() {
+	flag.Parse()
+
+	if flag.Arg(0) == "version" {
+		printVersion()
+		return
+	}
+
+	cfg := MustLoad(configFile)
+	l := logger.NewLogger(cfg.LogLevel)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	defer cancel()
+
+	var storage app.Storage
+
+	switch cfg.StorageType {
+	case SQLStorageType:
+		dbPool, err := pgxpool.New(ctx, cfg.MakeDBConnectionString())
+		if err != nil {
+			l.Error("Unable to connect to database", slog.String("error", err.Error()))
+			return
+		}
+		defer dbPool.Close()
+		storage = sqlstorage.New(dbPool)
+	case SQLiteStorageType:
+		db, err := sqlitestorage.Open(cfg.SQLite.Path)
+		if err != nil {
+			l.Error("Unable to open database", slog.String("error", err.Error()))
+			return
+		}
+		defer db.Close()
+		storage = sqlitestorage.New(db)
+	default:
+		l.Error("Unsupported storage type", slog.String("storage_type", cfg.StorageType))
+		return
+	}
+
+	q, err := amqpqueue.New(l, cfg.Queue.MakeAMQPConfig())
+	if err != nil {
+		l.Error("Unable to connect to queue", slog.String("error", err.Error()))
+		return
+	}
+	defer q.Close()
+
+	s := scheduler.New(l, storage, q, cfg.Scheduler.ScanInterval)
+	c := scheduler.NewCleaner(l, storage, cfg.Retention.Period, cfg.Retention.TrashPeriod,
+		cfg.Retention.Interval)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		if err := s.Run(ctx); err != nil {
+			l.Error("Scheduler failed", slog.String("error", err.Error()))
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		if err := c.Run(ctx); err != nil {
+			l.Error("Cleaner failed", slog.String("error", err.Error()))
+		}
+	}()
+
+	wg.Wait()
+
+	l.Info("Application stopped")
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/config"
+	"github.com/ilyakaznacheev/cleanenv"
+)
+
+const (
+	LogChannel     = "log"
+	FileChannel    = "file"
+	WebhookChannel = "webhook"
+)
+
+type Config struct {
+	LogLevel string       `yaml:"log_level" env:"LOG_LEVEL" env-default:"info"`
+	Queue    config.Queue `yaml:"queue"`
+	Sender   Sender       `yaml:"sender" env-prefix:"SENDER_"`
+}
+
+type Sender struct {
+	Channels       []string      `yaml:"channels" env:"CHANNELS" env-default:"log"`
+	FilePath       string        `yaml:"file_path" env:"FILE_PATH" env-default:"logs/notifications.log"`
+	WebhookURL     string        `yaml:"webhook_url" env:"WEBHOOK_URL"`
+	WebhookTimeout time.Duration `yaml:"webhook_timeout" env:"WEBHOOK_TIMEOUT" env-default:"5s"`
+	Retry          Retry         `yaml:"retry" env-prefix:"RETRY_"`
+	StatsInterval  time.Duration `yaml:"stats_interval" env:"STATS_INTERVAL" env-default:"1m"`
+}
+
+type Retry struct {
+	Attempts       int           `yaml:"attempts" env:"ATTEMPTS" env-default:"3"`
+	InitialBackoff time.Duration `yaml:"initial_backoff" env:"INITIAL_BACKOFF" env-default:"1s"`
+	MaxBackoff     time.Duration `yaml:"max_backoff" env:"MAX_BACKOFF" env-default:"30s"`
+}
+
+func MustLoad(cfgFilePath string) Config {
+	var cfg Config
+
+	err := cleanenv.ReadConfig(cfgFilePath, &cfg)
+	if err != nil {
+		log.Fatalf("failed to read config: %v", err)
+	}
+
+	validateChannels(cfg.Sender)
+
+	return cfg
+}
+
+func validateChannels(cfg Sender) {
+	if len(cfg.Channels) == 0 {
+		log.Fatal("at least one sender channel must be configured")
+	}
+
+	for _, ch := range cfg.Channels {
+		switch ch {
+		case LogChannel, FileChannel:
+		case WebhookChannel:
+			if cfg.WebhookURL == "" {
+				log.Fatal("webhook_url is required for webhook channel")
+			}
+		default:
+			log.Fatalf("unknown sender channel: %s", ch)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/logger"
+	amqpqueue "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/queue/amqp"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/sender"
+)
+
+var configFile string
+
+func init() {
+	flag.StringVar(&configFile, "config", "configs/sender_config.yaml", "Path to configuration file")
+}
+
+func main() {
+	flag.Parse()
+
+	if flag.Arg(0) == "version" {
+		printVersion()
+		return
+	}
+
+	cfg := MustLoad(configFile)
+	l := logger.NewLogger(cfg.LogLevel)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	defer cancel()
+
+	channels := make([]sender.Channel, 0, len(cfg.Sender.Channels))
+	for _, ch := range cfg.Sender.Channels {
+		switch ch {
+		case LogChannel:
+			channels = append(channels, sender.NewLogChannel(l))
+		case FileChannel:
+			channels = append(channels, sender.NewFileChannel(cfg.Sender.FilePath))
+		case WebhookChannel:
+			channels = append(channels, sender.NewWebhookChannel(cfg.Sender.WebhookURL, cfg.Sender.WebhookTimeout))
+		}
+	}
+
+	q, err := amqpqueue.New(l, cfg.Queue.MakeAMQPConfig())
+	if err != nil {
+		l.Error("Unable to connect to queue", slog.String("error", err.Error()))
+		return
+	}
+	defer q.Close()
+
+	s := sender.New(l, q, sender.RetryPolicy{
+		Attempts:       cfg.Sender.Retry.Attempts,
+		InitialBackoff: cfg.Sender.Retry.InitialBackoff,
+		MaxBackoff:     cfg.Sender.Retry.MaxBackoff,
+	}, channels...)
+
+	go reportStats(ctx, l, s, cfg.Sender.StatsInterval)
+
+	if err := s.Run(ctx); err != nil {
+		l.Error("Sender failed", slog.String("error", err.Error()))
+	}
+
+	logStats(l, s.Stats())
+	l.Info("Application stopped")
+}
+
+func reportStats(ctx context.Context, l logger.Logger, s *sender.Sender, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			logStats(l, s.Stats())
+		}
+	}
+}
+
+func logStats(l logger.Logger, stats sender.Stats) {
+	l.Info("Sender stats",
+		slog.Int64("delivered", stats.Delivered),
+		slog.Int64("failed", stats.Failed))
+}
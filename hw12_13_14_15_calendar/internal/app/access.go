@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/identity"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+// ownerScope returns the owner a request is limited to: the calling user if known, otherwise ownerID.
+// Requests without a calling user come from trusted internal callers and are not restricted.
+func ownerScope(ctx context.Context, ownerID string) (string, error) {
+	caller, ok := identity.FromContext(ctx)
+	if !ok {
+		return ownerID, nil
+	}
+
+	if ownerID != "" && ownerID != caller {
+		return "", storage.ErrForbidden
+	}
+
+	return caller, nil
+}
+
+// authorize checks that the calling user may access the event.
+func (a *App) authorize(ctx context.Context, event *storage.Event) error {
+	caller, ok := identity.FromContext(ctx)
+	if !ok || event.OwnerID == caller {
+		return nil
+	}
+
+	a.logger.Info("Access to event forbidden",
+		slog.String("event_id", event.ID),
+		slog.String("user_id", caller))
+
+	return storage.ErrForbidden
+}
+
+// authorizeView checks that the calling user may see the event, attendees may see events they are invited to.
+func (a *App) authorizeView(ctx context.Context, event *storage.Event) error {
+	caller, ok := identity.FromContext(ctx)
+	if ok {
+		if _, invited := event.Attendee(caller); invited {
+			return nil
+		}
+	}
+
+	return a.authorize(ctx, event)
+}
+
+// authorizeByID loads the event and checks that the calling user may access it.
+func (a *App) authorizeByID(ctx context.Context, id string) error {
+	if _, ok := identity.FromContext(ctx); !ok {
+		return nil
+	}
+
+	event, err := a.storage.GetEvent(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return a.authorize(ctx, event)
+}
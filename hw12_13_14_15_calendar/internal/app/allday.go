@@ -0,0 +1,50 @@
+package app
+
+import (
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+// allDayMargin widens period queries to storage. Dates of all-day events are stored as UTC midnights,
+// while the same dates start up to 14 hours earlier or 12 hours later in other time zones.
+const allDayMargin = 24 * time.Hour
+
+// normalizeAllDay turns times of an all-day event into UTC midnights of its dates. Dates are taken
+// in the location of the times, an end within a date includes that date, so 00:00-23:59 is a single day.
+func normalizeAllDay(start, end time.Time, exceptions []time.Time) (time.Time, time.Time, []time.Time) {
+	start = dateOf(start)
+	endDate := dateOf(end)
+	if !isMidnight(end) {
+		endDate = endDate.AddDate(0, 0, 1)
+	}
+	if endDate.Equal(start) {
+		endDate = start.AddDate(0, 0, 1)
+	}
+
+	if len(exceptions) == 0 {
+		return start, endDate, exceptions
+	}
+
+	normalized := make([]time.Time, len(exceptions))
+	for i, ex := range exceptions {
+		normalized[i] = dateOf(ex)
+	}
+
+	return start, endDate, normalized
+}
+
+// dateOf returns UTC midnight of the date of t in its location.
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func isMidnight(t time.Time) bool {
+	return t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0
+}
+
+// overlapsPeriod reports whether the occurrence intersects [start, end).
+// Occurrences without duration intersect the period if they start in it.
+func overlapsPeriod(event storage.Event, start, end time.Time) bool {
+	return event.StartTime.Before(end) && (event.EndTime.After(start) || !event.StartTime.Before(start))
+}
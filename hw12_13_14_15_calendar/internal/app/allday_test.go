@@ -0,0 +1,154 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+func TestNormalizeAllDay(t *testing.T) {
+	utcDate := func(day int) time.Time {
+		return time.Date(2026, time.October, day, 0, 0, 0, 0, time.UTC)
+	}
+	moscow := mustLoadLocation(t, "Europe/Moscow")
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		wantStart  time.Time
+		wantEnd    time.Time
+	}{
+		{
+			name:      "exclusive midnight end",
+			start:     utcDate(18),
+			end:       utcDate(20),
+			wantStart: utcDate(18),
+			wantEnd:   utcDate(20),
+		},
+		{
+			name:      "faked 00:00-23:59 day",
+			start:     utcDate(18),
+			end:       utcDate(18).Add(23*time.Hour + 59*time.Minute),
+			wantStart: utcDate(18),
+			wantEnd:   utcDate(19),
+		},
+		{
+			name:      "same start and end",
+			start:     utcDate(18),
+			end:       utcDate(18),
+			wantStart: utcDate(18),
+			wantEnd:   utcDate(19),
+		},
+		{
+			name:      "dates are taken in the location of times",
+			start:     time.Date(2026, time.October, 18, 1, 0, 0, 0, moscow),
+			end:       time.Date(2026, time.October, 19, 0, 0, 0, 0, moscow),
+			wantStart: utcDate(18),
+			wantEnd:   utcDate(19),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, _ := normalizeAllDay(tt.start, tt.end, nil)
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+				t.Errorf("normalizeAllDay() = %v, %v, want %v, %v", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestApp_AllDayEvents(t *testing.T) {
+	ctx := context.Background()
+	a := newTestApp()
+	moscow := mustLoadLocation(t, "Europe/Moscow")
+	newYork := mustLoadLocation(t, "America/New_York")
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+
+	holiday, err := a.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+		Title:     "Holiday",
+		StartTime: time.Date(2026, time.October, 19, 0, 0, 0, 0, moscow),
+		EndTime:   time.Date(2026, time.October, 19, 23, 59, 0, 0, moscow),
+		OwnerID:   ownerID,
+		AllDay:    true,
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v, want nil", err)
+	}
+
+	// All-day events do not conflict with timed events on the same date.
+	meeting := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	createEventsAt(t, a, meeting)
+
+	for _, loc := range []*time.Location{time.UTC, moscow, newYork, tokyo} {
+		day := time.Date(2026, time.October, 19, 12, 0, 0, 0, loc)
+		events, err := a.GetEventsForDay(ctx, day)
+		if err != nil {
+			t.Fatalf("GetEventsForDay() error = %v, want nil", err)
+		}
+
+		var got *storage.Event
+		for i := range events {
+			if events[i].ID == holiday.ID {
+				got = &events[i]
+			}
+		}
+		if got == nil {
+			t.Errorf("GetEventsForDay(%v) = %v, want the holiday", day, eventStarts(events))
+			continue
+		}
+		if want := startOfDay(day); !got.StartTime.Equal(want) || !got.EndTime.Equal(want.AddDate(0, 0, 1)) {
+			t.Errorf("holiday in %v = %v - %v, want the whole date", loc, got.StartTime, got.EndTime)
+		}
+
+		if events, _ := a.GetEventsForDay(ctx, day.AddDate(0, 0, -1)); len(events) != 0 {
+			t.Errorf("GetEventsForDay(%v) = %v, want none", day.AddDate(0, 0, -1), eventStarts(events))
+		}
+	}
+
+	busy, err := a.FreeBusy(ctx, []string{ownerID}, meeting.Add(-12*time.Hour), meeting.Add(12*time.Hour))
+	if err != nil {
+		t.Fatalf("FreeBusy() error = %v, want nil", err)
+	}
+	if len(busy) != 1 || !busy[0].Start.Equal(meeting) {
+		t.Errorf("FreeBusy() = %+v, want only the meeting", busy)
+	}
+}
+
+func TestApp_GetEventsByPeriodIncludesMultiDayEvents(t *testing.T) {
+	ctx := context.Background()
+	a := newTestApp()
+
+	start := time.Date(2026, time.October, 17, 10, 0, 0, 0, time.UTC)
+	if _, err := a.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+		Title: "Conference", StartTime: start, EndTime: start.AddDate(0, 0, 4), OwnerID: ownerID,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	series := time.Date(2026, time.October, 12, 22, 0, 0, 0, time.UTC)
+	if _, err := a.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+		Title: "Night shift", StartTime: series, EndTime: series.Add(8 * time.Hour), OwnerID: otherOwnerID,
+		RecurrenceRule: stringPtr("freq=daily"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := a.GetEventsForDay(ctx, time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetEventsForDay() error = %v, want nil", err)
+	}
+
+	got := eventStarts(events)
+	want := []time.Time{start, series.AddDate(0, 0, 6), series.AddDate(0, 0, 7)}
+	if len(got) != len(want) {
+		t.Fatalf("GetEventsForDay() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("GetEventsForDay()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
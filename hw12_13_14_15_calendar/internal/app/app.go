@@ -6,44 +6,130 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/ical"
 	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/logger"
 	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
 )
 
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
+
 type App struct {
-	logger  logger.Logger
-	storage Storage
+	logger        logger.Logger
+	storage       Storage
+	overlapPolicy OverlapPolicy
+	weekStart     time.Weekday
+}
+
+type Option func(*App)
+
+func WithOverlapPolicy(policy OverlapPolicy) Option {
+	return func(a *App) {
+		a.overlapPolicy = policy
+	}
 }
 
 type Storage interface {
 	CreateEvent(ctx context.Context, params storage.CreateOrUpdateEventParams) (*storage.Event, error)
 	GetEvent(ctx context.Context, id string) (*storage.Event, error)
-	UpdateEvent(ctx context.Context, event storage.Event) error
+	UpdateEvent(ctx context.Context, event storage.Event) (*storage.Event, error)
 	DeleteEvent(ctx context.Context, id string) error
-	GetAllEvents(ctx context.Context) ([]storage.Event, error)
-	GetEventsByPeriod(ctx context.Context, start, end time.Time) ([]storage.Event, error)
+	GetDeletedEvent(ctx context.Context, id string) (*storage.Event, error)
+	GetDeletedEvents(ctx context.Context, ownerID string) ([]storage.Event, error)
+	RestoreEvent(ctx context.Context, id string) (*storage.Event, error)
+	PurgeDeletedEvents(ctx context.Context, t time.Time) (int64, error)
+	AddAttendee(ctx context.Context, eventID, userID string) error
+	RemoveAttendee(ctx context.Context, eventID, userID string) error
+	SetAttendeeStatus(ctx context.Context, eventID, userID string, status storage.AttendeeStatus) error
+	ListEvents(ctx context.Context, query storage.ListEventsQuery) (storage.EventPage, error)
+	GetEventsByPeriod(ctx context.Context, ownerID string, start, end time.Time) ([]storage.Event, error)
+	GetOverlappingEvents(ctx context.Context, ownerID string, start, end time.Time) ([]storage.Event, error)
+	GetBusyEvents(ctx context.Context, userIDs []string, start, end time.Time) ([]storage.Event, error)
+	GetTimeZone(ctx context.Context, userID string) (string, error)
+	SetTimeZone(ctx context.Context, userID, timeZone string) error
+	GetEventsToNotify(ctx context.Context, from, to time.Time) ([]storage.Event, error)
+	DeleteEventsBefore(ctx context.Context, t time.Time) (int64, error)
 }
 
 type Application interface {
 	CreateEvent(ctx context.Context, param storage.CreateOrUpdateEventParams) (*storage.Event, error)
-	UpdateEvent(ctx context.Context, event storage.Event) error
+	UpdateEvent(ctx context.Context, event storage.Event) (*storage.Event, error)
 	DeleteEvent(ctx context.Context, id string) error
+	RestoreEvent(ctx context.Context, id string) (*storage.Event, error)
+	ListDeletedEvents(ctx context.Context) ([]storage.Event, error)
+	InviteAttendee(ctx context.Context, eventID, userID string) error
+	RemoveAttendee(ctx context.Context, eventID, userID string) error
+	RespondToEvent(ctx context.Context, eventID, userID string, status storage.AttendeeStatus) error
 	GetEvent(ctx context.Context, id string) (*storage.Event, error)
-	GetAllEvents(ctx context.Context) ([]storage.Event, error)
+	ListEvents(ctx context.Context, query storage.ListEventsQuery) (storage.EventPage, error)
 	GetEventsByPeriod(ctx context.Context, start, end time.Time) ([]storage.Event, error)
 	GetEventsForDay(ctx context.Context, day time.Time) ([]storage.Event, error)
-	GetEventsForWeek(ctx context.Context, weekStart time.Time) ([]storage.Event, error)
+	GetEventsForWeek(ctx context.Context, day time.Time) ([]storage.Event, error)
+	GetEventsForWeekStarting(ctx context.Context, day time.Time, weekStart time.Weekday) ([]storage.Event, error)
 	GetEventsForMonth(ctx context.Context, monthStart time.Time) ([]storage.Event, error)
+	FreeBusy(ctx context.Context, ownerIDs []string, from, to time.Time) ([]Interval, error)
+	FindSlots(ctx context.Context, query SlotQuery) ([]Interval, error)
+	ResolveLocation(ctx context.Context, name string) (*time.Location, error)
+	GetTimeZone(ctx context.Context, userID string) (string, error)
+	SetTimeZone(ctx context.Context, userID, name string) error
+	ExportEvents(ctx context.Context, ownerID string, start, end time.Time) ([]storage.Event, error)
+	ImportEvents(ctx context.Context, ownerID string, events []ical.Event) (ImportResult, error)
 }
 
-func New(logger logger.Logger, storage Storage) *App {
-	return &App{
-		logger:  logger,
-		storage: storage,
+func New(logger logger.Logger, storage Storage, opts ...Option) *App {
+	a := &App{
+		logger:        logger,
+		storage:       storage,
+		overlapPolicy: OverlapReject,
+		weekStart:     time.Monday,
 	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
 }
 
 func (a *App) CreateEvent(ctx context.Context, param storage.CreateOrUpdateEventParams) (*storage.Event, error) {
+	rule, err := normalizeRecurrenceRule(param.RecurrenceRule)
+	if err != nil {
+		a.logger.Info("Invalid recurrence rule", slog.String("error", err.Error()))
+		return nil, err
+	}
+	param.RecurrenceRule = rule
+
+	ownerID, err := ownerScope(ctx, param.OwnerID)
+	if err != nil {
+		a.logger.Info("Event of another owner can not be created", slog.String("owner_id", param.OwnerID))
+		return nil, err
+	}
+	param.OwnerID = ownerID
+
+	if param.TimeZone, err = a.seriesTimeZone(ctx, param.OwnerID, param.TimeZone, param.AllDay); err != nil {
+		return nil, err
+	}
+
+	if param.AllDay {
+		param.StartTime, param.EndTime, param.RecurrenceExceptions = normalizeAllDay(
+			param.StartTime, param.EndTime, param.RecurrenceExceptions)
+	}
+
+	if err := a.validateEvent(ctx, storage.Event{
+		Title:                param.Title,
+		StartTime:            param.StartTime,
+		EndTime:              param.EndTime,
+		OwnerID:              param.OwnerID,
+		RecurrenceRule:       param.RecurrenceRule,
+		RecurrenceExceptions: param.RecurrenceExceptions,
+		AllDay:               param.AllDay,
+		TimeZone:             param.TimeZone,
+	}); err != nil {
+		return nil, err
+	}
+
 	event, err := a.storage.CreateEvent(ctx, param)
 	if err != nil {
 		if errors.Is(err, storage.ErrEventAlreadyExists) {
@@ -56,20 +142,96 @@ func (a *App) CreateEvent(ctx context.Context, param storage.CreateOrUpdateEvent
 	return event, err
 }
 
-func (a *App) UpdateEvent(ctx context.Context, event storage.Event) error {
-	err := a.storage.UpdateEvent(ctx, event)
+// UpdateEvent replaces the event and returns it with the new version.
+// A non-zero event.Version makes the update conditional on the stored version.
+func (a *App) UpdateEvent(ctx context.Context, event storage.Event) (*storage.Event, error) {
+	rule, err := normalizeRecurrenceRule(event.RecurrenceRule)
+	if err != nil {
+		a.logger.Info("Invalid recurrence rule", slog.String("error", err.Error()))
+		return nil, err
+	}
+	event.RecurrenceRule = rule
+
+	if err := a.authorizeByID(ctx, event.ID); err != nil {
+		return nil, a.accessError("Failed to update event", err)
+	}
+
+	ownerID, err := ownerScope(ctx, event.OwnerID)
+	if err != nil {
+		a.logger.Info("Event can not be transferred to another owner", slog.String("event_id", event.ID))
+		return nil, err
+	}
+	event.OwnerID = ownerID
+
+	if event.TimeZone, err = a.seriesTimeZone(ctx, event.OwnerID, event.TimeZone, event.AllDay); err != nil {
+		return nil, err
+	}
+
+	if event.AllDay {
+		event.StartTime, event.EndTime, event.RecurrenceExceptions = normalizeAllDay(
+			event.StartTime, event.EndTime, event.RecurrenceExceptions)
+	}
+
+	if err := a.validateEvent(ctx, event); err != nil {
+		return nil, err
+	}
+
+	updated, err := a.storage.UpdateEvent(ctx, event)
 	if err != nil {
 		if errors.Is(err, storage.ErrEventNotFound) {
 			a.logger.Info("Event not found", slog.String("error", err.Error()))
 		}
 
+		if errors.Is(err, storage.ErrVersionConflict) {
+			a.logger.Info("Event version conflict",
+				slog.String("event_id", event.ID),
+				slog.Int64("expected_version", event.Version))
+			return nil, err
+		}
+
 		a.logger.Error("Failed to update event", slog.String("error", err.Error()))
 	}
 
+	return updated, err
+}
+
+func (a *App) validateEvent(ctx context.Context, event storage.Event) error {
+	if err := validateInterval(event.StartTime, event.EndTime); err != nil {
+		a.logger.Info("Invalid event interval", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := a.checkOverlap(ctx, event); err != nil {
+		if errors.Is(err, storage.ErrDateBusy) {
+			a.logger.Info("Date is busy", slog.String("error", err.Error()))
+		} else {
+			a.logger.Error("Failed to check event overlap", slog.String("error", err.Error()))
+		}
+		return err
+	}
+
+	return nil
+}
+
+// accessError logs an error of loading an event for access check.
+func (a *App) accessError(msg string, err error) error {
+	switch {
+	case errors.Is(err, storage.ErrForbidden):
+	case errors.Is(err, storage.ErrEventNotFound):
+		a.logger.Info("Event not found", slog.String("error", err.Error()))
+	default:
+		a.logger.Error(msg, slog.String("error", err.Error()))
+	}
+
 	return err
 }
 
+// DeleteEvent moves the event to the trash, it can be restored until the retention job purges it.
 func (a *App) DeleteEvent(ctx context.Context, id string) error {
+	if err := a.authorizeByID(ctx, id); err != nil {
+		return a.accessError("Failed to delete event", err)
+	}
+
 	err := a.storage.DeleteEvent(ctx, id)
 	if err != nil {
 		if errors.Is(err, storage.ErrEventNotFound) {
@@ -82,6 +244,48 @@ func (a *App) DeleteEvent(ctx context.Context, id string) error {
 	return err
 }
 
+// RestoreEvent moves the event out of the trash. The restored event is checked for overlaps
+// like a new one, since its time may have been taken while it was in the trash.
+func (a *App) RestoreEvent(ctx context.Context, id string) (*storage.Event, error) {
+	event, err := a.storage.GetDeletedEvent(ctx, id)
+	if err != nil {
+		return nil, a.accessError("Failed to restore event", err)
+	}
+
+	if err := a.authorize(ctx, event); err != nil {
+		return nil, err
+	}
+
+	if err := a.validateEvent(ctx, *event); err != nil {
+		return nil, err
+	}
+
+	restored, err := a.storage.RestoreEvent(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrEventNotFound) {
+			a.logger.Info("Event not found in trash", slog.String("event_id", id))
+			return nil, err
+		}
+
+		a.logger.Error("Failed to restore event", slog.String("error", err.Error()))
+	}
+
+	return restored, err
+}
+
+// ListDeletedEvents returns events in the trash of the calling user, or of all owners for internal callers.
+func (a *App) ListDeletedEvents(ctx context.Context) ([]storage.Event, error) {
+	ownerID, _ := ownerScope(ctx, "")
+
+	events, err := a.storage.GetDeletedEvents(ctx, ownerID)
+	if err != nil {
+		a.logger.Error("Failed to list deleted events", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return events, nil
+}
+
 func (a *App) GetEvent(ctx context.Context, id string) (*storage.Event, error) {
 	event, err := a.storage.GetEvent(ctx, id)
 	if err != nil {
@@ -90,51 +294,160 @@ func (a *App) GetEvent(ctx context.Context, id string) (*storage.Event, error) {
 		}
 
 		a.logger.Error("Failed to get event", slog.String("error", err.Error()))
+		return nil, err
 	}
 
-	return event, err
+	if err := a.authorizeView(ctx, event); err != nil {
+		return nil, err
+	}
+
+	return event, nil
 }
 
-func (a *App) GetAllEvents(ctx context.Context) ([]storage.Event, error) {
-	events, err := a.storage.GetAllEvents(ctx)
+// ListEvents returns a page of stored events of the calling user, or of all owners for internal callers.
+// Empty sort field and page size are replaced with defaults.
+func (a *App) ListEvents(ctx context.Context, query storage.ListEventsQuery) (storage.EventPage, error) {
+	ownerID, err := ownerScope(ctx, query.OwnerID)
 	if err != nil {
-		a.logger.Error("Failed to get all events", slog.String("error", err.Error()))
+		a.logger.Info("Events of another owner can not be listed", slog.String("owner_id", query.OwnerID))
+		return storage.EventPage{}, err
+	}
+	query.OwnerID = ownerID
+
+	if query.SortBy == "" {
+		query.SortBy = storage.SortByStartTime
+	}
+	if query.PageSize == 0 {
+		query.PageSize = DefaultPageSize
+	}
+	query.PageSize = min(query.PageSize, MaxPageSize)
+
+	if err := query.Validate(); err != nil {
+		a.logger.Info("Invalid list query", slog.String("error", err.Error()))
+		return storage.EventPage{}, err
 	}
 
-	return events, err
+	page, err := a.storage.ListEvents(ctx, query)
+	if err != nil {
+		if errors.Is(err, storage.ErrInvalidCursor) {
+			a.logger.Info("Invalid page cursor", slog.String("error", err.Error()))
+			return storage.EventPage{}, err
+		}
+
+		a.logger.Error("Failed to list events", slog.String("error", err.Error()))
+	}
+
+	return page, err
 }
 
+// GetEventsByPeriod returns occurrences of events the calling user owns or attends intersecting the period,
+// or of events of all owners for internal callers. Events are rendered in the location of start,
+// so all-day events fall on their dates in that location.
 func (a *App) GetEventsByPeriod(ctx context.Context, start, end time.Time) ([]storage.Event, error) {
-	events, err := a.storage.GetEventsByPeriod(ctx, start, end)
+	ownerID, _ := ownerScope(ctx, "")
+
+	events, err := a.storage.GetEventsByPeriod(ctx, ownerID, start.Add(-allDayMargin), end.Add(allDayMargin))
+	if err == nil {
+		events, err = occurrencesInPeriod(events, start, end)
+	}
+
 	if err != nil {
 		a.logger.Error("Failed to get events by period",
 			slog.String("start", start.String()),
 			slog.String("end", end.String()),
 			slog.String("error", err.Error()))
+		return nil, err
 	}
 
 	return events, nil
 }
 
+// GetEventsForDay returns events of the calendar day of the date in its location.
+// Boundaries are local midnights, so a day may be 23 or 25 hours long around DST changes.
 func (a *App) GetEventsForDay(ctx context.Context, day time.Time) ([]storage.Event, error) {
-	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	start := startOfDay(day)
 	end := start.AddDate(0, 0, 1)
 
 	return a.GetEventsByPeriod(ctx, start, end)
 }
 
-func (a *App) GetEventsForWeek(ctx context.Context, weekStart time.Time) ([]storage.Event, error) {
-	for weekStart.Weekday() != time.Monday {
-		weekStart = weekStart.AddDate(0, 0, -1)
-	}
-	end := weekStart.AddDate(0, 0, 7)
-
-	return a.GetEventsByPeriod(ctx, weekStart, end)
+// GetEventsForWeek returns events of the week containing the date, weeks start on the configured day,
+// Monday by default.
+func (a *App) GetEventsForWeek(ctx context.Context, day time.Time) ([]storage.Event, error) {
+	return a.GetEventsForWeekStarting(ctx, day, a.weekStart)
 }
 
+// GetEventsForMonth returns events of the calendar month of the date in its location.
 func (a *App) GetEventsForMonth(ctx context.Context, monthStart time.Time) ([]storage.Event, error) {
 	start := time.Date(monthStart.Year(), monthStart.Month(), 1, 0, 0, 0, 0, monthStart.Location())
 	end := start.AddDate(0, 1, 0)
 
 	return a.GetEventsByPeriod(ctx, start, end)
 }
+
+// ExportEvents returns events of the period the owner owns or attends without expanding recurring ones.
+// Events of all owners are returned to internal callers if ownerID is empty.
+func (a *App) ExportEvents(ctx context.Context, ownerID string, start, end time.Time) ([]storage.Event, error) {
+	scope, err := ownerScope(ctx, ownerID)
+	if err != nil {
+		a.logger.Info("Events of another owner can not be exported", slog.String("owner_id", ownerID))
+		return nil, err
+	}
+
+	events, err := a.storage.GetEventsByPeriod(ctx, scope, start, end)
+	if err != nil {
+		a.logger.Error("Failed to export events", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return events, nil
+}
+
+type ImportResult struct {
+	Imported int
+	Errors   []ImportError
+}
+
+// ImportError describes an event which was not imported, Index is the position of the event in the calendar.
+type ImportError struct {
+	Index int
+	UID   string
+	Err   error
+}
+
+// ImportEvents creates decoded events of the owner one by one, skipping events which failed to decode.
+func (a *App) ImportEvents(ctx context.Context, ownerID string, events []ical.Event) (ImportResult, error) {
+	var result ImportResult
+
+	scope, err := ownerScope(ctx, ownerID)
+	if err != nil {
+		a.logger.Info("Events of another owner can not be imported", slog.String("owner_id", ownerID))
+		return result, err
+	}
+	ownerID = scope
+
+	for i, event := range events {
+		err := event.Err
+		if err == nil {
+			event.Params.OwnerID = ownerID
+			_, err = a.CreateEvent(ctx, event.Params)
+		}
+
+		if err != nil {
+			result.Errors = append(result.Errors, ImportError{Index: i, UID: event.UID, Err: err})
+			continue
+		}
+		result.Imported++
+	}
+
+	a.logger.Info("Events imported",
+		slog.String("owner_id", ownerID),
+		slog.Int("imported", result.Imported),
+		slog.Int("failed", len(result.Errors)))
+
+	return result, nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,521 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/ical"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/identity"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/recurrence"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+	memorystorage "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/memory"
+)
+
+const (
+	ownerID      = "123e4567-e89b-12d3-a456-426614174000"
+	otherOwnerID = "223e4567-e89b-12d3-a456-426614174000"
+)
+
+func newTestApp() *App {
+	return New(slog.New(slog.NewTextHandler(io.Discard, nil)), memorystorage.NewStorage())
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestApp_GetEventsForWeekExpandsRecurringEvents(t *testing.T) {
+	ctx := context.Background()
+	a := newTestApp()
+
+	monday := time.Date(2025, time.May, 5, 10, 0, 0, 0, time.UTC)
+
+	_, err := a.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+		Title:                "Standup",
+		StartTime:            monday,
+		EndTime:              monday.Add(15 * time.Minute),
+		OwnerID:              ownerID,
+		RecurrenceRule:       stringPtr("freq=weekly;byday=mo,we,fr"),
+		RecurrenceExceptions: []time.Time{monday.AddDate(0, 0, 9)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = a.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+		Title:     "Retro",
+		StartTime: monday.AddDate(0, 0, 11).Add(time.Hour),
+		EndTime:   monday.AddDate(0, 0, 11).Add(2 * time.Hour),
+		OwnerID:   ownerID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := a.GetEventsForWeek(ctx, monday.AddDate(0, 0, 9))
+	if err != nil {
+		t.Fatalf("GetEventsForWeek() error = %v, want nil", err)
+	}
+
+	want := []struct {
+		title string
+		start time.Time
+	}{
+		{"Standup", monday.AddDate(0, 0, 7)},
+		{"Standup", monday.AddDate(0, 0, 11)},
+		{"Retro", monday.AddDate(0, 0, 11).Add(time.Hour)},
+	}
+
+	if len(events) != len(want) {
+		t.Fatalf("GetEventsForWeek() = %+v, want %d events", events, len(want))
+	}
+
+	for i, w := range want {
+		if events[i].Title != w.title || !events[i].StartTime.Equal(w.start) {
+			t.Errorf("events[%d] = %s at %v, want %s at %v", i, events[i].Title, events[i].StartTime, w.title, w.start)
+		}
+	}
+
+	if *events[0].RecurrenceRule != "FREQ=WEEKLY;BYDAY=MO,WE,FR" {
+		t.Errorf("RecurrenceRule = %q, want normalized rule", *events[0].RecurrenceRule)
+	}
+	if events[0].EndTime.Sub(events[0].StartTime) != 15*time.Minute {
+		t.Errorf("occurrence duration = %v, want 15m", events[0].EndTime.Sub(events[0].StartTime))
+	}
+}
+
+func TestApp_CreateEventInvalidRecurrenceRule(t *testing.T) {
+	a := newTestApp()
+	start := time.Date(2025, time.May, 5, 10, 0, 0, 0, time.UTC)
+
+	_, err := a.CreateEvent(context.Background(), storage.CreateOrUpdateEventParams{
+		Title:          "Broken",
+		StartTime:      start,
+		EndTime:        start.Add(time.Hour),
+		OwnerID:        ownerID,
+		RecurrenceRule: stringPtr("FREQ=SOMETIMES"),
+	})
+	if !errors.Is(err, recurrence.ErrInvalidRule) {
+		t.Errorf("CreateEvent() error = %v, want %v", err, recurrence.ErrInvalidRule)
+	}
+}
+
+func TestApp_CreateEventInvertedInterval(t *testing.T) {
+	a := newTestApp()
+	start := time.Date(2025, time.May, 5, 10, 0, 0, 0, time.UTC)
+
+	_, err := a.CreateEvent(context.Background(), storage.CreateOrUpdateEventParams{
+		Title:     "Inverted",
+		StartTime: start,
+		EndTime:   start.Add(-time.Hour),
+		OwnerID:   ownerID,
+	})
+	if !errors.Is(err, storage.ErrInvalidInterval) {
+		t.Errorf("CreateEvent() error = %v, want %v", err, storage.ErrInvalidInterval)
+	}
+}
+
+func TestApp_OverlapPolicy(t *testing.T) {
+	start := time.Date(2025, time.May, 5, 10, 0, 0, 0, time.UTC)
+	daily := "FREQ=DAILY"
+
+	existing := []storage.CreateOrUpdateEventParams{
+		{Title: "Meeting", StartTime: start, EndTime: start.Add(time.Hour), OwnerID: ownerID},
+		{
+			Title:          "Standup",
+			StartTime:      start.Add(-24 * time.Hour).Add(-time.Hour),
+			EndTime:        start.Add(-24 * time.Hour).Add(-45 * time.Minute),
+			OwnerID:        ownerID,
+			RecurrenceRule: &daily,
+		},
+		{Title: "Foreign", StartTime: start.Add(2 * time.Hour), EndTime: start.Add(3 * time.Hour), OwnerID: otherOwnerID},
+	}
+
+	tests := []struct {
+		name    string
+		policy  OverlapPolicy
+		start   time.Time
+		end     time.Time
+		rule    *string
+		wantErr error
+	}{
+		{"Overlapping", OverlapReject, start.Add(30 * time.Minute), start.Add(90 * time.Minute), nil, storage.ErrDateBusy},
+		{"Touching", OverlapReject, start.Add(time.Hour), start.Add(2 * time.Hour), nil, nil},
+		{"Other owner", OverlapReject, start.Add(2 * time.Hour), start.Add(3 * time.Hour), nil, nil},
+		{
+			"Occurrence", OverlapReject, start.AddDate(0, 0, 3).Add(-50 * time.Minute), start.AddDate(0, 0, 3), nil,
+			storage.ErrDateBusy,
+		},
+		{
+			"Recurring", OverlapReject, start.AddDate(0, 0, -4).Add(-75 * time.Minute),
+			start.AddDate(0, 0, -4).Add(-55 * time.Minute), &daily, storage.ErrDateBusy,
+		},
+		{"Warn", OverlapWarn, start, start.Add(time.Hour), nil, nil},
+		{"Allow", OverlapAllow, start, start.Add(time.Hour), nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			a := New(slog.New(slog.NewTextHandler(io.Discard, nil)), memorystorage.NewStorage(),
+				WithOverlapPolicy(tt.policy))
+
+			for _, e := range existing {
+				if _, err := a.CreateEvent(ctx, e); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			_, err := a.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+				Title:          "New",
+				StartTime:      tt.start,
+				EndTime:        tt.end,
+				OwnerID:        ownerID,
+				RecurrenceRule: tt.rule,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreateEvent() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApp_UpdateEventIgnoresItself(t *testing.T) {
+	ctx := context.Background()
+	a := newTestApp()
+	start := time.Date(2025, time.May, 5, 10, 0, 0, 0, time.UTC)
+
+	event, err := a.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+		Title:     "Meeting",
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		OwnerID:   ownerID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	event.EndTime = start.Add(2 * time.Hour)
+	if _, err := a.UpdateEvent(ctx, *event); err != nil {
+		t.Errorf("UpdateEvent() error = %v, want nil", err)
+	}
+}
+
+func TestApp_RestoreEvent(t *testing.T) {
+	a := newTestApp()
+	start := time.Date(2025, time.May, 5, 10, 0, 0, 0, time.UTC)
+
+	ctx := identity.NewContext(context.Background(), ownerID)
+	otherCtx := identity.NewContext(context.Background(), otherOwnerID)
+
+	event, err := a.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+		Title: "Meeting", StartTime: start, EndTime: start.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.DeleteEvent(ctx, event.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if trash, err := a.ListDeletedEvents(otherCtx); err != nil || len(trash) != 0 {
+		t.Errorf("ListDeletedEvents() of another owner = %+v, %v, want no events", trash, err)
+	}
+	if trash, err := a.ListDeletedEvents(ctx); err != nil || len(trash) != 1 {
+		t.Errorf("ListDeletedEvents() = %+v, %v, want the deleted event", trash, err)
+	}
+
+	if _, err := a.RestoreEvent(otherCtx, event.ID); !errors.Is(err, storage.ErrForbidden) {
+		t.Errorf("RestoreEvent() by another owner error = %v, want %v", err, storage.ErrForbidden)
+	}
+
+	if _, err := a.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+		Title: "Replacement", StartTime: start, EndTime: start.Add(time.Hour),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := a.RestoreEvent(ctx, event.ID); !errors.Is(err, storage.ErrDateBusy) {
+		t.Errorf("RestoreEvent() over another event error = %v, want %v", err, storage.ErrDateBusy)
+	}
+
+	if _, err := a.RestoreEvent(ctx, "nonexistent-id"); !errors.Is(err, storage.ErrEventNotFound) {
+		t.Errorf("RestoreEvent() error = %v, want %v", err, storage.ErrEventNotFound)
+	}
+}
+
+func TestApp_Attendees(t *testing.T) {
+	a := newTestApp()
+	start := time.Date(2025, time.May, 5, 10, 0, 0, 0, time.UTC)
+	strangerID := "323e4567-e89b-12d3-a456-426614174000"
+
+	ctx := identity.NewContext(context.Background(), ownerID)
+	guestCtx := identity.NewContext(context.Background(), otherOwnerID)
+	strangerCtx := identity.NewContext(context.Background(), strangerID)
+
+	event, err := a.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+		Title: "Meeting", StartTime: start, EndTime: start.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.InviteAttendee(ctx, event.ID, ownerID); !errors.Is(err, storage.ErrOwnerAttendee) {
+		t.Errorf("InviteAttendee() of owner error = %v, want %v", err, storage.ErrOwnerAttendee)
+	}
+	if err := a.InviteAttendee(strangerCtx, event.ID, strangerID); !errors.Is(err, storage.ErrForbidden) {
+		t.Errorf("InviteAttendee() by stranger error = %v, want %v", err, storage.ErrForbidden)
+	}
+	if err := a.InviteAttendee(ctx, event.ID, otherOwnerID); err != nil {
+		t.Fatalf("InviteAttendee() error = %v, want nil", err)
+	}
+
+	if _, err := a.GetEvent(guestCtx, event.ID); err != nil {
+		t.Errorf("GetEvent() by attendee error = %v, want nil", err)
+	}
+	if _, err := a.GetEvent(strangerCtx, event.ID); !errors.Is(err, storage.ErrForbidden) {
+		t.Errorf("GetEvent() by stranger error = %v, want %v", err, storage.ErrForbidden)
+	}
+
+	day, err := a.GetEventsForDay(guestCtx, start)
+	if err != nil || len(day) != 1 || day[0].ID != event.ID {
+		t.Errorf("GetEventsForDay() of attendee = %+v, %v, want the event", day, err)
+	}
+
+	err = a.RespondToEvent(guestCtx, event.ID, ownerID, storage.AttendeeAccepted)
+	if !errors.Is(err, storage.ErrForbidden) {
+		t.Errorf("RespondToEvent() for another user error = %v, want %v", err, storage.ErrForbidden)
+	}
+	if err := a.RespondToEvent(guestCtx, event.ID, "", "maybe"); !errors.Is(err, storage.ErrInvalidAttendeeStatus) {
+		t.Errorf("RespondToEvent() error = %v, want %v", err, storage.ErrInvalidAttendeeStatus)
+	}
+	if err := a.RespondToEvent(guestCtx, event.ID, "", storage.AttendeeDeclined); err != nil {
+		t.Fatalf("RespondToEvent() error = %v, want nil", err)
+	}
+
+	if day, _ := a.GetEventsForDay(guestCtx, start); len(day) != 0 {
+		t.Errorf("GetEventsForDay() of declined attendee = %+v, want no events", day)
+	}
+
+	if err := a.RemoveAttendee(strangerCtx, event.ID, otherOwnerID); !errors.Is(err, storage.ErrForbidden) {
+		t.Errorf("RemoveAttendee() by stranger error = %v, want %v", err, storage.ErrForbidden)
+	}
+	if err := a.RemoveAttendee(guestCtx, event.ID, otherOwnerID); err != nil {
+		t.Errorf("RemoveAttendee() by attendee error = %v, want nil", err)
+	}
+}
+
+func TestApp_FreeBusy(t *testing.T) {
+	ctx := context.Background()
+	a := New(slog.New(slog.NewTextHandler(io.Discard, nil)), memorystorage.NewStorage(),
+		WithOverlapPolicy(OverlapAllow))
+	day := time.Date(2025, time.May, 5, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	strangerID := "323e4567-e89b-12d3-a456-426614174000"
+
+	events := []storage.CreateOrUpdateEventParams{
+		{Title: "Night", StartTime: at(-1, 0), EndTime: at(1, 0), OwnerID: ownerID},
+		{
+			Title: "Standup", StartTime: at(9, 0), EndTime: at(9, 15), OwnerID: ownerID,
+			RecurrenceRule: stringPtr("FREQ=DAILY"),
+		},
+		{Title: "Review", StartTime: at(9, 15), EndTime: at(10, 0), OwnerID: otherOwnerID},
+		{Title: "Lunch", StartTime: at(12, 0), EndTime: at(13, 0), OwnerID: ownerID},
+		{Title: "Inside lunch", StartTime: at(12, 15), EndTime: at(12, 45), OwnerID: otherOwnerID},
+		{Title: "Other user", StartTime: at(15, 0), EndTime: at(16, 0), OwnerID: strangerID},
+		{Title: "Declined", StartTime: at(17, 0), EndTime: at(18, 0), OwnerID: strangerID},
+	}
+
+	created := make(map[string]*storage.Event, len(events))
+	for _, params := range events {
+		event, err := a.CreateEvent(ctx, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		created[params.Title] = event
+	}
+
+	for title, status := range map[string]storage.AttendeeStatus{
+		"Other user": storage.AttendeeAccepted,
+		"Declined":   storage.AttendeeDeclined,
+	} {
+		if err := a.InviteAttendee(ctx, created[title].ID, otherOwnerID); err != nil {
+			t.Fatal(err)
+		}
+		if err := a.RespondToEvent(ctx, created[title].ID, otherOwnerID, status); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	busy, err := a.FreeBusy(ctx, []string{ownerID, otherOwnerID, ownerID}, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("FreeBusy() error = %v, want nil", err)
+	}
+
+	want := []Interval{
+		{Start: at(0, 0), End: at(1, 0)},
+		{Start: at(9, 0), End: at(10, 0)},
+		{Start: at(12, 0), End: at(13, 0)},
+		{Start: at(15, 0), End: at(16, 0)},
+	}
+	if len(busy) != len(want) {
+		t.Fatalf("FreeBusy() = %+v, want %+v", busy, want)
+	}
+	for i := range want {
+		if !busy[i].Start.Equal(want[i].Start) || !busy[i].End.Equal(want[i].End) {
+			t.Errorf("FreeBusy()[%d] = %+v, want %+v", i, busy[i], want[i])
+		}
+	}
+
+	if _, err := a.FreeBusy(ctx, nil, day, day.AddDate(0, 0, 1)); !errors.Is(err, storage.ErrInvalidQuery) {
+		t.Errorf("FreeBusy() without users error = %v, want %v", err, storage.ErrInvalidQuery)
+	}
+	if _, err := a.FreeBusy(ctx, []string{ownerID}, day, day); !errors.Is(err, storage.ErrInvalidQuery) {
+		t.Errorf("FreeBusy() with empty period error = %v, want %v", err, storage.ErrInvalidQuery)
+	}
+}
+
+func TestMergeIntervals(t *testing.T) {
+	base := time.Date(2025, time.May, 5, 0, 0, 0, 0, time.UTC)
+	iv := func(start, end int) Interval {
+		return Interval{Start: base.Add(time.Duration(start) * time.Hour), End: base.Add(time.Duration(end) * time.Hour)}
+	}
+
+	tests := []struct {
+		name      string
+		intervals []Interval
+		want      []Interval
+	}{
+		{name: "empty", intervals: nil, want: []Interval{}},
+		{name: "disjoint unsorted", intervals: []Interval{iv(3, 4), iv(1, 2)}, want: []Interval{iv(1, 2), iv(3, 4)}},
+		{name: "adjacent", intervals: []Interval{iv(1, 2), iv(2, 3)}, want: []Interval{iv(1, 3)}},
+		{name: "overlapping", intervals: []Interval{iv(1, 3), iv(2, 4)}, want: []Interval{iv(1, 4)}},
+		{name: "nested", intervals: []Interval{iv(1, 5), iv(2, 3), iv(4, 5)}, want: []Interval{iv(1, 5)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeIntervals(tt.intervals)
+			if len(got) != len(tt.want) {
+				t.Fatalf("mergeIntervals() = %+v, want %+v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("mergeIntervals()[%d] = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestApp_ImportEvents(t *testing.T) {
+	ctx := context.Background()
+	a := newTestApp()
+	start := time.Date(2025, time.May, 5, 10, 0, 0, 0, time.UTC)
+
+	events := []ical.Event{
+		{UID: "a", Params: storage.CreateOrUpdateEventParams{Title: "A", StartTime: start, EndTime: start.Add(time.Hour)}},
+		{UID: "b", Err: ical.ErrInvalidEvent},
+		{UID: "c", Params: storage.CreateOrUpdateEventParams{Title: "C", StartTime: start, EndTime: start.Add(time.Hour)}},
+	}
+
+	result, err := a.ImportEvents(ctx, ownerID, events)
+	if err != nil {
+		t.Fatalf("ImportEvents() error = %v, want nil", err)
+	}
+	if result.Imported != 1 {
+		t.Errorf("Imported = %d, want 1", result.Imported)
+	}
+
+	if len(result.Errors) != 2 {
+		t.Fatalf("Errors = %+v, want 2 errors", result.Errors)
+	}
+	if result.Errors[0].Index != 1 || !errors.Is(result.Errors[0].Err, ical.ErrInvalidEvent) {
+		t.Errorf("Errors[0] = %+v, want invalid event at 1", result.Errors[0])
+	}
+	if result.Errors[1].Index != 2 || !errors.Is(result.Errors[1].Err, storage.ErrDateBusy) {
+		t.Errorf("Errors[1] = %+v, want busy date at 2", result.Errors[1])
+	}
+
+	exported, err := a.ExportEvents(ctx, ownerID, start, start.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("ExportEvents() error = %v, want nil", err)
+	}
+	if len(exported) != 1 || exported[0].OwnerID != ownerID {
+		t.Errorf("ExportEvents() = %+v, want imported event of the owner", exported)
+	}
+}
+
+func TestApp_OwnerScoping(t *testing.T) {
+	a := newTestApp()
+	start := time.Date(2025, time.May, 5, 10, 0, 0, 0, time.UTC)
+
+	ctx := identity.NewContext(context.Background(), ownerID)
+	otherCtx := identity.NewContext(context.Background(), otherOwnerID)
+
+	own, err := a.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+		Title: "Own", StartTime: start, EndTime: start.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if own.OwnerID != ownerID {
+		t.Errorf("OwnerID = %v, want %v", own.OwnerID, ownerID)
+	}
+
+	if _, err := a.CreateEvent(otherCtx, storage.CreateOrUpdateEventParams{
+		Title: "Other", StartTime: start, EndTime: start.Add(time.Hour),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = a.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+		Title: "Foreign", StartTime: start, EndTime: start.Add(time.Hour), OwnerID: otherOwnerID,
+	})
+	if !errors.Is(err, storage.ErrForbidden) {
+		t.Errorf("CreateEvent() for another owner error = %v, want %v", err, storage.ErrForbidden)
+	}
+
+	page, err := a.ListEvents(ctx, storage.ListEventsQuery{})
+	if err != nil || len(page.Events) != 1 || page.Events[0].ID != own.ID {
+		t.Errorf("ListEvents() = %+v, %v, want only own event", page, err)
+	}
+
+	if _, err := a.ListEvents(ctx, storage.ListEventsQuery{OwnerID: otherOwnerID}); !errors.Is(err, storage.ErrForbidden) {
+		t.Errorf("ListEvents() of another owner error = %v, want %v", err, storage.ErrForbidden)
+	}
+
+	day, err := a.GetEventsForDay(otherCtx, start)
+	if err != nil || len(day) != 1 || day[0].OwnerID != otherOwnerID {
+		t.Errorf("GetEventsForDay() = %+v, %v, want only event of the other owner", day, err)
+	}
+
+	internal, err := a.ListEvents(context.Background(), storage.ListEventsQuery{})
+	if err != nil || len(internal.Events) != 2 {
+		t.Errorf("ListEvents() without identity = %+v, %v, want all events", internal, err)
+	}
+
+	if _, err := a.GetEvent(otherCtx, own.ID); !errors.Is(err, storage.ErrForbidden) {
+		t.Errorf("GetEvent() error = %v, want %v", err, storage.ErrForbidden)
+	}
+
+	updated := *own
+	updated.Title = "Hijacked"
+	if _, err := a.UpdateEvent(otherCtx, updated); !errors.Is(err, storage.ErrForbidden) {
+		t.Errorf("UpdateEvent() error = %v, want %v", err, storage.ErrForbidden)
+	}
+
+	if err := a.DeleteEvent(otherCtx, own.ID); !errors.Is(err, storage.ErrForbidden) {
+		t.Errorf("DeleteEvent() error = %v, want %v", err, storage.ErrForbidden)
+	}
+
+	if err := a.DeleteEvent(ctx, own.ID); err != nil {
+		t.Errorf("DeleteEvent() error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/identity"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+// InviteAttendee invites the user to the event, only the owner of the event may invite.
+func (a *App) InviteAttendee(ctx context.Context, eventID, userID string) error {
+	event, err := a.storage.GetEvent(ctx, eventID)
+	if err != nil {
+		return a.accessError("Failed to invite attendee", err)
+	}
+
+	if err := a.authorize(ctx, event); err != nil {
+		return err
+	}
+
+	if event.OwnerID == userID {
+		a.logger.Info("Event owner can not be invited", slog.String("event_id", eventID))
+		return storage.ErrOwnerAttendee
+	}
+
+	if err := a.storage.AddAttendee(ctx, eventID, userID); err != nil {
+		return a.attendeeError("Failed to invite attendee", err)
+	}
+
+	return nil
+}
+
+// RemoveAttendee removes the user from the event. The owner may remove anyone, attendees may remove themselves.
+func (a *App) RemoveAttendee(ctx context.Context, eventID, userID string) error {
+	event, err := a.storage.GetEvent(ctx, eventID)
+	if err != nil {
+		return a.accessError("Failed to remove attendee", err)
+	}
+
+	if caller, ok := identity.FromContext(ctx); !ok || caller != userID {
+		if err := a.authorize(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	if err := a.storage.RemoveAttendee(ctx, eventID, userID); err != nil {
+		return a.attendeeError("Failed to remove attendee", err)
+	}
+
+	return nil
+}
+
+// RespondToEvent sets the status of the attendee, which is the calling user or userID for internal callers.
+func (a *App) RespondToEvent(ctx context.Context, eventID, userID string, status storage.AttendeeStatus) error {
+	if err := status.Validate(); err != nil {
+		a.logger.Info("Invalid attendee status", slog.String("error", err.Error()))
+		return err
+	}
+
+	userID, err := ownerScope(ctx, userID)
+	if err != nil {
+		a.logger.Info("Attendee can not respond for another user", slog.String("event_id", eventID))
+		return err
+	}
+
+	if err := a.storage.SetAttendeeStatus(ctx, eventID, userID, status); err != nil {
+		return a.attendeeError("Failed to respond to event", err)
+	}
+
+	a.logger.Info("Attendee responded",
+		slog.String("event_id", eventID),
+		slog.String("user_id", userID),
+		slog.String("status", string(status)))
+
+	return nil
+}
+
+func (a *App) attendeeError(msg string, err error) error {
+	if errors.Is(err, storage.ErrEventNotFound) || errors.Is(err, storage.ErrAttendeeNotFound) {
+		a.logger.Info(msg, slog.String("error", err.Error()))
+		return err
+	}
+
+	a.logger.Error(msg, slog.String("error", err.Error()))
+	return err
+}
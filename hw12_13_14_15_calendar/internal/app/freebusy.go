@@ -0,0 +1,138 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+const (
+	// MaxFreeBusyUsers limits the number of users in one free/busy request.
+	MaxFreeBusyUsers = 100
+	// maxFreeBusyRange limits the period of a free/busy request, since recurring events are expanded over it.
+	maxFreeBusyRange = overlapHorizon
+)
+
+// Interval is a half-open time interval [Start, End).
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// FreeBusy returns sorted non-overlapping intervals of [from, to) in which any of the users is busy
+// with an event they own or attend. Details of the events are not disclosed, so any user may ask.
+// All-day events do not make users busy.
+func (a *App) FreeBusy(ctx context.Context, ownerIDs []string, from, to time.Time) ([]Interval, error) {
+	if err := validateFreeBusy(ownerIDs, from, to); err != nil {
+		a.logger.Info("Invalid free/busy query", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	busy, err := a.busyIntervals(ctx, uniqueStrings(ownerIDs), from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeIntervals(busy), nil
+}
+
+// busyIntervals returns occurrences of events of the users intersecting [from, to), clipped to it.
+func (a *App) busyIntervals(ctx context.Context, userIDs []string, from, to time.Time) ([]Interval, error) {
+	events, err := a.storage.GetBusyEvents(ctx, userIDs, from, to)
+	if err != nil {
+		a.logger.Error("Failed to get busy events", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	busy := make([]Interval, 0, len(events))
+	for _, event := range events {
+		if event.AllDay {
+			continue
+		}
+
+		// Occurrences starting before the period may still overlap it.
+		occurrences, err := ExpandOccurrences([]storage.Event{event},
+			from.Add(-event.EndTime.Sub(event.StartTime)), to)
+		if err != nil {
+			a.logger.Error("Failed to expand busy events", slog.String("error", err.Error()))
+			return nil, err
+		}
+
+		for _, o := range occurrences {
+			busy = append(busy, Interval{Start: o.StartTime, End: o.EndTime})
+		}
+	}
+
+	return clipIntervals(busy, from, to), nil
+}
+
+func validateFreeBusy(ownerIDs []string, from, to time.Time) error {
+	switch {
+	case len(ownerIDs) == 0:
+		return fmt.Errorf("%w: no users", storage.ErrInvalidQuery)
+	case len(ownerIDs) > MaxFreeBusyUsers:
+		return fmt.Errorf("%w: more than %d users", storage.ErrInvalidQuery, MaxFreeBusyUsers)
+	case !from.Before(to):
+		return fmt.Errorf("%w: from must be before to", storage.ErrInvalidQuery)
+	case to.Sub(from) > maxFreeBusyRange:
+		return fmt.Errorf("%w: period is longer than %s", storage.ErrInvalidQuery, maxFreeBusyRange)
+	}
+
+	return nil
+}
+
+// clipIntervals cuts intervals to [from, to), dropping empty ones.
+func clipIntervals(intervals []Interval, from, to time.Time) []Interval {
+	result := intervals[:0]
+	for _, i := range intervals {
+		if i.Start.Before(from) {
+			i.Start = from
+		}
+		if i.End.After(to) {
+			i.End = to
+		}
+		if i.Start.Before(i.End) {
+			result = append(result, i)
+		}
+	}
+
+	return result
+}
+
+// mergeIntervals sorts intervals and coalesces overlapping and adjacent ones.
+func mergeIntervals(intervals []Interval) []Interval {
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].Start.Before(intervals[j].Start)
+	})
+
+	merged := make([]Interval, 0, len(intervals))
+	for _, i := range intervals {
+		last := len(merged) - 1
+		if last >= 0 && !i.Start.After(merged[last].End) {
+			if i.End.After(merged[last].End) {
+				merged[last].End = i.End
+			}
+			continue
+		}
+		merged = append(merged, i)
+	}
+
+	return merged
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if _, ok := seen[v]; !ok {
+			seen[v] = struct{}{}
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
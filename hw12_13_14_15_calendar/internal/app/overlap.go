@@ -0,0 +1,132 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+type OverlapPolicy string
+
+const (
+	// OverlapReject rejects events overlapping other events of the same owner.
+	OverlapReject OverlapPolicy = "reject"
+	// OverlapWarn logs overlaps but stores events anyway.
+	OverlapWarn OverlapPolicy = "warn"
+	// OverlapAllow skips overlap detection.
+	OverlapAllow OverlapPolicy = "allow"
+)
+
+// overlapHorizon limits how far occurrences of an endless series are checked for overlaps.
+const overlapHorizon = 366 * 24 * time.Hour
+
+func ParseOverlapPolicy(s string) (OverlapPolicy, error) {
+	switch p := OverlapPolicy(s); p {
+	case OverlapReject, OverlapWarn, OverlapAllow:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown overlap policy: %s", s)
+	}
+}
+
+func validateInterval(start, end time.Time) error {
+	if end.Before(start) {
+		return storage.ErrInvalidInterval
+	}
+
+	return nil
+}
+
+// checkOverlap applies the overlap policy to the event being stored.
+// All-day events are transparent, they neither conflict with other events nor are conflicted with.
+func (a *App) checkOverlap(ctx context.Context, event storage.Event) error {
+	if a.overlapPolicy == OverlapAllow || event.AllDay {
+		return nil
+	}
+
+	busy, err := a.findOverlap(ctx, event)
+	if err != nil {
+		return err
+	}
+	if busy == nil {
+		return nil
+	}
+
+	if a.overlapPolicy == OverlapWarn {
+		a.logger.Warn("Event overlaps another event",
+			slog.String("owner_id", event.OwnerID),
+			slog.String("busy_event_id", busy.ID),
+			slog.String("busy_start", busy.StartTime.String()))
+		return nil
+	}
+
+	return fmt.Errorf("%w: overlaps event %s at %s", storage.ErrDateBusy, busy.ID, busy.StartTime.Format(time.RFC3339))
+}
+
+// findOverlap returns an occurrence of another event of the same owner overlapping any occurrence of the event.
+func (a *App) findOverlap(ctx context.Context, event storage.Event) (*storage.Event, error) {
+	from := event.StartTime
+	to := from.Add(overlapHorizon)
+
+	end, err := event.SeriesEnd()
+	if err != nil {
+		return nil, err
+	}
+	if end != nil && end.Before(to) {
+		to = *end
+	}
+
+	candidates, err := a.storage.GetOverlappingEvents(ctx, event.OwnerID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var busy []storage.Event
+	for _, candidate := range candidates {
+		if candidate.ID == event.ID || candidate.AllDay {
+			continue
+		}
+
+		// Occurrences starting before the window may still overlap it.
+		occurrences, err := ExpandOccurrences([]storage.Event{candidate},
+			from.Add(-candidate.EndTime.Sub(candidate.StartTime)), to)
+		if err != nil {
+			return nil, err
+		}
+		busy = append(busy, occurrences...)
+	}
+	if len(busy) == 0 {
+		return nil, nil
+	}
+
+	own, err := ExpandOccurrences([]storage.Event{event}, from, to)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(busy, func(i, j int) bool {
+		return busy[i].StartTime.Before(busy[j].StartTime)
+	})
+
+	return firstOverlap(own, busy), nil
+}
+
+// firstOverlap finds an element of b overlapping an element of a, both sorted by StartTime.
+func firstOverlap(a, b []storage.Event) *storage.Event {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case !a[i].EndTime.After(b[j].StartTime):
+			i++
+		case !b[j].EndTime.After(a[i].StartTime):
+			j++
+		default:
+			return &b[j]
+		}
+	}
+
+	return nil
+}
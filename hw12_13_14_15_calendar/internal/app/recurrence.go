@@ -0,0 +1,87 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/recurrence"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+// ExpandOccurrences replaces recurring events with their occurrences starting in [start, end).
+// Every occurrence keeps the ID and the rule of its series, StartTime and EndTime are shifted to the occurrence.
+// Series are expanded in their time zones, see storage.Event.TimeZone.
+func ExpandOccurrences(events []storage.Event, start, end time.Time) ([]storage.Event, error) {
+	result := make([]storage.Event, 0, len(events))
+
+	for _, event := range events {
+		if !event.IsRecurring() {
+			result = append(result, event)
+			continue
+		}
+
+		rule, err := recurrence.Parse(*event.RecurrenceRule)
+		if err != nil {
+			return nil, fmt.Errorf("event %s: %w", event.ID, err)
+		}
+
+		duration := event.EndTime.Sub(event.StartTime)
+		for _, occurrenceStart := range rule.Between(event.StartTime.In(event.Location()), start, end,
+			event.RecurrenceExceptions) {
+			occurrence := event
+			occurrence.StartTime = occurrenceStart
+			occurrence.EndTime = occurrenceStart.Add(duration)
+			result = append(result, occurrence)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].StartTime.Before(result[j].StartTime)
+	})
+
+	return result, nil
+}
+
+// occurrencesInPeriod returns occurrences of the events intersecting [start, end), sorted by start.
+// Series are expanded in their own time zones, so that occurrences do not depend on who is looking,
+// and only the occurrences are rendered in the location of start.
+func occurrencesInPeriod(events []storage.Event, start, end time.Time) ([]storage.Event, error) {
+	var result []storage.Event
+	for _, event := range events {
+		// Occurrences starting before the period may still overlap it,
+		// and all-day occurrences move by up to allDayMargin when rendered.
+		occurrences, err := ExpandOccurrences([]storage.Event{event},
+			start.Add(-event.EndTime.Sub(event.StartTime)-allDayMargin), end.Add(allDayMargin))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, o := range occurrences {
+			o = o.Localize(start.Location())
+			if overlapsPeriod(o, start, end) {
+				result = append(result, o)
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].StartTime.Before(result[j].StartTime)
+	})
+
+	return result, nil
+}
+
+func normalizeRecurrenceRule(rule *string) (*string, error) {
+	if rule == nil || *rule == "" {
+		return nil, nil
+	}
+
+	r, err := recurrence.Parse(*rule)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := r.String()
+	return &normalized, nil
+}
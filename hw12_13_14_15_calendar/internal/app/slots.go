@@ -0,0 +1,236 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+const (
+	DefaultSlotStep  = 30 * time.Minute
+	DefaultSlotLimit = 5
+	MaxSlotLimit     = 50
+)
+
+// WorkingHours limits slots to a part of every day in the local time of each owner.
+// Start and End are offsets from the local midnight, zero Start and End allow the whole day.
+type WorkingHours struct {
+	Start        time.Duration
+	End          time.Duration
+	WeekdaysOnly bool
+}
+
+type SlotQuery struct {
+	OwnerIDs []string
+	Duration time.Duration
+	// From and To is the search window.
+	From         time.Time
+	To           time.Time
+	WorkingHours WorkingHours
+	// Locations are time zones of the owners for working hours.
+	// Default time zones of the owners are used for missing ones, UTC if they are not set.
+	Locations map[string]*time.Location
+	// Step aligns slot starts, slots found in one free interval start Step apart.
+	Step  time.Duration
+	Limit int
+}
+
+// FindSlots returns the earliest slots of the query duration in which all owners are free and at work.
+// Empty step and limit are replaced with defaults.
+func (a *App) FindSlots(ctx context.Context, query SlotQuery) ([]Interval, error) {
+	if query.Step == 0 {
+		query.Step = DefaultSlotStep
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultSlotLimit
+	}
+	query.OwnerIDs = uniqueStrings(query.OwnerIDs)
+
+	err := query.validate()
+	if err != nil {
+		a.logger.Info("Invalid slot query", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	locations := make(map[string]*time.Location, len(query.OwnerIDs))
+	for _, ownerID := range query.OwnerIDs {
+		loc := query.Locations[ownerID]
+		if loc == nil {
+			if loc, err = a.userLocation(ctx, ownerID); err != nil {
+				return nil, err
+			}
+		}
+		locations[ownerID] = loc
+	}
+	query.Locations = locations
+
+	busy, err := a.busyIntervals(ctx, query.OwnerIDs, query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return findSlots(query, busy), nil
+}
+
+func (q SlotQuery) validate() error {
+	if err := validateFreeBusy(q.OwnerIDs, q.From, q.To); err != nil {
+		return err
+	}
+
+	wh := q.WorkingHours
+	switch {
+	case q.Duration <= 0 || q.Duration > 24*time.Hour:
+		return fmt.Errorf("%w: duration must be positive and at most a day", storage.ErrInvalidQuery)
+	case q.Step <= 0:
+		return fmt.Errorf("%w: step must be positive", storage.ErrInvalidQuery)
+	case q.Limit < 0 || q.Limit > MaxSlotLimit:
+		return fmt.Errorf("%w: limit must be between 1 and %d", storage.ErrInvalidQuery, MaxSlotLimit)
+	case wh.Start < 0 || wh.End > 24*time.Hour || (wh.End != 0 && wh.Start >= wh.End):
+		return fmt.Errorf("%w: invalid working hours", storage.ErrInvalidQuery)
+	}
+
+	return nil
+}
+
+func (q SlotQuery) location(ownerID string) *time.Location {
+	if loc := q.Locations[ownerID]; loc != nil {
+		return loc
+	}
+
+	return time.UTC
+}
+
+// findSlots intersects the search window with working hours of every owner, removes busy intervals
+// and places slots in what is left.
+func findSlots(q SlotQuery, busy []Interval) []Interval {
+	free := []Interval{{Start: q.From, End: q.To}}
+	for _, ownerID := range q.OwnerIDs {
+		free = intersectIntervals(free, workingIntervals(q.From, q.To, q.WorkingHours, q.location(ownerID)))
+	}
+	free = subtractIntervals(free, mergeIntervals(busy))
+
+	slots := make([]Interval, 0, q.Limit)
+	for _, interval := range free {
+		start := ceilTime(interval.Start, q.Step)
+		for ; !start.Add(q.Duration).After(interval.End); start = start.Add(q.Step) {
+			if len(slots) == q.Limit {
+				return slots
+			}
+			slots = append(slots, Interval{Start: start, End: start.Add(q.Duration)})
+		}
+	}
+
+	return slots
+}
+
+// workingIntervals returns working hours of every local day intersecting [from, to), clipped to it.
+// Days are built from the calendar date, so working hours keep their local time across DST changes.
+func workingIntervals(from, to time.Time, wh WorkingHours, loc *time.Location) []Interval {
+	var result []Interval
+
+	local := from.In(loc)
+	for day := 0; ; day++ {
+		date := time.Date(local.Year(), local.Month(), local.Day()+day, 0, 0, 0, 0, loc)
+		if !date.Before(to) {
+			break
+		}
+
+		if wh.WeekdaysOnly && (date.Weekday() == time.Saturday || date.Weekday() == time.Sunday) {
+			continue
+		}
+
+		start := atLocalTime(date, wh.Start)
+		end := time.Date(date.Year(), date.Month(), date.Day()+1, 0, 0, 0, 0, loc)
+		if wh.End != 0 {
+			end = atLocalTime(date, wh.End)
+		}
+
+		result = append(result, Interval{Start: start, End: end})
+	}
+
+	return clipIntervals(result, from, to)
+}
+
+// atLocalTime returns the moment the wall clock of the date location shows the offset since midnight.
+func atLocalTime(date time.Time, offset time.Duration) time.Time {
+	hour := int(offset / time.Hour)
+	minute := int(offset % time.Hour / time.Minute)
+
+	return time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, date.Location())
+}
+
+// intersectIntervals returns parts covered by both sorted non-overlapping lists.
+func intersectIntervals(a, b []Interval) []Interval {
+	var result []Interval
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		start := maxTime(a[i].Start, b[j].Start)
+		end := minTime(a[i].End, b[j].End)
+		if start.Before(end) {
+			result = append(result, Interval{Start: start, End: end})
+		}
+
+		if a[i].End.Before(b[j].End) {
+			i++
+		} else {
+			j++
+		}
+	}
+
+	return result
+}
+
+// subtractIntervals returns parts of a not covered by busy, both sorted and non-overlapping.
+func subtractIntervals(a, busy []Interval) []Interval {
+	var result []Interval
+
+	j := 0
+	for _, interval := range a {
+		start := interval.Start
+		for j < len(busy) && !busy[j].End.After(start) {
+			j++
+		}
+
+		for k := j; k < len(busy) && busy[k].Start.Before(interval.End); k++ {
+			if busy[k].Start.After(start) {
+				result = append(result, Interval{Start: start, End: busy[k].Start})
+			}
+			start = maxTime(start, busy[k].End)
+		}
+
+		if start.Before(interval.End) {
+			result = append(result, Interval{Start: start, End: interval.End})
+		}
+	}
+
+	return result
+}
+
+// ceilTime rounds t up to a multiple of step since the zero time.
+func ceilTime(t time.Time, step time.Duration) time.Time {
+	if r := t.Truncate(step); r.Before(t) {
+		return r.Add(step)
+	}
+
+	return t
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
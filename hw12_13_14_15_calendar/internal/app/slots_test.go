@@ -0,0 +1,251 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s is not available: %v", name, err)
+	}
+
+	return loc
+}
+
+func TestFindSlots(t *testing.T) {
+	utc := func(day, hour, minute int) time.Time {
+		return time.Date(2025, time.May, day, hour, minute, 0, 0, time.UTC)
+	}
+	march := func(day, hour int) time.Time {
+		return time.Date(2025, time.March, day, hour, 0, 0, 0, time.UTC)
+	}
+	officeHours := WorkingHours{Start: 9 * time.Hour, End: 18 * time.Hour, WeekdaysOnly: true}
+	moscow := mustLoadLocation(t, "Europe/Moscow")
+	newYork := mustLoadLocation(t, "America/New_York")
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+
+	tests := []struct {
+		name  string
+		query SlotQuery
+		busy  []Interval
+		want  []Interval
+	}{
+		{
+			name: "whole day without events",
+			query: SlotQuery{
+				OwnerIDs: []string{ownerID}, Duration: time.Hour, From: utc(5, 0, 0), To: utc(6, 0, 0),
+				Step: 30 * time.Minute, Limit: 3,
+			},
+			want: []Interval{
+				{Start: utc(5, 0, 0), End: utc(5, 1, 0)},
+				{Start: utc(5, 0, 30), End: utc(5, 1, 30)},
+				{Start: utc(5, 1, 0), End: utc(5, 2, 0)},
+			},
+		},
+		{
+			name: "busy start of working day",
+			query: SlotQuery{
+				OwnerIDs: []string{ownerID}, Duration: time.Hour, From: utc(5, 0, 0), To: utc(6, 0, 0),
+				WorkingHours: officeHours, Step: time.Hour, Limit: 2,
+			},
+			busy: []Interval{{Start: utc(5, 9, 0), End: utc(5, 10, 0)}},
+			want: []Interval{
+				{Start: utc(5, 10, 0), End: utc(5, 11, 0)},
+				{Start: utc(5, 11, 0), End: utc(5, 12, 0)},
+			},
+		},
+		{
+			name: "slot start is aligned to step",
+			query: SlotQuery{
+				OwnerIDs: []string{ownerID}, Duration: 30 * time.Minute, From: utc(5, 9, 0), To: utc(5, 12, 0),
+				Step: 30 * time.Minute, Limit: 1,
+			},
+			busy: []Interval{{Start: utc(5, 9, 0), End: utc(5, 9, 10)}},
+			want: []Interval{{Start: utc(5, 9, 30), End: utc(5, 10, 0)}},
+		},
+		{
+			name: "gap shorter than duration is skipped",
+			query: SlotQuery{
+				OwnerIDs: []string{ownerID}, Duration: time.Hour, From: utc(5, 9, 0), To: utc(5, 18, 0),
+				Step: 30 * time.Minute, Limit: 1,
+			},
+			busy: []Interval{
+				{Start: utc(5, 9, 0), End: utc(5, 10, 0)},
+				{Start: utc(5, 10, 30), End: utc(5, 12, 0)},
+			},
+			want: []Interval{{Start: utc(5, 12, 0), End: utc(5, 13, 0)}},
+		},
+		{
+			name: "overlapping busy intervals of several owners",
+			query: SlotQuery{
+				OwnerIDs: []string{ownerID, otherOwnerID}, Duration: time.Hour, From: utc(5, 9, 0), To: utc(5, 18, 0),
+				Step: time.Hour, Limit: 1,
+			},
+			busy: []Interval{
+				{Start: utc(5, 10, 0), End: utc(5, 12, 0)},
+				{Start: utc(5, 9, 0), End: utc(5, 11, 0)},
+			},
+			want: []Interval{{Start: utc(5, 12, 0), End: utc(5, 13, 0)}},
+		},
+		{
+			name: "weekend is skipped",
+			query: SlotQuery{
+				OwnerIDs: []string{ownerID}, Duration: time.Hour, From: utc(10, 0, 0), To: utc(13, 0, 0),
+				WorkingHours: officeHours, Step: time.Hour, Limit: 1,
+			},
+			want: []Interval{{Start: utc(12, 9, 0), End: utc(12, 10, 0)}},
+		},
+		{
+			name: "working hours in time zones of owners",
+			query: SlotQuery{
+				OwnerIDs: []string{ownerID, otherOwnerID}, Duration: time.Hour, From: utc(5, 0, 0), To: utc(6, 0, 0),
+				WorkingHours: officeHours, Step: time.Hour, Limit: 3,
+				Locations: map[string]*time.Location{ownerID: moscow, otherOwnerID: newYork},
+			},
+			want: []Interval{
+				{Start: utc(5, 13, 0), End: utc(5, 14, 0)},
+				{Start: utc(5, 14, 0), End: utc(5, 15, 0)},
+			},
+		},
+		{
+			name: "working hours keep local time across DST change",
+			query: SlotQuery{
+				OwnerIDs: []string{ownerID}, Duration: time.Hour, From: march(28, 0), To: march(32, 0),
+				Step: time.Hour, Limit: 5, Locations: map[string]*time.Location{ownerID: berlin},
+				WorkingHours: WorkingHours{Start: 9 * time.Hour, End: 10 * time.Hour, WeekdaysOnly: true},
+			},
+			want: []Interval{{Start: march(28, 8), End: march(28, 9)}, {Start: march(31, 7), End: march(31, 8)}},
+		},
+		{
+			name: "working day ends at midnight",
+			query: SlotQuery{
+				OwnerIDs: []string{ownerID}, Duration: time.Hour, From: utc(5, 0, 0), To: utc(6, 0, 0),
+				WorkingHours: WorkingHours{Start: 23 * time.Hour, End: 24 * time.Hour}, Step: time.Hour, Limit: 2,
+			},
+			want: []Interval{{Start: utc(5, 23, 0), End: utc(6, 0, 0)}},
+		},
+		{
+			name: "limit spans free intervals",
+			query: SlotQuery{
+				OwnerIDs: []string{ownerID}, Duration: time.Hour, From: utc(5, 9, 0), To: utc(5, 13, 0),
+				Step: time.Hour, Limit: 2,
+			},
+			busy: []Interval{{Start: utc(5, 10, 0), End: utc(5, 12, 0)}},
+			want: []Interval{
+				{Start: utc(5, 9, 0), End: utc(5, 10, 0)},
+				{Start: utc(5, 12, 0), End: utc(5, 13, 0)},
+			},
+		},
+		{
+			name: "no free time",
+			query: SlotQuery{
+				OwnerIDs: []string{ownerID}, Duration: time.Hour, From: utc(5, 9, 0), To: utc(5, 18, 0),
+				Step: time.Hour, Limit: 2,
+			},
+			busy: []Interval{{Start: utc(5, 8, 0), End: utc(5, 19, 0)}},
+			want: []Interval{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findSlots(tt.query, tt.busy)
+			if len(got) != len(tt.want) {
+				t.Fatalf("findSlots() = %+v, want %+v", got, tt.want)
+			}
+			for i := range tt.want {
+				if !got[i].Start.Equal(tt.want[i].Start) || !got[i].End.Equal(tt.want[i].End) {
+					t.Errorf("findSlots()[%d] = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSubtractIntervals(t *testing.T) {
+	base := time.Date(2025, time.May, 5, 0, 0, 0, 0, time.UTC)
+	iv := func(start, end int) Interval {
+		return Interval{Start: base.Add(time.Duration(start) * time.Hour), End: base.Add(time.Duration(end) * time.Hour)}
+	}
+
+	tests := []struct {
+		name string
+		a    []Interval
+		busy []Interval
+		want []Interval
+	}{
+		{name: "nothing busy", a: []Interval{iv(1, 5)}, want: []Interval{iv(1, 5)}},
+		{name: "busy inside", a: []Interval{iv(1, 5)}, busy: []Interval{iv(2, 3)}, want: []Interval{iv(1, 2), iv(3, 5)}},
+		{name: "busy covers start", a: []Interval{iv(1, 5)}, busy: []Interval{iv(0, 2)}, want: []Interval{iv(2, 5)}},
+		{name: "busy covers all", a: []Interval{iv(1, 5)}, busy: []Interval{iv(0, 6)}, want: nil},
+		{
+			name: "busy spans intervals",
+			a:    []Interval{iv(1, 3), iv(4, 6)},
+			busy: []Interval{iv(2, 5)},
+			want: []Interval{iv(1, 2), iv(5, 6)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := subtractIntervals(tt.a, tt.busy)
+			if len(got) != len(tt.want) {
+				t.Fatalf("subtractIntervals() = %+v, want %+v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("subtractIntervals()[%d] = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestApp_FindSlots(t *testing.T) {
+	ctx := context.Background()
+	a := newTestApp()
+	start := time.Date(2025, time.May, 5, 9, 0, 0, 0, time.UTC)
+
+	if _, err := a.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+		Title: "Busy", StartTime: start, EndTime: start.Add(90 * time.Minute), OwnerID: otherOwnerID,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	slots, err := a.FindSlots(ctx, SlotQuery{
+		OwnerIDs:     []string{ownerID, otherOwnerID},
+		Duration:     time.Hour,
+		From:         start.Add(-9 * time.Hour),
+		To:           start.Add(15 * time.Hour),
+		WorkingHours: WorkingHours{Start: 9 * time.Hour, End: 18 * time.Hour},
+		Limit:        1,
+	})
+	if err != nil {
+		t.Fatalf("FindSlots() error = %v, want nil", err)
+	}
+	if len(slots) != 1 || !slots[0].Start.Equal(start.Add(90*time.Minute)) {
+		t.Errorf("FindSlots() = %+v, want a slot at %v", slots, start.Add(90*time.Minute))
+	}
+
+	invalid := []SlotQuery{
+		{OwnerIDs: []string{ownerID}, From: start, To: start.Add(time.Hour)},
+		{OwnerIDs: []string{ownerID}, Duration: time.Hour, From: start, To: start.Add(time.Hour), Limit: MaxSlotLimit + 1},
+		{
+			OwnerIDs: []string{ownerID}, Duration: time.Hour, From: start, To: start.Add(time.Hour),
+			WorkingHours: WorkingHours{Start: 18 * time.Hour, End: 9 * time.Hour},
+		},
+	}
+	for _, q := range invalid {
+		if _, err := a.FindSlots(ctx, q); !errors.Is(err, storage.ErrInvalidQuery) {
+			t.Errorf("FindSlots(%+v) error = %v, want %v", q, err, storage.ErrInvalidQuery)
+		}
+	}
+}
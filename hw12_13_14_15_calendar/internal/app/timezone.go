@@ -0,0 +1,141 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/identity"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+var ErrUnknownTimeZone = errors.New("unknown time zone")
+
+// ResolveLocation returns the named IANA time zone. If the name is empty, the default time zone
+// of the calling user is returned, UTC is used for internal callers and users without one.
+func (a *App) ResolveLocation(ctx context.Context, name string) (*time.Location, error) {
+	if name != "" {
+		return loadLocation(name)
+	}
+
+	caller, ok := identity.FromContext(ctx)
+	if !ok {
+		return time.UTC, nil
+	}
+
+	return a.userLocation(ctx, caller)
+}
+
+// GetTimeZone returns the name of the default time zone of the user, which is the calling user if known.
+func (a *App) GetTimeZone(ctx context.Context, userID string) (string, error) {
+	userID, err := a.settingsScope(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	loc, err := a.userLocation(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	return loc.String(), nil
+}
+
+// SetTimeZone sets the default time zone of the user, which is the calling user if known.
+func (a *App) SetTimeZone(ctx context.Context, userID, name string) error {
+	userID, err := a.settingsScope(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	loc, err := loadLocation(name)
+	if err != nil {
+		a.logger.Info("Unknown time zone", slog.String("time_zone", name))
+		return err
+	}
+
+	if err := a.storage.SetTimeZone(ctx, userID, loc.String()); err != nil {
+		a.logger.Error("Failed to set time zone", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+func (a *App) settingsScope(ctx context.Context, userID string) (string, error) {
+	scope, err := ownerScope(ctx, userID)
+	if err != nil {
+		a.logger.Info("Settings of another user can not be accessed", slog.String("user_id", userID))
+		return "", err
+	}
+
+	if scope == "" {
+		return "", fmt.Errorf("%w: user is required", storage.ErrInvalidQuery)
+	}
+
+	return scope, nil
+}
+
+// seriesTimeZone returns the time zone an event of the owner recurs in: the named one or the default time zone
+// of the owner. All-day events recur in UTC and get none.
+func (a *App) seriesTimeZone(ctx context.Context, ownerID, name string, allDay bool) (string, error) {
+	if allDay {
+		return "", nil
+	}
+
+	if name != "" {
+		loc, err := loadLocation(name)
+		if err != nil {
+			a.logger.Info("Unknown time zone", slog.String("time_zone", name))
+			return "", err
+		}
+
+		return loc.String(), nil
+	}
+
+	loc, err := a.userLocation(ctx, ownerID)
+	if err != nil {
+		return "", err
+	}
+
+	return loc.String(), nil
+}
+
+// userLocation returns the default time zone of the user or UTC if it is not set.
+func (a *App) userLocation(ctx context.Context, userID string) (*time.Location, error) {
+	name, err := a.storage.GetTimeZone(ctx, userID)
+	if err != nil {
+		a.logger.Error("Failed to get time zone", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if name == "" {
+		return time.UTC, nil
+	}
+
+	if loc, err := loadLocation(name); err == nil {
+		return loc, nil
+	}
+
+	a.logger.Warn("Stored time zone is unknown, using UTC",
+		slog.String("user_id", userID),
+		slog.String("time_zone", name))
+
+	return time.UTC, nil
+}
+
+// loadLocation loads an IANA time zone, the local time zone of the server is not accepted.
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTimeZone, name)
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTimeZone, name)
+	}
+
+	return loc, nil
+}
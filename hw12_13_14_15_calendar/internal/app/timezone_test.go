@@ -0,0 +1,209 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/identity"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+func createEventsAt(t *testing.T, a *App, starts ...time.Time) {
+	t.Helper()
+
+	for _, start := range starts {
+		if _, err := a.CreateEvent(context.Background(), storage.CreateOrUpdateEventParams{
+			Title: start.String(), StartTime: start, EndTime: start.Add(15 * time.Minute), OwnerID: ownerID,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func eventStarts(events []storage.Event) []time.Time {
+	starts := make([]time.Time, len(events))
+	for i, event := range events {
+		starts[i] = event.StartTime
+	}
+
+	return starts
+}
+
+func TestApp_GetEventsForDayInTimeZone(t *testing.T) {
+	ctx := context.Background()
+	a := newTestApp()
+	moscow := mustLoadLocation(t, "Europe/Moscow")
+
+	lateUTC := time.Date(2025, time.May, 5, 22, 30, 0, 0, time.UTC)
+	createEventsAt(t, a, lateUTC)
+
+	events, err := a.GetEventsForDay(ctx, time.Date(2025, time.May, 6, 12, 0, 0, 0, moscow))
+	if err != nil {
+		t.Fatalf("GetEventsForDay() error = %v, want nil", err)
+	}
+	if len(events) != 1 || !events[0].StartTime.Equal(lateUTC) {
+		t.Fatalf("GetEventsForDay() = %v, want [%v]", eventStarts(events), lateUTC)
+	}
+	if loc := events[0].StartTime.Location(); loc != moscow {
+		t.Errorf("StartTime location = %v, want %v", loc, moscow)
+	}
+
+	events, err = a.GetEventsForDay(ctx, time.Date(2025, time.May, 6, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetEventsForDay() error = %v, want nil", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("GetEventsForDay() in UTC = %v, want none", eventStarts(events))
+	}
+}
+
+func TestApp_GetEventsForWeekAcrossDSTChange(t *testing.T) {
+	ctx := context.Background()
+	a := newTestApp()
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+
+	// Clocks move forward on Sunday, March 30, 2025, so the week from March 24 is 167 hours long.
+	firstMinutes := time.Date(2025, time.March, 23, 23, 30, 0, 0, time.UTC)
+	lastHour := time.Date(2025, time.March, 30, 21, 30, 0, 0, time.UTC)
+	nextWeek := time.Date(2025, time.March, 30, 22, 30, 0, 0, time.UTC)
+	createEventsAt(t, a, firstMinutes, lastHour, nextWeek)
+
+	events, err := a.GetEventsForWeek(ctx, time.Date(2025, time.March, 30, 23, 45, 0, 0, berlin))
+	if err != nil {
+		t.Fatalf("GetEventsForWeek() error = %v, want nil", err)
+	}
+
+	got := eventStarts(events)
+	if len(got) != 2 || !got[0].Equal(firstMinutes) || !got[1].Equal(lastHour) {
+		t.Errorf("GetEventsForWeek() = %v, want [%v %v]", got, firstMinutes, lastHour)
+	}
+}
+
+func TestApp_TimeZoneSettings(t *testing.T) {
+	a := newTestApp()
+	ctx := identity.NewContext(context.Background(), ownerID)
+	moscow := mustLoadLocation(t, "Europe/Moscow")
+
+	loc, err := a.ResolveLocation(ctx, "")
+	if err != nil || loc != time.UTC {
+		t.Fatalf("ResolveLocation() = %v, %v, want UTC, nil", loc, err)
+	}
+
+	if err := a.SetTimeZone(ctx, "", "Europe/Moscow"); err != nil {
+		t.Fatalf("SetTimeZone() error = %v, want nil", err)
+	}
+
+	loc, err = a.ResolveLocation(ctx, "")
+	if err != nil || loc.String() != moscow.String() {
+		t.Errorf("ResolveLocation() = %v, %v, want %v, nil", loc, err, moscow)
+	}
+
+	loc, err = a.ResolveLocation(ctx, "Asia/Tokyo")
+	if err != nil || loc.String() != "Asia/Tokyo" {
+		t.Errorf("ResolveLocation(Asia/Tokyo) = %v, %v, want Asia/Tokyo, nil", loc, err)
+	}
+
+	if tz, err := a.GetTimeZone(ctx, ""); err != nil || tz != "Europe/Moscow" {
+		t.Errorf("GetTimeZone() = %q, %v, want Europe/Moscow, nil", tz, err)
+	}
+
+	for _, name := range []string{"Mars/Olympus", "Local", ""} {
+		if err := a.SetTimeZone(ctx, "", name); !errors.Is(err, ErrUnknownTimeZone) {
+			t.Errorf("SetTimeZone(%q) error = %v, want %v", name, err, ErrUnknownTimeZone)
+		}
+		if name == "" {
+			continue
+		}
+		if _, err := a.ResolveLocation(ctx, name); !errors.Is(err, ErrUnknownTimeZone) {
+			t.Errorf("ResolveLocation(%q) error = %v, want %v", name, err, ErrUnknownTimeZone)
+		}
+	}
+
+	if err := a.SetTimeZone(ctx, otherOwnerID, "Europe/Moscow"); !errors.Is(err, storage.ErrForbidden) {
+		t.Errorf("SetTimeZone() of another user error = %v, want %v", err, storage.ErrForbidden)
+	}
+
+	if err := a.SetTimeZone(context.Background(), "", "Europe/Moscow"); !errors.Is(err, storage.ErrInvalidQuery) {
+		t.Errorf("SetTimeZone() without user error = %v, want %v", err, storage.ErrInvalidQuery)
+	}
+}
+
+func TestApp_GetEventsForDayExpandsSeriesIndependentlyOfViewer(t *testing.T) {
+	ctx := context.Background()
+	a := newTestApp()
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+
+	rule := "FREQ=WEEKLY"
+	start := time.Date(2026, time.January, 5, 9, 0, 0, 0, berlin)
+	skipped := time.Date(2026, time.July, 13, 9, 0, 0, 0, berlin)
+	if _, err := a.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+		Title:                "Standup",
+		StartTime:            start,
+		EndTime:              start.Add(15 * time.Minute),
+		OwnerID:              ownerID,
+		RecurrenceRule:       &rule,
+		RecurrenceExceptions: []time.Time{skipped},
+		TimeZone:             "Europe/Berlin",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := time.Date(2026, time.July, 6, 7, 0, 0, 0, time.UTC)
+	for _, loc := range []*time.Location{berlin, time.UTC, mustLoadLocation(t, "America/New_York")} {
+		events, err := a.GetEventsForDay(ctx, time.Date(2026, time.July, 6, 12, 0, 0, 0, loc))
+		if err != nil {
+			t.Fatalf("GetEventsForDay() error = %v, want nil", err)
+		}
+		if got := eventStarts(events); len(got) != 1 || !got[0].Equal(want) {
+			t.Errorf("GetEventsForDay() in %v = %v, want [%v]", loc, got, want)
+		}
+
+		events, err = a.GetEventsForDay(ctx, time.Date(2026, time.July, 13, 12, 0, 0, 0, loc))
+		if err != nil {
+			t.Fatalf("GetEventsForDay() error = %v, want nil", err)
+		}
+		if len(events) != 0 {
+			t.Errorf("GetEventsForDay() of the excluded date in %v = %v, want none", loc, eventStarts(events))
+		}
+	}
+}
+
+func TestApp_RecurringEventsKeepWallClockOfOwnerTimeZone(t *testing.T) {
+	a := newTestApp()
+	ctx := identity.NewContext(context.Background(), ownerID)
+
+	if err := a.SetTimeZone(ctx, "", "Europe/Berlin"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Backends may return times in UTC, the series still recurs at 09:00 in Berlin.
+	rule := "FREQ=DAILY"
+	start := time.Date(2026, time.January, 5, 8, 0, 0, 0, time.UTC)
+	event, err := a.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+		Title: "Standup", StartTime: start, EndTime: start.Add(15 * time.Minute), RecurrenceRule: &rule,
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v, want nil", err)
+	}
+	if event.TimeZone != "Europe/Berlin" {
+		t.Errorf("TimeZone = %q, want Europe/Berlin", event.TimeZone)
+	}
+
+	events, err := a.GetEventsForDay(ctx, time.Date(2026, time.July, 6, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetEventsForDay() error = %v, want nil", err)
+	}
+	want := time.Date(2026, time.July, 6, 7, 0, 0, 0, time.UTC)
+	if got := eventStarts(events); len(got) != 1 || !got[0].Equal(want) {
+		t.Errorf("GetEventsForDay() = %v, want [%v]", got, want)
+	}
+
+	_, err = a.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+		Title: "Standup", StartTime: start, EndTime: start.Add(time.Hour), TimeZone: "Mars/Olympus",
+	})
+	if !errors.Is(err, ErrUnknownTimeZone) {
+		t.Errorf("CreateEvent() with unknown time zone error = %v, want %v", err, ErrUnknownTimeZone)
+	}
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+// WithWeekStart sets the first day of the week used when a request does not choose one.
+func WithWeekStart(weekStart time.Weekday) Option {
+	return func(a *App) {
+		a.weekStart = weekStart
+	}
+}
+
+// ParseWeekday parses an English weekday name in any case, e.g. "monday" or "Sunday".
+func ParseWeekday(s string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(s, d.String()) {
+			return d, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown weekday: %s", s)
+}
+
+// GetEventsForWeekStarting returns events of the week containing the date, weeks start on weekStart
+// in the date location.
+func (a *App) GetEventsForWeekStarting(
+	ctx context.Context,
+	day time.Time,
+	weekStart time.Weekday,
+) ([]storage.Event, error) {
+	if weekStart < time.Sunday || weekStart > time.Saturday {
+		return nil, fmt.Errorf("%w: invalid first day of week %d", storage.ErrInvalidQuery, weekStart)
+	}
+
+	start := startOfDay(day)
+	start = start.AddDate(0, 0, -(int(start.Weekday()-weekStart)+7)%7)
+	end := start.AddDate(0, 0, 7)
+
+	return a.GetEventsByPeriod(ctx, start, end)
+}
+
+// ISOWeekStart returns the local midnight of Monday of the ISO 8601 week in the format 2026-W42.
+func ISOWeekStart(isoWeek string, loc *time.Location) (time.Time, error) {
+	yearStr, weekStr, ok := strings.Cut(isoWeek, "-W")
+	if !ok || len(yearStr) != 4 || len(weekStr) != 2 {
+		return time.Time{}, fmt.Errorf("invalid ISO week %q, use YYYY-Www (e.g. 2026-W42)", isoWeek)
+	}
+
+	year, err := strconv.Atoi(yearStr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid ISO week year %q", yearStr)
+	}
+
+	week, err := strconv.Atoi(weekStr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid ISO week number %q", weekStr)
+	}
+
+	// December 28 always falls into the last ISO week of its year.
+	if _, weeks := time.Date(year, time.December, 28, 0, 0, 0, 0, loc).ISOWeek(); week < 1 || week > weeks {
+		return time.Time{}, fmt.Errorf("week %d is out of range 1-%d of year %d", week, weeks, year)
+	}
+
+	// January 4 always falls into the first ISO week.
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, loc)
+	fromMonday := (int(jan4.Weekday()) + 6) % 7
+
+	return time.Date(year, time.January, 4-fromMonday+(week-1)*7, 0, 0, 0, 0, loc), nil
+}
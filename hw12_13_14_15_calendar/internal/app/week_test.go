@@ -0,0 +1,127 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+	memorystorage "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/memory"
+)
+
+func TestParseWeekday(t *testing.T) {
+	for _, s := range []string{"monday", "Sunday", "SATURDAY"} {
+		if _, err := ParseWeekday(s); err != nil {
+			t.Errorf("ParseWeekday(%q) error = %v, want nil", s, err)
+		}
+	}
+
+	if d, _ := ParseWeekday("sunday"); d != time.Sunday {
+		t.Errorf("ParseWeekday(sunday) = %v, want %v", d, time.Sunday)
+	}
+
+	for _, s := range []string{"", "mon", "funday"} {
+		if _, err := ParseWeekday(s); err == nil {
+			t.Errorf("ParseWeekday(%q) error = nil, want error", s)
+		}
+	}
+}
+
+func TestISOWeekStart(t *testing.T) {
+	tests := []struct {
+		isoWeek string
+		want    time.Time
+		wantErr bool
+	}{
+		{isoWeek: "2026-W42", want: time.Date(2026, time.October, 12, 0, 0, 0, 0, time.UTC)},
+		{isoWeek: "2026-W01", want: time.Date(2025, time.December, 29, 0, 0, 0, 0, time.UTC)},
+		{isoWeek: "2020-W53", want: time.Date(2020, time.December, 28, 0, 0, 0, 0, time.UTC)},
+		{isoWeek: "2021-W01", want: time.Date(2021, time.January, 4, 0, 0, 0, 0, time.UTC)},
+		{isoWeek: "2021-W53", wantErr: true},
+		{isoWeek: "2026-W00", wantErr: true},
+		{isoWeek: "2026-W1", wantErr: true},
+		{isoWeek: "2026-42", wantErr: true},
+		{isoWeek: "20a6-W42", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.isoWeek, func(t *testing.T) {
+			got, err := ISOWeekStart(tt.isoWeek, time.UTC)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ISOWeekStart() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("ISOWeekStart() = %v, want %v", got, tt.want)
+			}
+			if !tt.wantErr {
+				if year, week := got.ISOWeek(); got.Weekday() != time.Monday || fmt.Sprintf("%d-W%02d", year, week) != tt.isoWeek {
+					t.Errorf("ISOWeekStart() = %v is not Monday of %s", got, tt.isoWeek)
+				}
+			}
+		})
+	}
+}
+
+func TestApp_GetEventsForWeekStarting(t *testing.T) {
+	ctx := context.Background()
+	a := newTestApp()
+
+	// Sunday, October 11 and Saturday, October 17, 2026.
+	sunday := time.Date(2026, time.October, 11, 10, 0, 0, 0, time.UTC)
+	saturday := time.Date(2026, time.October, 17, 10, 0, 0, 0, time.UTC)
+	createEventsAt(t, a, sunday, saturday)
+
+	wednesday := time.Date(2026, time.October, 14, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		weekStart time.Weekday
+		want      []time.Time
+	}{
+		{weekStart: time.Monday, want: []time.Time{saturday}},
+		{weekStart: time.Sunday, want: []time.Time{sunday, saturday}},
+		{weekStart: time.Saturday, want: []time.Time{sunday}},
+		{weekStart: time.Wednesday, want: []time.Time{saturday}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.weekStart.String(), func(t *testing.T) {
+			events, err := a.GetEventsForWeekStarting(ctx, wednesday, tt.weekStart)
+			if err != nil {
+				t.Fatalf("GetEventsForWeekStarting() error = %v, want nil", err)
+			}
+
+			got := eventStarts(events)
+			if len(got) != len(tt.want) {
+				t.Fatalf("GetEventsForWeekStarting() = %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("GetEventsForWeekStarting()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	if _, err := a.GetEventsForWeekStarting(ctx, wednesday, 7); !errors.Is(err, storage.ErrInvalidQuery) {
+		t.Errorf("GetEventsForWeekStarting() error = %v, want %v", err, storage.ErrInvalidQuery)
+	}
+}
+
+func TestApp_GetEventsForWeekUsesConfiguredWeekStart(t *testing.T) {
+	ctx := context.Background()
+	a := New(slog.New(slog.NewTextHandler(io.Discard, nil)), memorystorage.NewStorage(), WithWeekStart(time.Sunday))
+
+	sunday := time.Date(2026, time.October, 11, 10, 0, 0, 0, time.UTC)
+	createEventsAt(t, a, sunday)
+
+	events, err := a.GetEventsForWeek(ctx, time.Date(2026, time.October, 14, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetEventsForWeek() error = %v, want nil", err)
+	}
+	if len(events) != 1 {
+		t.Errorf("GetEventsForWeek() = %v, want [%v]", eventStarts(events), sunday)
+	}
+}
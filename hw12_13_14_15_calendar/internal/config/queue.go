@@ -0,0 +1,46 @@
+// Package config holds configuration shared by the calendar binaries.
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	amqpqueue "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/queue/amqp"
+)
+
+// Queue configures the AMQP queue the scheduler publishes notifications to and the sender consumes them from.
+type Queue struct {
+	Host           string        `yaml:"host" env:"QUEUE_HOST" env-default:"localhost"`
+	Port           int           `yaml:"port" env:"QUEUE_PORT" env-default:"5672"`
+	Username       string        `yaml:"username" env:"QUEUE_USERNAME" env-default:"guest"`
+	Password       string        `yaml:"password" env:"QUEUE_PASSWORD" env-default:"guest"`
+	VirtualHost    string        `yaml:"virtual_host" env:"QUEUE_VIRTUAL_HOST" env-default:"/"`
+	Exchange       string        `yaml:"exchange" env:"QUEUE_EXCHANGE" env-default:"calendar"`
+	Name           string        `yaml:"name" env:"QUEUE_NAME" env-default:"notifications"`
+	RoutingKey     string        `yaml:"routing_key" env:"QUEUE_ROUTING_KEY" env-default:"notification"`
+	Prefetch       int           `yaml:"prefetch" env:"QUEUE_PREFETCH" env-default:"10"`
+	ReconnectDelay time.Duration `yaml:"reconnect_delay" env:"QUEUE_RECONNECT_DELAY" env-default:"5s"`
+}
+
+func (q Queue) MakeAMQPURL() string {
+	u := url.URL{
+		Scheme: "amqp",
+		User:   url.UserPassword(q.Username, q.Password),
+		Host:   fmt.Sprintf("%s:%d", q.Host, q.Port),
+		Path:   q.VirtualHost,
+	}
+
+	return u.String()
+}
+
+func (q Queue) MakeAMQPConfig() amqpqueue.Config {
+	return amqpqueue.Config{
+		URL:            q.MakeAMQPURL(),
+		Exchange:       q.Exchange,
+		Queue:          q.Name,
+		RoutingKey:     q.RoutingKey,
+		Prefetch:       q.Prefetch,
+		ReconnectDelay: q.ReconnectDelay,
+	}
+}
@@ -0,0 +1,108 @@
+package grpchandler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/helpers"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+	pb "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/pb/event"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var attendeeStatuses = map[pb.AttendeeStatus]storage.AttendeeStatus{
+	pb.AttendeeStatus_ATTENDEE_STATUS_NEEDS_ACTION: storage.AttendeeNeedsAction,
+	pb.AttendeeStatus_ATTENDEE_STATUS_ACCEPTED:     storage.AttendeeAccepted,
+	pb.AttendeeStatus_ATTENDEE_STATUS_DECLINED:     storage.AttendeeDeclined,
+	pb.AttendeeStatus_ATTENDEE_STATUS_TENTATIVE:    storage.AttendeeTentative,
+}
+
+func (h *EventHandler) InviteAttendee(ctx context.Context, req *pb.AttendeeRequest) (*pb.EmptyResponse, error) {
+	if err := validateAttendeeRequest(req.GetEventId(), req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	if err := h.app.InviteAttendee(ctx, req.GetEventId(), req.GetUserId()); err != nil {
+		return nil, attendeeError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+func (h *EventHandler) RemoveAttendee(ctx context.Context, req *pb.AttendeeRequest) (*pb.EmptyResponse, error) {
+	if err := validateAttendeeRequest(req.GetEventId(), req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	if err := h.app.RemoveAttendee(ctx, req.GetEventId(), req.GetUserId()); err != nil {
+		return nil, attendeeError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+func (h *EventHandler) RespondToEvent(ctx context.Context, req *pb.RespondToEventRequest) (*pb.EmptyResponse, error) {
+	if req.GetEventId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Event ID is required")
+	}
+
+	if req.GetUserId() != "" && !helpers.IsValidUUID(req.GetUserId()) {
+		return nil, status.Error(codes.InvalidArgument, "user_id must be uuid")
+	}
+
+	attendeeStatus, ok := attendeeStatuses[req.GetStatus()]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "unknown attendee status")
+	}
+
+	if err := h.app.RespondToEvent(ctx, req.GetEventId(), req.GetUserId(), attendeeStatus); err != nil {
+		return nil, attendeeError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+func validateAttendeeRequest(eventID, userID string) error {
+	if eventID == "" {
+		return status.Error(codes.InvalidArgument, "Event ID is required")
+	}
+
+	if !helpers.IsValidUUID(userID) {
+		return status.Error(codes.InvalidArgument, "user_id must be uuid")
+	}
+
+	return nil
+}
+
+func attendeeError(err error) error {
+	switch {
+	case errors.Is(err, storage.ErrEventNotFound), errors.Is(err, storage.ErrAttendeeNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, storage.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, storage.ErrOwnerAttendee), errors.Is(err, storage.ErrInvalidAttendeeStatus):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func attendeesToProto(attendees []storage.Attendee) []*pb.Attendee {
+	result := make([]*pb.Attendee, 0, len(attendees))
+	for _, a := range attendees {
+		result = append(result, &pb.Attendee{UserId: a.UserID, Status: attendeeStatusToProto(a.Status)})
+	}
+
+	return result
+}
+
+func attendeeStatusToProto(s storage.AttendeeStatus) pb.AttendeeStatus {
+	for p, st := range attendeeStatuses {
+		if st == s {
+			return p
+		}
+	}
+
+	return pb.AttendeeStatus_ATTENDEE_STATUS_NEEDS_ACTION
+}
@@ -3,10 +3,12 @@ package grpchandler
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/app"
 	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/helpers"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/recurrence"
 	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
 	pb "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/pb/event"
 	"google.golang.org/grpc/codes"
@@ -24,18 +26,18 @@ func NewEventHandler(app app.Application) *EventHandler {
 	return &EventHandler{app: app}
 }
 
-func (h *EventHandler) Create(ctx context.Context, req *pb.CreateOrUpdateEventRequest) (*pb.EmptyResponse, error) {
+func (h *EventHandler) Create(ctx context.Context, req *pb.CreateOrUpdateEventRequest) (*pb.Event, error) {
 	param, err := createOrUpdateRequestToStorageParams(req)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	_, err = h.app.CreateEvent(ctx, *param)
+	created, err := h.app.CreateEvent(ctx, *param)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, eventChangeError(err)
 	}
 
-	return &pb.EmptyResponse{}, nil
+	return eventToProto(*created), nil
 }
 
 func (h *EventHandler) Get(ctx context.Context, req *pb.GetEventRequest) (*pb.Event, error) {
@@ -49,13 +51,17 @@ func (h *EventHandler) Get(ctx context.Context, req *pb.GetEventRequest) (*pb.Ev
 			return nil, status.Error(codes.NotFound, "event not found")
 		}
 
+		if errors.Is(err, storage.ErrForbidden) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	return eventToProto(*event), nil
 }
 
-func (h *EventHandler) Update(ctx context.Context, req *pb.CreateOrUpdateEventRequest) (*pb.EmptyResponse, error) {
+func (h *EventHandler) Update(ctx context.Context, req *pb.CreateOrUpdateEventRequest) (*pb.Event, error) {
 	if req.GetId() == "" {
 		return nil, status.Error(codes.InvalidArgument, "Event ID is required")
 	}
@@ -66,25 +72,26 @@ func (h *EventHandler) Update(ctx context.Context, req *pb.CreateOrUpdateEventRe
 	}
 
 	event := storage.Event{
-		ID:           req.GetId(),
-		Title:        param.Title,
-		StartTime:    param.StartTime,
-		EndTime:      param.EndTime,
-		Description:  param.Description,
-		OwnerID:      param.OwnerID,
-		NotifyBefore: param.NotifyBefore,
+		ID:                   req.GetId(),
+		Title:                param.Title,
+		StartTime:            param.StartTime,
+		EndTime:              param.EndTime,
+		Description:          param.Description,
+		OwnerID:              param.OwnerID,
+		NotifyBefore:         param.NotifyBefore,
+		RecurrenceRule:       param.RecurrenceRule,
+		RecurrenceExceptions: param.RecurrenceExceptions,
+		Version:              req.GetExpectedVersion(),
+		AllDay:               param.AllDay,
+		TimeZone:             param.TimeZone,
 	}
 
-	err = h.app.UpdateEvent(ctx, event)
+	updated, err := h.app.UpdateEvent(ctx, event)
 	if err != nil {
-		if errors.Is(err, storage.ErrEventNotFound) {
-			return nil, status.Error(codes.NotFound, "event not found")
-		}
-
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, eventChangeError(err)
 	}
 
-	return &pb.EmptyResponse{}, nil
+	return eventToProto(*updated), nil
 }
 
 func (h *EventHandler) Delete(ctx context.Context, req *pb.DeleteEventRequest) (*pb.EmptyResponse, error) {
@@ -98,28 +105,34 @@ func (h *EventHandler) Delete(ctx context.Context, req *pb.DeleteEventRequest) (
 			return nil, status.Error(codes.NotFound, "event not found")
 		}
 
+		if errors.Is(err, storage.ErrForbidden) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	return &pb.EmptyResponse{}, nil
 }
 
-func (h *EventHandler) ListEvents(ctx context.Context, _ *pb.EmptyRequest) (*pb.EventListResponse, error) {
-	events, err := h.app.GetAllEvents(ctx)
-	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+func (h *EventHandler) Restore(ctx context.Context, req *pb.RestoreEventRequest) (*pb.Event, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Event ID is required")
 	}
 
-	eventList := make([]*pb.Event, len(events))
-	for i, event := range events {
-		eventList[i] = eventToProto(event)
+	event, err := h.app.RestoreEvent(ctx, req.GetId())
+	if err != nil {
+		return nil, eventChangeError(err)
 	}
 
-	return &pb.EventListResponse{Events: eventList}, nil
+	return eventToProto(*event), nil
 }
 
-func (h *EventHandler) ListDayEvents(ctx context.Context, req *pb.DateRequest) (*pb.EventListResponse, error) {
-	events, err := h.app.GetEventsForDay(ctx, req.GetDate().AsTime())
+func (h *EventHandler) ListDeletedEvents(
+	ctx context.Context,
+	_ *pb.ListDeletedEventsRequest,
+) (*pb.EventListResponse, error) {
+	events, err := h.app.ListDeletedEvents(ctx)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -127,22 +140,153 @@ func (h *EventHandler) ListDayEvents(ctx context.Context, req *pb.DateRequest) (
 	return eventsToResponse(events), nil
 }
 
-func (h *EventHandler) ListWeekEvents(ctx context.Context, req *pb.DateRequest) (*pb.EventListResponse, error) {
-	events, err := h.app.GetEventsForWeek(ctx, req.GetDate().AsTime())
+var sortFields = map[pb.EventSortField]storage.SortField{
+	pb.EventSortField_EVENT_SORT_FIELD_START_TIME: storage.SortByStartTime,
+	pb.EventSortField_EVENT_SORT_FIELD_TITLE:      storage.SortByTitle,
+}
+
+func (h *EventHandler) ListEvents(ctx context.Context, req *pb.ListEventsRequest) (*pb.EventListResponse, error) {
+	sortBy, ok := sortFields[req.GetSortBy()]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "unknown sort field")
+	}
+
+	query := storage.ListEventsQuery{
+		Title:      req.GetTitle(),
+		SortBy:     sortBy,
+		Descending: req.GetDescending(),
+		PageSize:   int(req.GetPageSize()),
+		Cursor:     req.GetPageToken(),
+	}
+
+	if req.GetFrom() != nil {
+		from := req.GetFrom().AsTime()
+		query.From = &from
+	}
+	if req.GetTo() != nil {
+		to := req.GetTo().AsTime()
+		query.To = &to
+	}
+
+	page, err := h.app.ListEvents(ctx, query)
 	if err != nil {
+		if errors.Is(err, storage.ErrInvalidQuery) || errors.Is(err, storage.ErrInvalidCursor) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	return eventsToResponse(events), nil
+	resp := eventsToResponse(page.Events)
+	resp.NextPageToken = page.NextCursor
+
+	return resp, nil
+}
+
+func (h *EventHandler) ListDayEvents(ctx context.Context, req *pb.DateRequest) (*pb.EventListResponse, error) {
+	return h.listPeriodEvents(ctx, req.GetTimeZone(), func(loc *time.Location) ([]storage.Event, error) {
+		return h.app.GetEventsForDay(ctx, dateIn(req.GetDate(), loc))
+	})
+}
+
+// ListWeekEvents lists events of the week containing the date or of the ISO week.
+func (h *EventHandler) ListWeekEvents(ctx context.Context, req *pb.WeekRequest) (*pb.EventListResponse, error) {
+	weekStart, ok := weekdays[req.GetWeekStart()]
+	if !ok && req.GetWeekStart() != pb.Weekday_WEEKDAY_UNSPECIFIED {
+		return nil, status.Error(codes.InvalidArgument, "unknown week_start")
+	}
+
+	if req.GetIsoWeek() != "" {
+		if req.GetDate() != nil || ok {
+			return nil, status.Error(codes.InvalidArgument, "iso_week can not be combined with date or week_start")
+		}
+
+		return h.listPeriodEvents(ctx, req.GetTimeZone(), func(loc *time.Location) ([]storage.Event, error) {
+			monday, err := app.ISOWeekStart(req.GetIsoWeek(), loc)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", storage.ErrInvalidQuery, err)
+			}
+
+			return h.app.GetEventsForWeekStarting(ctx, monday, time.Monday)
+		})
+	}
+
+	return h.listPeriodEvents(ctx, req.GetTimeZone(), func(loc *time.Location) ([]storage.Event, error) {
+		date := dateIn(req.GetDate(), loc)
+		if !ok {
+			return h.app.GetEventsForWeek(ctx, date)
+		}
+
+		return h.app.GetEventsForWeekStarting(ctx, date, weekStart)
+	})
 }
 
 func (h *EventHandler) ListMonthEvents(ctx context.Context, req *pb.DateRequest) (*pb.EventListResponse, error) {
-	events, err := h.app.GetEventsForMonth(ctx, req.GetDate().AsTime())
+	return h.listPeriodEvents(ctx, req.GetTimeZone(), func(loc *time.Location) ([]storage.Event, error) {
+		return h.app.GetEventsForMonth(ctx, dateIn(req.GetDate(), loc))
+	})
+}
+
+var weekdays = map[pb.Weekday]time.Weekday{
+	pb.Weekday_WEEKDAY_MONDAY:    time.Monday,
+	pb.Weekday_WEEKDAY_TUESDAY:   time.Tuesday,
+	pb.Weekday_WEEKDAY_WEDNESDAY: time.Wednesday,
+	pb.Weekday_WEEKDAY_THURSDAY:  time.Thursday,
+	pb.Weekday_WEEKDAY_FRIDAY:    time.Friday,
+	pb.Weekday_WEEKDAY_SATURDAY:  time.Saturday,
+	pb.Weekday_WEEKDAY_SUNDAY:    time.Sunday,
+}
+
+// dateIn returns midnight of the UTC date of ts in the location, so that the date does not depend
+// on the offset of the location.
+func dateIn(ts *timestamppb.Timestamp, loc *time.Location) time.Time {
+	t := ts.AsTime()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// listPeriodEvents lists events of a period in the requested time zone.
+func (h *EventHandler) listPeriodEvents(
+	ctx context.Context,
+	timeZone string,
+	list func(loc *time.Location) ([]storage.Event, error),
+) (*pb.EventListResponse, error) {
+	loc, err := h.app.ResolveLocation(ctx, timeZone)
 	if err != nil {
+		return nil, timeZoneError(err)
+	}
+
+	events, err := list(loc)
+	if err != nil {
+		if errors.Is(err, storage.ErrInvalidQuery) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	return eventsToResponse(events), nil
+	resp := eventsToResponse(events)
+	resp.TimeZone = loc.String()
+
+	return resp, nil
+}
+
+// eventChangeError maps errors of creating, updating and restoring events to gRPC statuses.
+func eventChangeError(err error) error {
+	switch {
+	case errors.Is(err, storage.ErrEventNotFound):
+		return status.Error(codes.NotFound, "event not found")
+	case errors.Is(err, recurrence.ErrInvalidRule), errors.Is(err, storage.ErrInvalidInterval),
+		errors.Is(err, app.ErrUnknownTimeZone):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, storage.ErrDateBusy):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, storage.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, storage.ErrVersionConflict):
+		return status.Error(codes.Aborted, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
 }
 
 func eventsToResponse(events []storage.Event) *pb.EventListResponse {
@@ -160,7 +304,7 @@ func createOrUpdateRequestToStorageParams(
 		return nil, status.Error(codes.InvalidArgument, "start and end time must be provided")
 	}
 
-	if !helpers.IsValidUUID(req.GetOwnerId()) {
+	if req.GetOwnerId() != "" && !helpers.IsValidUUID(req.GetOwnerId()) {
 		return nil, status.Error(codes.InvalidArgument, "owner_id must be uuid")
 	}
 
@@ -169,13 +313,22 @@ func createOrUpdateRequestToStorageParams(
 		notifyBefore = req.GetNotifyBefore().AsDuration()
 	}
 
+	exceptions := make([]time.Time, 0, len(req.GetRecurrenceExceptions()))
+	for _, ex := range req.GetRecurrenceExceptions() {
+		exceptions = append(exceptions, ex.AsTime())
+	}
+
 	return &storage.CreateOrUpdateEventParams{
-		Title:        req.GetTitle(),
-		StartTime:    req.GetStartTime().AsTime(),
-		EndTime:      req.GetEndTime().AsTime(),
-		Description:  req.Description,
-		OwnerID:      req.GetOwnerId(),
-		NotifyBefore: &notifyBefore,
+		Title:                req.GetTitle(),
+		StartTime:            req.GetStartTime().AsTime(),
+		EndTime:              req.GetEndTime().AsTime(),
+		Description:          req.Description,
+		OwnerID:              req.GetOwnerId(),
+		NotifyBefore:         &notifyBefore,
+		RecurrenceRule:       req.RecurrenceRule,
+		RecurrenceExceptions: exceptions,
+		AllDay:               req.GetAllDay(),
+		TimeZone:             req.GetTimeZone(),
 	}, nil
 }
 
@@ -186,11 +339,29 @@ func eventToProto(e storage.Event) *pb.Event {
 		StartTime: timestamppb.New(e.StartTime),
 		EndTime:   timestamppb.New(e.EndTime),
 		OwnerId:   e.OwnerID,
+		Version:   e.Version,
+		AllDay:    e.AllDay,
+		TimeZone:  e.TimeZone,
 	}
 
 	if e.NotifyBefore != nil {
 		eventProto.NotifyBefore = durationpb.New(*e.NotifyBefore)
 	}
 
+	if len(e.Attendees) > 0 {
+		eventProto.Attendees = attendeesToProto(e.Attendees)
+	}
+
+	if e.DeletedAt != nil {
+		eventProto.DeletedAt = timestamppb.New(*e.DeletedAt)
+	}
+
+	if e.RecurrenceRule != nil {
+		eventProto.RecurrenceRule = e.RecurrenceRule
+		for _, ex := range e.RecurrenceExceptions {
+			eventProto.RecurrenceExceptions = append(eventProto.RecurrenceExceptions, timestamppb.New(ex))
+		}
+	}
+
 	return eventProto
 }
@@ -0,0 +1,111 @@
+package grpchandler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/app"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/helpers"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+	pb "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/pb/event"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *EventHandler) FreeBusy(ctx context.Context, req *pb.FreeBusyRequest) (*pb.FreeBusyResponse, error) {
+	if req.GetFrom() == nil || req.GetTo() == nil {
+		return nil, status.Error(codes.InvalidArgument, "from and to must be provided")
+	}
+
+	if err := validateUserIDs(req.GetUserIds()); err != nil {
+		return nil, err
+	}
+
+	intervals, err := h.app.FreeBusy(ctx, req.GetUserIds(), req.GetFrom().AsTime(), req.GetTo().AsTime())
+	if err != nil {
+		if errors.Is(err, storage.ErrInvalidQuery) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.FreeBusyResponse{Busy: intervalsToProto(intervals)}, nil
+}
+
+func (h *EventHandler) FindSlots(ctx context.Context, req *pb.FindSlotsRequest) (*pb.FindSlotsResponse, error) {
+	query, err := findSlotsRequestToQuery(req)
+	if err != nil {
+		return nil, err
+	}
+
+	slots, err := h.app.FindSlots(ctx, query)
+	if err != nil {
+		if errors.Is(err, storage.ErrInvalidQuery) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.FindSlotsResponse{Slots: intervalsToProto(slots)}, nil
+}
+
+func findSlotsRequestToQuery(req *pb.FindSlotsRequest) (app.SlotQuery, error) {
+	if req.GetFrom() == nil || req.GetTo() == nil || req.GetDuration() == nil {
+		return app.SlotQuery{}, status.Error(codes.InvalidArgument, "duration, from and to must be provided")
+	}
+
+	if err := validateUserIDs(req.GetUserIds()); err != nil {
+		return app.SlotQuery{}, err
+	}
+
+	query := app.SlotQuery{
+		OwnerIDs:  req.GetUserIds(),
+		Duration:  req.GetDuration().AsDuration(),
+		From:      req.GetFrom().AsTime(),
+		To:        req.GetTo().AsTime(),
+		Step:      req.GetStep().AsDuration(),
+		Limit:     int(req.GetLimit()),
+		Locations: make(map[string]*time.Location, len(req.GetTimeZones())),
+	}
+
+	if wh := req.GetWorkingHours(); wh != nil {
+		query.WorkingHours = app.WorkingHours{
+			Start:        wh.GetStart().AsDuration(),
+			End:          wh.GetEnd().AsDuration(),
+			WeekdaysOnly: wh.GetWeekdaysOnly(),
+		}
+	}
+
+	for userID, name := range req.GetTimeZones() {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return app.SlotQuery{}, status.Errorf(codes.InvalidArgument, "unknown time zone %q", name)
+		}
+		query.Locations[userID] = loc
+	}
+
+	return query, nil
+}
+
+func validateUserIDs(ids []string) error {
+	for _, id := range ids {
+		if !helpers.IsValidUUID(id) {
+			return status.Error(codes.InvalidArgument, "user_ids must be uuids")
+		}
+	}
+
+	return nil
+}
+
+func intervalsToProto(intervals []app.Interval) []*pb.TimeInterval {
+	result := make([]*pb.TimeInterval, len(intervals))
+	for i, interval := range intervals {
+		result[i] = &pb.TimeInterval{Start: timestamppb.New(interval.Start), End: timestamppb.New(interval.End)}
+	}
+
+	return result
+}
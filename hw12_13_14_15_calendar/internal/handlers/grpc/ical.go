@@ -0,0 +1,113 @@
+package grpchandler
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/helpers"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/ical"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+	pb "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/pb/event"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	chunkSize     = 32 << 10
+	maxImportSize = 10 << 20
+)
+
+func (h *EventHandler) ExportICalendar(
+	req *pb.ExportICalendarRequest,
+	stream grpc.ServerStreamingServer[pb.ICalendarChunk],
+) error {
+	if req.GetFrom() == nil || req.GetTo() == nil {
+		return status.Error(codes.InvalidArgument, "from and to must be provided")
+	}
+
+	if req.OwnerId != nil && !helpers.IsValidUUID(req.GetOwnerId()) {
+		return status.Error(codes.InvalidArgument, "owner_id must be uuid")
+	}
+
+	events, err := h.app.ExportEvents(stream.Context(), req.GetOwnerId(), req.GetFrom().AsTime(), req.GetTo().AsTime())
+	if err != nil {
+		if errors.Is(err, storage.ErrForbidden) {
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	var buf bytes.Buffer
+	if err := ical.Encode(&buf, events, time.Now()); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	for buf.Len() > 0 {
+		if err := stream.Send(&pb.ICalendarChunk{Data: buf.Next(chunkSize)}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *EventHandler) ImportICalendar(
+	stream grpc.ClientStreamingServer[pb.ImportICalendarRequest, pb.ImportICalendarResponse],
+) error {
+	var (
+		ownerID string
+		buf     bytes.Buffer
+	)
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if ownerID == "" {
+			ownerID = req.GetOwnerId()
+		}
+
+		if buf.Len()+len(req.GetData()) > maxImportSize {
+			return status.Errorf(codes.ResourceExhausted, "calendar is larger than %d bytes", maxImportSize)
+		}
+		buf.Write(req.GetData())
+	}
+
+	if ownerID != "" && !helpers.IsValidUUID(ownerID) {
+		return status.Error(codes.InvalidArgument, "owner_id must be uuid")
+	}
+
+	decoded, err := ical.Decode(&buf)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := h.app.ImportEvents(stream.Context(), ownerID, decoded)
+	if err != nil {
+		if errors.Is(err, storage.ErrForbidden) {
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ImportICalendarResponse{Imported: int64(result.Imported)}
+	for _, importErr := range result.Errors {
+		resp.Errors = append(resp.Errors, &pb.ImportICalendarError{
+			Index: int64(importErr.Index),
+			Uid:   importErr.UID,
+			Error: importErr.Err.Error(),
+		})
+	}
+
+	return stream.SendAndClose(resp)
+}
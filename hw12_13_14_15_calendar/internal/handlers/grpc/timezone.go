@@ -0,0 +1,49 @@
+package grpchandler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/app"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/helpers"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+	pb "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/pb/event"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *EventHandler) GetTimeZone(ctx context.Context, req *pb.GetTimeZoneRequest) (*pb.TimeZone, error) {
+	if req.GetUserId() != "" && !helpers.IsValidUUID(req.GetUserId()) {
+		return nil, status.Error(codes.InvalidArgument, "user_id must be uuid")
+	}
+
+	timeZone, err := h.app.GetTimeZone(ctx, req.GetUserId())
+	if err != nil {
+		return nil, timeZoneError(err)
+	}
+
+	return &pb.TimeZone{UserId: req.GetUserId(), TimeZone: timeZone}, nil
+}
+
+func (h *EventHandler) SetTimeZone(ctx context.Context, req *pb.TimeZone) (*pb.EmptyResponse, error) {
+	if req.GetUserId() != "" && !helpers.IsValidUUID(req.GetUserId()) {
+		return nil, status.Error(codes.InvalidArgument, "user_id must be uuid")
+	}
+
+	if err := h.app.SetTimeZone(ctx, req.GetUserId(), req.GetTimeZone()); err != nil {
+		return nil, timeZoneError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+func timeZoneError(err error) error {
+	switch {
+	case errors.Is(err, app.ErrUnknownTimeZone), errors.Is(err, storage.ErrInvalidQuery):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, storage.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,101 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/helpers"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+	"github.com/go-playground/validator/v10"
+)
+
+type inviteAttendeeRequest struct {
+	UserID string `json:"userId" validate:"required,uuid"`
+}
+
+type respondToEventRequest struct {
+	Status string `json:"status" validate:"required,oneof=needs-action accepted declined tentative"`
+}
+
+func (e *EventHandler) InviteAttendee(w http.ResponseWriter, r *http.Request) {
+	var req inviteAttendeeRequest
+	if !e.decodeRequest(w, r, &req) {
+		return
+	}
+
+	if err := e.app.InviteAttendee(r.Context(), r.PathValue("id"), req.UserID); err != nil {
+		respondWithAttendeeError(w, err, "Failed to invite attendee")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusCreated, OK())
+}
+
+func (e *EventHandler) RemoveAttendee(w http.ResponseWriter, r *http.Request) {
+	userID := r.PathValue("userId")
+	if !helpers.IsValidUUID(userID) {
+		RespondWithJSON(w, http.StatusBadRequest, Error("userId must be uuid"))
+		return
+	}
+
+	if err := e.app.RemoveAttendee(r.Context(), r.PathValue("id"), userID); err != nil {
+		respondWithAttendeeError(w, err, "Failed to remove attendee")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, OK())
+}
+
+// RespondToEvent sets the status of the attendee from the path, who must be the calling user.
+func (e *EventHandler) RespondToEvent(w http.ResponseWriter, r *http.Request) {
+	userID := r.PathValue("userId")
+	if !helpers.IsValidUUID(userID) {
+		RespondWithJSON(w, http.StatusBadRequest, Error("userId must be uuid"))
+		return
+	}
+
+	var req respondToEventRequest
+	if !e.decodeRequest(w, r, &req) {
+		return
+	}
+
+	status := storage.AttendeeStatus(req.Status)
+	if err := e.app.RespondToEvent(r.Context(), r.PathValue("id"), userID, status); err != nil {
+		respondWithAttendeeError(w, err, "Failed to respond to event")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, OK())
+}
+
+// decodeRequest decodes and validates the JSON body, responding with an error if it is invalid.
+func (e *EventHandler) decodeRequest(w http.ResponseWriter, r *http.Request, req any) bool {
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		RespondWithJSON(w, http.StatusBadRequest, Error("Invalid request payload"))
+		return false
+	}
+
+	if err := e.validator.Struct(req); err != nil {
+		var validateErr validator.ValidationErrors
+		if errors.As(err, &validateErr) {
+			RespondWithJSON(w, http.StatusBadRequest, ValidationError(validateErr))
+			return false
+		}
+	}
+
+	return true
+}
+
+func respondWithAttendeeError(w http.ResponseWriter, err error, internalMessage string) {
+	switch {
+	case errors.Is(err, storage.ErrEventNotFound), errors.Is(err, storage.ErrAttendeeNotFound):
+		RespondWithJSON(w, http.StatusNotFound, Error(err.Error()))
+	case errors.Is(err, storage.ErrForbidden):
+		RespondWithJSON(w, http.StatusForbidden, Error(err.Error()))
+	case errors.Is(err, storage.ErrOwnerAttendee), errors.Is(err, storage.ErrInvalidAttendeeStatus):
+		RespondWithJSON(w, http.StatusBadRequest, Error(err.Error()))
+	default:
+		RespondWithJSON(w, http.StatusInternalServerError, Error(internalMessage))
+	}
+}
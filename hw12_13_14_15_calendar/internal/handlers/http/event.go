@@ -1,14 +1,19 @@
 package httphandler
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/app"
 	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/helpers"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/recurrence"
 	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
 	"github.com/go-playground/validator/v10"
 )
@@ -21,12 +26,16 @@ type EventHandler struct {
 }
 
 type createOrUpdateEventRequest struct {
-	Title        string  `json:"title" validate:"required,min=1,max=100"`
-	StartTime    string  `json:"startTime" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
-	EndTime      string  `json:"endTime" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
-	Description  *string `json:"description" validate:"omitempty,max=500"`
-	OwnerID      string  `json:"ownerId" validate:"required,uuid"`
-	NotifyBefore *int    `json:"notifyBefore" validate:"omitempty,min=0"`
+	Title                string   `json:"title" validate:"required,min=1,max=100"`
+	StartTime            string   `json:"startTime" validate:"required"`
+	EndTime              string   `json:"endTime" validate:"required"`
+	Description          *string  `json:"description" validate:"omitempty,max=500"`
+	OwnerID              string   `json:"ownerId" validate:"omitempty,uuid"`
+	NotifyBefore         *int     `json:"notifyBefore" validate:"omitempty,min=0"`
+	RecurrenceRule       *string  `json:"recurrenceRule" validate:"omitempty,max=255"`
+	RecurrenceExceptions []string `json:"recurrenceExceptions"`
+	AllDay               bool     `json:"allDay"`
+	TimeZone             string   `json:"timeZone" validate:"omitempty,max=64"`
 }
 
 func NewEventHandler(app app.Application) *EventHandler {
@@ -56,13 +65,13 @@ func (e *EventHandler) prepareForCreateOrUpdate(
 		}
 	}
 
-	startTime, err := time.Parse(time.RFC3339, req.StartTime)
+	startTime, err := parseEventTime(req.StartTime, req.AllDay)
 	if err != nil {
 		RespondWithJSON(w, http.StatusBadRequest, Error("Invalid start time format"))
 		return nil, err
 	}
 
-	endTime, err := time.Parse(time.RFC3339, req.EndTime)
+	endTime, err := parseEventTime(req.EndTime, req.AllDay)
 	if err != nil {
 		RespondWithJSON(w, http.StatusBadRequest, Error("Invalid end time format"))
 		return nil, err
@@ -74,34 +83,61 @@ func (e *EventHandler) prepareForCreateOrUpdate(
 		notifyBefore = &duration
 	}
 
+	exceptions := make([]time.Time, 0, len(req.RecurrenceExceptions))
+	for _, ex := range req.RecurrenceExceptions {
+		exception, err := parseEventTime(ex, req.AllDay)
+		if err != nil {
+			RespondWithJSON(w, http.StatusBadRequest, Error("Invalid recurrence exception format"))
+			return nil, err
+		}
+		exceptions = append(exceptions, exception)
+	}
+
 	return &storage.CreateOrUpdateEventParams{
-		Title:        req.Title,
-		StartTime:    startTime,
-		EndTime:      endTime,
-		Description:  req.Description,
-		OwnerID:      req.OwnerID,
-		NotifyBefore: notifyBefore,
+		Title:                req.Title,
+		StartTime:            startTime,
+		EndTime:              endTime,
+		Description:          req.Description,
+		OwnerID:              req.OwnerID,
+		NotifyBefore:         notifyBefore,
+		RecurrenceRule:       req.RecurrenceRule,
+		RecurrenceExceptions: exceptions,
+		AllDay:               req.AllDay,
+		TimeZone:             req.TimeZone,
 	}, nil
 }
 
+// parseEventTime parses an RFC 3339 time or, for all-day events, also a date like 2026-10-18.
+// All-day events last from the date of startTime to the date of endTime.
+func parseEventTime(value string, allDay bool) (time.Time, error) {
+	if allDay && len(value) == len(dateFormat) {
+		return time.Parse(dateFormat, value)
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+// Create responds with the created event, its ETag and location like Get does.
 func (e *EventHandler) Create(w http.ResponseWriter, r *http.Request) {
 	params, err := e.prepareForCreateOrUpdate(w, r)
 	if err != nil {
 		return
 	}
 
-	_, err = e.app.CreateEvent(r.Context(), *params)
+	event, err := e.app.CreateEvent(r.Context(), *params)
 	if err != nil {
 		if errors.Is(err, storage.ErrEventAlreadyExists) {
 			RespondWithJSON(w, http.StatusBadRequest, "Event already exists")
 			return
 		}
 
-		RespondWithJSON(w, http.StatusInternalServerError, Error("Failed to create event"))
+		respondWithEventChangeError(w, err, "Failed to create event")
 		return
 	}
 
-	RespondWithJSON(w, http.StatusCreated, OK())
+	w.Header().Set("Location", "/api/events/"+event.ID)
+	w.Header().Set("ETag", formatETag(event.Version))
+	RespondWithJSON(w, http.StatusCreated, event)
 }
 
 func (e *EventHandler) Update(w http.ResponseWriter, r *http.Request) {
@@ -116,27 +152,34 @@ func (e *EventHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	expectedVersion, err := parseIfMatch(r.Header.Get("If-Match"))
+	if err != nil {
+		RespondWithJSON(w, http.StatusPreconditionFailed, Error(err.Error()))
+		return
+	}
+
 	event := storage.Event{
-		ID:           eventID,
-		Title:        param.Title,
-		StartTime:    param.StartTime,
-		EndTime:      param.EndTime,
-		Description:  param.Description,
-		OwnerID:      param.OwnerID,
-		NotifyBefore: param.NotifyBefore,
+		ID:                   eventID,
+		Title:                param.Title,
+		StartTime:            param.StartTime,
+		EndTime:              param.EndTime,
+		Description:          param.Description,
+		OwnerID:              param.OwnerID,
+		NotifyBefore:         param.NotifyBefore,
+		RecurrenceRule:       param.RecurrenceRule,
+		RecurrenceExceptions: param.RecurrenceExceptions,
+		Version:              expectedVersion,
+		AllDay:               param.AllDay,
+		TimeZone:             param.TimeZone,
 	}
 
-	err = e.app.UpdateEvent(r.Context(), event)
+	updated, err := e.app.UpdateEvent(r.Context(), event)
 	if err != nil {
-		if errors.Is(err, storage.ErrEventNotFound) {
-			RespondWithJSON(w, http.StatusNotFound, "Event not found")
-			return
-		}
-
-		RespondWithJSON(w, http.StatusInternalServerError, Error("Failed to create event"))
+		respondWithEventChangeError(w, err, "Failed to update event")
 		return
 	}
 
+	w.Header().Set("ETag", formatETag(updated.Version))
 	RespondWithJSON(w, http.StatusCreated, OK())
 }
 
@@ -154,6 +197,11 @@ func (e *EventHandler) Delete(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if errors.Is(err, storage.ErrForbidden) {
+			RespondWithJSON(w, http.StatusForbidden, Error(err.Error()))
+			return
+		}
+
 		RespondWithJSON(w, http.StatusInternalServerError, Error("Failed to delete event"))
 		return
 	}
@@ -161,6 +209,39 @@ func (e *EventHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	RespondWithJSON(w, http.StatusOK, OK())
 }
 
+// Restore moves the event out of the trash and returns it.
+func (e *EventHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	eventID := r.PathValue("id")
+	if eventID == "" {
+		RespondWithJSON(w, http.StatusBadRequest, Error("Event ID is required"))
+		return
+	}
+
+	event, err := e.app.RestoreEvent(r.Context(), eventID)
+	if err != nil {
+		respondWithEventChangeError(w, err, "Failed to restore event")
+		return
+	}
+
+	w.Header().Set("ETag", formatETag(event.Version))
+	RespondWithJSON(w, http.StatusOK, event)
+}
+
+// ListTrash returns deleted events which can be restored.
+func (e *EventHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	events, err := e.app.ListDeletedEvents(r.Context())
+	if err != nil {
+		RespondWithJSON(w, http.StatusInternalServerError, Error("Failed to get deleted events"))
+		return
+	}
+
+	if events == nil {
+		events = []storage.Event{}
+	}
+
+	RespondWithJSON(w, http.StatusOK, EventListResponse{Events: events})
+}
+
 func (e *EventHandler) Get(w http.ResponseWriter, r *http.Request) {
 	eventID := r.PathValue("id")
 	if eventID == "" {
@@ -175,81 +256,230 @@ func (e *EventHandler) Get(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if errors.Is(err, storage.ErrForbidden) {
+			RespondWithJSON(w, http.StatusForbidden, Error(err.Error()))
+			return
+		}
+
 		RespondWithJSON(w, http.StatusInternalServerError, Error("Failed to get event"))
 		return
 	}
 
+	w.Header().Set("ETag", formatETag(event.Version))
 	RespondWithJSON(w, http.StatusOK, event)
 }
 
-func (e *EventHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	events, err := e.app.GetAllEvents(r.Context())
+// List returns a page of events filtered by the from, to and title query parameters.
+// The sort parameter is startTime or title, prefixed with "-" for descending order.
+func (e *EventHandler) List(w http.ResponseWriter, r *http.Request) {
+	query, err := parseListQuery(r)
+	if err != nil {
+		RespondWithJSON(w, http.StatusBadRequest, Error(err.Error()))
+		return
+	}
+
+	page, err := e.app.ListEvents(r.Context(), query)
 	if err != nil {
+		if errors.Is(err, storage.ErrInvalidQuery) || errors.Is(err, storage.ErrInvalidCursor) {
+			RespondWithJSON(w, http.StatusBadRequest, Error(err.Error()))
+			return
+		}
+
 		RespondWithJSON(w, http.StatusInternalServerError, Error("Failed to get events"))
 		return
 	}
 
-	RespondWithJSON(w, http.StatusOK, events)
+	events := page.Events
+	if events == nil {
+		events = []storage.Event{}
+	}
+
+	RespondWithJSON(w, http.StatusOK, EventListResponse{Events: events, NextPageToken: page.NextCursor})
 }
 
 func (e *EventHandler) GetDayEvents(w http.ResponseWriter, r *http.Request) {
-	date, err := parseDateParam(r)
-	if err != nil {
-		RespondWithJSON(w, http.StatusBadRequest, Error(err.Error()))
+	e.listPeriodEvents(w, r, parseDateParam, e.app.GetEventsForDay, "Failed to get day events: ")
+}
+
+// GetWeekEvents responds with events of the week containing the date parameter or of the isoWeek parameter.
+// The weekStart parameter overrides the configured first day of week, ISO weeks always start on Monday.
+func (e *EventHandler) GetWeekEvents(w http.ResponseWriter, r *http.Request) {
+	const internalMessage = "Failed to get week events: "
+
+	params := r.URL.Query()
+	if params.Has("isoWeek") {
+		if params.Has("date") || params.Has("weekStart") {
+			RespondWithJSON(w, http.StatusBadRequest, Error("isoWeek can not be combined with date or weekStart"))
+			return
+		}
+
+		e.listPeriodEvents(w, r, parseISOWeekParam, func(ctx context.Context, monday time.Time) ([]storage.Event, error) {
+			return e.app.GetEventsForWeekStarting(ctx, monday, time.Monday)
+		}, internalMessage)
 		return
 	}
 
-	events, err := e.app.GetEventsForDay(r.Context(), date)
-	if err != nil {
-		RespondWithJSON(w, http.StatusInternalServerError, Error("Failed to get day events: "+err.Error()))
-		return
+	list := e.app.GetEventsForWeek
+	if params.Has("weekStart") {
+		weekStart, err := app.ParseWeekday(params.Get("weekStart"))
+		if err != nil {
+			RespondWithJSON(w, http.StatusBadRequest, Error(err.Error()))
+			return
+		}
+
+		list = func(ctx context.Context, date time.Time) ([]storage.Event, error) {
+			return e.app.GetEventsForWeekStarting(ctx, date, weekStart)
+		}
 	}
 
-	RespondWithJSON(w, http.StatusOK, events)
+	e.listPeriodEvents(w, r, parseDateParam, list, internalMessage)
 }
 
-func (e *EventHandler) GetWeekEvents(w http.ResponseWriter, r *http.Request) {
-	date, err := parseDateParam(r)
+func (e *EventHandler) GetMonthEvents(w http.ResponseWriter, r *http.Request) {
+	e.listPeriodEvents(w, r, parseDateParam, e.app.GetEventsForMonth, "Failed to get month events: ")
+}
+
+// listPeriodEvents responds with events of the period containing the parsed date.
+// Boundaries and event times are in the tz parameter time zone or the default one of the calling user.
+func (e *EventHandler) listPeriodEvents(
+	w http.ResponseWriter,
+	r *http.Request,
+	parseDate func(*http.Request, *time.Location) (time.Time, error),
+	list func(context.Context, time.Time) ([]storage.Event, error),
+	internalMessage string,
+) {
+	loc, err := e.app.ResolveLocation(r.Context(), r.URL.Query().Get("tz"))
+	if err != nil {
+		respondWithTimeZoneError(w, err)
+		return
+	}
+
+	date, err := parseDate(r, loc)
 	if err != nil {
 		RespondWithJSON(w, http.StatusBadRequest, Error(err.Error()))
 		return
 	}
 
-	events, err := e.app.GetEventsForWeek(r.Context(), date)
+	events, err := list(r.Context(), date)
 	if err != nil {
-		RespondWithJSON(w, http.StatusInternalServerError, Error("Failed to get week events: "+err.Error()))
+		RespondWithJSON(w, http.StatusInternalServerError, Error(internalMessage+err.Error()))
 		return
 	}
 
 	RespondWithJSON(w, http.StatusOK, events)
 }
 
-func (e *EventHandler) GetMonthEvents(w http.ResponseWriter, r *http.Request) {
-	date, err := parseDateParam(r)
-	if err != nil {
-		RespondWithJSON(w, http.StatusBadRequest, Error(err.Error()))
-		return
+var sortFields = map[string]storage.SortField{
+	"startTime": storage.SortByStartTime,
+	"title":     storage.SortByTitle,
+}
+
+func parseListQuery(r *http.Request) (storage.ListEventsQuery, error) {
+	params := r.URL.Query()
+	query := storage.ListEventsQuery{
+		Title:  params.Get("title"),
+		Cursor: params.Get("pageToken"),
+	}
+
+	var err error
+	if query.From, err = parseTimeParam(params, "from"); err != nil {
+		return query, err
+	}
+	if query.To, err = parseTimeParam(params, "to"); err != nil {
+		return query, err
 	}
 
-	events, err := e.app.GetEventsForMonth(r.Context(), date)
+	if sort := params.Get("sort"); sort != "" {
+		query.Descending = strings.HasPrefix(sort, "-")
+		field, ok := sortFields[strings.TrimPrefix(sort, "-")]
+		if !ok {
+			return query, fmt.Errorf("invalid sort, use startTime or title with optional - prefix")
+		}
+		query.SortBy = field
+	}
+
+	if pageSize := params.Get("pageSize"); pageSize != "" {
+		size, err := strconv.Atoi(pageSize)
+		if err != nil || size <= 0 {
+			return query, fmt.Errorf("pageSize must be a positive number")
+		}
+		query.PageSize = size
+	}
+
+	return query, nil
+}
+
+func parseTimeParam(params url.Values, name string) (*time.Time, error) {
+	value := params.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
 	if err != nil {
-		RespondWithJSON(w, http.StatusInternalServerError, Error("Failed to get month events: "+err.Error()))
-		return
+		return nil, fmt.Errorf("invalid %s format, use RFC 3339 (e.g. 2023-12-31T10:00:00Z)", name)
 	}
 
-	RespondWithJSON(w, http.StatusOK, events)
+	return &t, nil
 }
 
-func parseDateParam(r *http.Request) (time.Time, error) {
+func parseDateParam(r *http.Request, loc *time.Location) (time.Time, error) {
 	dateStr := r.URL.Query().Get("date")
 	if dateStr == "" {
 		return time.Time{}, fmt.Errorf("date parameter is required (format: YYYY-MM-DD)")
 	}
 
-	date, err := time.Parse(dateFormat, dateStr)
+	date, err := time.ParseInLocation(dateFormat, dateStr, loc)
 	if err != nil {
 		return time.Time{}, fmt.Errorf("invalid date format, use YYYY-MM-DD (e.g. 2023-12-31)")
 	}
 
 	return date, nil
 }
+
+func parseISOWeekParam(r *http.Request, loc *time.Location) (time.Time, error) {
+	return app.ISOWeekStart(r.URL.Query().Get("isoWeek"), loc)
+}
+
+func formatETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// parseIfMatch returns the event version required by the If-Match header, 0 if any version is accepted.
+func parseIfMatch(header string) (int64, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	value, err := strconv.Unquote(header)
+	if err != nil {
+		return 0, fmt.Errorf("If-Match must be a single strong ETag")
+	}
+
+	version, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("If-Match does not match any event version")
+	}
+
+	return version, nil
+}
+
+// respondWithEventChangeError maps errors of creating, updating and restoring events to HTTP responses.
+func respondWithEventChangeError(w http.ResponseWriter, err error, internalMessage string) {
+	switch {
+	case errors.Is(err, storage.ErrEventNotFound):
+		RespondWithJSON(w, http.StatusNotFound, "Event not found")
+	case errors.Is(err, recurrence.ErrInvalidRule), errors.Is(err, storage.ErrInvalidInterval),
+		errors.Is(err, app.ErrUnknownTimeZone):
+		RespondWithJSON(w, http.StatusBadRequest, Error(err.Error()))
+	case errors.Is(err, storage.ErrDateBusy):
+		RespondWithJSON(w, http.StatusConflict, Error(err.Error()))
+	case errors.Is(err, storage.ErrForbidden):
+		RespondWithJSON(w, http.StatusForbidden, Error(err.Error()))
+	case errors.Is(err, storage.ErrVersionConflict):
+		RespondWithJSON(w, http.StatusPreconditionFailed, Error(err.Error()))
+	default:
+		RespondWithJSON(w, http.StatusInternalServerError, Error(internalMessage))
+	}
+}
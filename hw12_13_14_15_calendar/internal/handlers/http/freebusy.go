@@ -0,0 +1,67 @@
+package httphandler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/app"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+type freeBusyRequest struct {
+	Users []string `json:"users" validate:"required,min=1,max=100,dive,uuid"`
+	From  string   `json:"from" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	To    string   `json:"to" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+type FreeBusyResponse struct {
+	Busy []TimeInterval `json:"busy"`
+}
+
+type TimeInterval struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// FreeBusy returns merged intervals in which any of the users is busy.
+func (e *EventHandler) FreeBusy(w http.ResponseWriter, r *http.Request) {
+	var req freeBusyRequest
+	if !e.decodeRequest(w, r, &req) {
+		return
+	}
+
+	from, err := time.Parse(time.RFC3339, req.From)
+	if err != nil {
+		RespondWithJSON(w, http.StatusBadRequest, Error("Invalid from format"))
+		return
+	}
+
+	to, err := time.Parse(time.RFC3339, req.To)
+	if err != nil {
+		RespondWithJSON(w, http.StatusBadRequest, Error("Invalid to format"))
+		return
+	}
+
+	intervals, err := e.app.FreeBusy(r.Context(), req.Users, from, to)
+	if err != nil {
+		if errors.Is(err, storage.ErrInvalidQuery) {
+			RespondWithJSON(w, http.StatusBadRequest, Error(err.Error()))
+			return
+		}
+
+		RespondWithJSON(w, http.StatusInternalServerError, Error("Failed to get free/busy"))
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, FreeBusyResponse{Busy: intervalsToResponse(intervals)})
+}
+
+func intervalsToResponse(intervals []app.Interval) []TimeInterval {
+	result := make([]TimeInterval, len(intervals))
+	for i, interval := range intervals {
+		result[i] = TimeInterval{Start: interval.Start, End: interval.End}
+	}
+
+	return result
+}
@@ -0,0 +1,132 @@
+package httphandler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/helpers"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/ical"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+const maxImportSize = 10 << 20
+
+type ImportResponse struct {
+	Status   string        `json:"status"`
+	Imported int           `json:"imported"`
+	Errors   []ImportError `json:"errors,omitempty"`
+}
+
+type ImportError struct {
+	Index int    `json:"index"`
+	UID   string `json:"uid,omitempty"`
+	Error string `json:"error"`
+}
+
+func (e *EventHandler) ExportICal(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	from, err := time.Parse(dateFormat, query.Get("from"))
+	if err != nil {
+		RespondWithJSON(w, http.StatusBadRequest, Error("from parameter is required (format: YYYY-MM-DD)"))
+		return
+	}
+
+	to, err := time.Parse(dateFormat, query.Get("to"))
+	if err != nil {
+		RespondWithJSON(w, http.StatusBadRequest, Error("to parameter is required (format: YYYY-MM-DD)"))
+		return
+	}
+
+	ownerID := query.Get("owner")
+	if ownerID != "" && !helpers.IsValidUUID(ownerID) {
+		RespondWithJSON(w, http.StatusBadRequest, Error("owner must be uuid"))
+		return
+	}
+
+	events, err := e.app.ExportEvents(r.Context(), ownerID, from, to)
+	if err != nil {
+		if errors.Is(err, storage.ErrForbidden) {
+			RespondWithJSON(w, http.StatusForbidden, Error(err.Error()))
+			return
+		}
+
+		RespondWithJSON(w, http.StatusInternalServerError, Error("Failed to export events"))
+		return
+	}
+
+	w.Header().Set("Content-Type", ical.ContentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="calendar.ics"`)
+	w.WriteHeader(http.StatusOK)
+	ical.Encode(w, events, time.Now())
+}
+
+// ImportICal creates events from an .ics file sent as the request body or as the "file" field of a multipart form.
+// Events are imported for the calling user.
+func (e *EventHandler) ImportICal(w http.ResponseWriter, r *http.Request) {
+	ownerID := r.URL.Query().Get("owner")
+	if ownerID != "" && !helpers.IsValidUUID(ownerID) {
+		RespondWithJSON(w, http.StatusBadRequest, Error("owner must be uuid"))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	body, err := importBody(r)
+	if err != nil {
+		RespondWithJSON(w, http.StatusBadRequest, Error(err.Error()))
+		return
+	}
+	defer body.Close()
+
+	decoded, err := ical.Decode(body)
+	if err != nil {
+		RespondWithJSON(w, http.StatusBadRequest, Error(err.Error()))
+		return
+	}
+
+	result, err := e.app.ImportEvents(r.Context(), ownerID, decoded)
+	if err != nil {
+		if errors.Is(err, storage.ErrForbidden) {
+			RespondWithJSON(w, http.StatusForbidden, Error(err.Error()))
+			return
+		}
+
+		RespondWithJSON(w, http.StatusInternalServerError, Error("Failed to import events"))
+		return
+	}
+
+	resp := ImportResponse{Status: statusOK, Imported: result.Imported}
+	for _, importErr := range result.Errors {
+		resp.Status = statusError
+		resp.Errors = append(resp.Errors, ImportError{
+			Index: importErr.Index,
+			UID:   importErr.UID,
+			Error: importErr.Err.Error(),
+		})
+	}
+
+	RespondWithJSON(w, http.StatusOK, resp)
+}
+
+func importBody(r *http.Request) (io.ReadCloser, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		return r.Body, nil
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, fmt.Errorf("file is larger than %d bytes", maxImportSize)
+		}
+		return nil, errors.New("file field is required")
+	}
+
+	return file, nil
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -19,6 +20,11 @@ type Response struct {
 	Error  string `json:"error,omitempty"`
 }
 
+type EventListResponse struct {
+	Events        []storage.Event `json:"events"`
+	NextPageToken string          `json:"nextPageToken,omitempty"`
+}
+
 func OK() Response {
 	return Response{
 		Status: statusOK,
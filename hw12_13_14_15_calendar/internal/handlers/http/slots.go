@@ -0,0 +1,113 @@
+package httphandler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/app"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+type findSlotsRequest struct {
+	Users []string `json:"users" validate:"required,min=1,max=100,dive,uuid"`
+	// Duration and Step are in minutes.
+	Duration     int                  `json:"duration" validate:"required,min=1,max=1440"`
+	From         string               `json:"from" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	To           string               `json:"to" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	WorkingHours *workingHoursRequest `json:"workingHours"`
+	TimeZones    map[string]string    `json:"timeZones" validate:"dive,keys,uuid,endkeys,required"`
+	Step         int                  `json:"step" validate:"omitempty,min=1,max=1440"`
+	Limit        int                  `json:"limit" validate:"omitempty,min=1,max=50"`
+}
+
+// workingHoursRequest has local times in the HH:MM format, end may be 24:00.
+type workingHoursRequest struct {
+	Start        string `json:"start" validate:"required"`
+	End          string `json:"end" validate:"required"`
+	WeekdaysOnly bool   `json:"weekdaysOnly"`
+}
+
+type SlotsResponse struct {
+	Slots []TimeInterval `json:"slots"`
+}
+
+// FindSlots returns the earliest slots in which all users are free within their working hours.
+func (e *EventHandler) FindSlots(w http.ResponseWriter, r *http.Request) {
+	var req findSlotsRequest
+	if !e.decodeRequest(w, r, &req) {
+		return
+	}
+
+	query, err := req.toQuery()
+	if err != nil {
+		RespondWithJSON(w, http.StatusBadRequest, Error(err.Error()))
+		return
+	}
+
+	slots, err := e.app.FindSlots(r.Context(), query)
+	if err != nil {
+		if errors.Is(err, storage.ErrInvalidQuery) {
+			RespondWithJSON(w, http.StatusBadRequest, Error(err.Error()))
+			return
+		}
+
+		RespondWithJSON(w, http.StatusInternalServerError, Error("Failed to find slots"))
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, SlotsResponse{Slots: intervalsToResponse(slots)})
+}
+
+func (req findSlotsRequest) toQuery() (app.SlotQuery, error) {
+	query := app.SlotQuery{
+		OwnerIDs:  req.Users,
+		Duration:  time.Duration(req.Duration) * time.Minute,
+		Step:      time.Duration(req.Step) * time.Minute,
+		Limit:     req.Limit,
+		Locations: make(map[string]*time.Location, len(req.TimeZones)),
+	}
+
+	var err error
+	if query.From, err = time.Parse(time.RFC3339, req.From); err != nil {
+		return query, errors.New("invalid from format")
+	}
+	if query.To, err = time.Parse(time.RFC3339, req.To); err != nil {
+		return query, errors.New("invalid to format")
+	}
+
+	for userID, name := range req.TimeZones {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return query, fmt.Errorf("unknown time zone %q", name)
+		}
+		query.Locations[userID] = loc
+	}
+
+	if req.WorkingHours != nil {
+		if query.WorkingHours.Start, err = parseClock(req.WorkingHours.Start); err != nil {
+			return query, err
+		}
+		if query.WorkingHours.End, err = parseClock(req.WorkingHours.End); err != nil {
+			return query, err
+		}
+		query.WorkingHours.WeekdaysOnly = req.WorkingHours.WeekdaysOnly
+	}
+
+	return query, nil
+}
+
+// parseClock parses HH:MM into the offset from midnight.
+func parseClock(s string) (time.Duration, error) {
+	hours, minutes, ok := strings.Cut(s, ":")
+	h, errH := strconv.Atoi(hours)
+	m, errM := strconv.Atoi(minutes)
+	if !ok || errH != nil || errM != nil || h < 0 || m < 0 || m > 59 || h*60+m > 24*60 {
+		return 0, fmt.Errorf("invalid time %q, want HH:MM", s)
+	}
+
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
@@ -0,0 +1,54 @@
+package httphandler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/app"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+type TimeZoneResponse struct {
+	TimeZone string `json:"timeZone"`
+}
+
+type setTimeZoneRequest struct {
+	TimeZone string `json:"timeZone" validate:"required,max=64"`
+}
+
+// GetTimeZone responds with the default time zone of the calling user.
+func (e *EventHandler) GetTimeZone(w http.ResponseWriter, r *http.Request) {
+	timeZone, err := e.app.GetTimeZone(r.Context(), "")
+	if err != nil {
+		respondWithTimeZoneError(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, TimeZoneResponse{TimeZone: timeZone})
+}
+
+// SetTimeZone sets the default time zone of the calling user.
+func (e *EventHandler) SetTimeZone(w http.ResponseWriter, r *http.Request) {
+	var req setTimeZoneRequest
+	if !e.decodeRequest(w, r, &req) {
+		return
+	}
+
+	if err := e.app.SetTimeZone(r.Context(), "", req.TimeZone); err != nil {
+		respondWithTimeZoneError(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, OK())
+}
+
+func respondWithTimeZoneError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrUnknownTimeZone), errors.Is(err, storage.ErrInvalidQuery):
+		RespondWithJSON(w, http.StatusBadRequest, Error(err.Error()))
+	case errors.Is(err, storage.ErrForbidden):
+		RespondWithJSON(w, http.StatusForbidden, Error(err.Error()))
+	default:
+		RespondWithJSON(w, http.StatusInternalServerError, Error("Failed to resolve time zone: "+err.Error()))
+	}
+}
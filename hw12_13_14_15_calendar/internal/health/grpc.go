@@ -0,0 +1,111 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCServer implements grpc.health.v1.Health. The empty service name and the names of the served services
+// all report readiness of the whole service.
+type GRPCServer struct {
+	healthpb.UnimplementedHealthServer
+
+	health   *Health
+	services map[string]struct{}
+	// interval is how often watched statuses are checked.
+	interval time.Duration
+}
+
+var _ healthpb.HealthServer = (*GRPCServer)(nil)
+
+func NewGRPCServer(h *Health, interval time.Duration, services ...string) *GRPCServer {
+	s := &GRPCServer{
+		health:   h,
+		services: map[string]struct{}{"": {}},
+		interval: interval,
+	}
+
+	for _, service := range services {
+		s.services[service] = struct{}{}
+	}
+
+	return s
+}
+
+func (s *GRPCServer) Check(
+	ctx context.Context,
+	req *healthpb.HealthCheckRequest,
+) (*healthpb.HealthCheckResponse, error) {
+	if _, ok := s.services[req.GetService()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+
+	return &healthpb.HealthCheckResponse{Status: s.status(ctx)}, nil
+}
+
+func (s *GRPCServer) List(ctx context.Context, _ *healthpb.HealthListRequest) (*healthpb.HealthListResponse, error) {
+	servingStatus := s.status(ctx)
+
+	resp := &healthpb.HealthListResponse{Statuses: make(map[string]*healthpb.HealthCheckResponse, len(s.services))}
+	for service := range s.services {
+		resp.Statuses[service] = &healthpb.HealthCheckResponse{Status: servingStatus}
+	}
+
+	return resp, nil
+}
+
+// Watch sends the status whenever it changes. The stream ends once the service shuts down,
+// so that it does not hold up the graceful stop of the server.
+func (s *GRPCServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ctx := stream.Context()
+
+	if _, ok := s.services[req.GetService()]; !ok {
+		if err := stream.Send(&healthpb.HealthCheckResponse{
+			Status: healthpb.HealthCheckResponse_SERVICE_UNKNOWN,
+		}); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-s.health.Done():
+		}
+		return nil
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	last := healthpb.HealthCheckResponse_UNKNOWN
+	for {
+		if current := s.status(ctx); current != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: current}); err != nil {
+				return err
+			}
+			last = current
+		}
+
+		if s.health.isShuttingDown() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.health.Done():
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *GRPCServer) status(ctx context.Context) healthpb.HealthCheckResponse_ServingStatus {
+	if s.health.Ready(ctx).Status == StatusServing {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
@@ -0,0 +1,136 @@
+// Package health reports liveness and readiness of the service over HTTP and the gRPC health protocol.
+package health
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrShuttingDown is the readiness error once the service started to shut down.
+var ErrShuttingDown = errors.New("service is shutting down")
+
+const (
+	StatusServing    = "SERVING"
+	StatusNotServing = "NOT_SERVING"
+	checkOK          = "OK"
+)
+
+// Checker tells whether a dependency of the service is usable.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Report is the result of the readiness checks, Checks maps names of checkers to OK or their errors.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// Health aggregates readiness checkers. The service is ready when all of them pass
+// and it is not shutting down.
+type Health struct {
+	timeout time.Duration
+
+	mu       sync.RWMutex
+	checkers map[string]Checker
+
+	shutdownOnce sync.Once
+	shutdown     chan struct{}
+}
+
+// New creates Health which gives each check the timeout to complete.
+func New(timeout time.Duration) *Health {
+	return &Health{
+		timeout:  timeout,
+		checkers: make(map[string]Checker),
+		shutdown: make(chan struct{}),
+	}
+}
+
+// Add registers the checker under the name, replacing one registered before.
+func (h *Health) Add(name string, checker Checker) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.checkers[name] = checker
+}
+
+// Shutdown makes the service not ready for good. It is called at the start of graceful shutdown,
+// so that no new requests are routed to the service while it finishes ones in progress.
+func (h *Health) Shutdown() {
+	h.shutdownOnce.Do(func() { close(h.shutdown) })
+}
+
+// Done is closed by Shutdown.
+func (h *Health) Done() <-chan struct{} {
+	return h.shutdown
+}
+
+func (h *Health) isShuttingDown() bool {
+	select {
+	case <-h.shutdown:
+		return true
+	default:
+		return false
+	}
+}
+
+// Ready runs all checkers concurrently.
+func (h *Health) Ready(ctx context.Context) Report {
+	if h.isShuttingDown() {
+		return Report{Status: StatusNotServing, Checks: map[string]string{"shutdown": ErrShuttingDown.Error()}}
+	}
+
+	h.mu.RLock()
+	names := make([]string, 0, len(h.checkers))
+	for name := range h.checkers {
+		names = append(names, name)
+	}
+	checkers := make([]Checker, len(names))
+	sort.Strings(names)
+	for i, name := range names {
+		checkers[i] = h.checkers[name]
+	}
+	h.mu.RUnlock()
+
+	errs := make([]error, len(checkers))
+	var wg sync.WaitGroup
+	for i, checker := range checkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = h.check(ctx, checker)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusServing, Checks: make(map[string]string, len(names))}
+	for i, name := range names {
+		report.Checks[name] = checkOK
+		if errs[i] != nil {
+			report.Status = StatusNotServing
+			report.Checks[name] = errs[i].Error()
+		}
+	}
+
+	return report
+}
+
+func (h *Health) check(ctx context.Context, checker Checker) error {
+	if h.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.timeout)
+		defer cancel()
+	}
+
+	return checker.Check(ctx)
+}
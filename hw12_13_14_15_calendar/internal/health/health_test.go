@@ -0,0 +1,187 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const eventsService = "event.Events"
+
+var errUnavailable = errors.New("connection refused")
+
+func ok(context.Context) error {
+	return nil
+}
+
+func TestReady(t *testing.T) {
+	h := New(time.Second)
+	h.Add("database", CheckerFunc(ok))
+
+	report := h.Ready(context.Background())
+	if report.Status != StatusServing || report.Checks["database"] != checkOK {
+		t.Errorf("Ready() = %v, want %s with database %s", report, StatusServing, checkOK)
+	}
+
+	h.Add("queue", CheckerFunc(func(context.Context) error { return errUnavailable }))
+
+	report = h.Ready(context.Background())
+	if report.Status != StatusNotServing {
+		t.Errorf("Ready().Status = %s, want %s", report.Status, StatusNotServing)
+	}
+	if report.Checks["database"] != checkOK || report.Checks["queue"] != errUnavailable.Error() {
+		t.Errorf("Ready().Checks = %v, want database %s and queue %q", report.Checks, checkOK, errUnavailable)
+	}
+}
+
+func TestReady_Timeout(t *testing.T) {
+	h := New(10 * time.Millisecond)
+	h.Add("database", CheckerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+
+	report := h.Ready(context.Background())
+	if report.Status != StatusNotServing || report.Checks["database"] != context.DeadlineExceeded.Error() {
+		t.Errorf("Ready() = %v, want %s with database %q", report, StatusNotServing, context.DeadlineExceeded)
+	}
+}
+
+func TestReady_Shutdown(t *testing.T) {
+	h := New(time.Second)
+	h.Add("database", CheckerFunc(ok))
+
+	h.Shutdown()
+	h.Shutdown()
+
+	select {
+	case <-h.Done():
+	default:
+		t.Fatalf("Done() is not closed after Shutdown()")
+	}
+
+	if report := h.Ready(context.Background()); report.Status != StatusNotServing {
+		t.Errorf("Ready().Status after Shutdown() = %s, want %s", report.Status, StatusNotServing)
+	}
+}
+
+func TestHandlers(t *testing.T) {
+	h := New(time.Second)
+	h.Add("queue", CheckerFunc(func(context.Context) error { return errUnavailable }))
+
+	tests := []struct {
+		name       string
+		handler    http.Handler
+		wantCode   int
+		wantStatus string
+	}{
+		{name: "liveness", handler: h.LivenessHandler(), wantCode: http.StatusOK, wantStatus: StatusServing},
+		{
+			name:       "readiness",
+			handler:    h.ReadinessHandler(),
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: StatusNotServing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			tt.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("status code = %d, want %d", rec.Code, tt.wantCode)
+			}
+
+			var report Report
+			if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+				t.Fatalf("Decode() error = %v, want nil", err)
+			}
+			if report.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", report.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestGRPCServer_Check(t *testing.T) {
+	h := New(time.Second)
+	s := NewGRPCServer(h, time.Second, eventsService)
+	ctx := context.Background()
+
+	for _, service := range []string{"", eventsService} {
+		resp, err := s.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil || resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("Check(%q) = %v, %v, want %v, nil", service, resp.GetStatus(), err,
+				healthpb.HealthCheckResponse_SERVING)
+		}
+	}
+
+	if _, err := s.Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Check(%q) error = %v, want %v", "unknown", err, codes.NotFound)
+	}
+
+	h.Shutdown()
+
+	list, err := s.List(ctx, &healthpb.HealthListRequest{})
+	if err != nil {
+		t.Fatalf("List() error = %v, want nil", err)
+	}
+	if len(list.GetStatuses()) != 2 {
+		t.Errorf("List() returned %d services, want 2", len(list.GetStatuses()))
+	}
+	for service, resp := range list.GetStatuses() {
+		if resp.GetStatus() != healthpb.HealthCheckResponse_NOT_SERVING {
+			t.Errorf("List()[%q] = %v, want %v", service, resp.GetStatus(), healthpb.HealthCheckResponse_NOT_SERVING)
+		}
+	}
+}
+
+func TestGRPCServer_Watch(t *testing.T) {
+	h := New(time.Second)
+	s := NewGRPCServer(h, time.Hour, eventsService)
+	stream := &watchStream{ctx: context.Background(), sent: make(chan healthpb.HealthCheckResponse_ServingStatus, 2)}
+
+	done := make(chan error)
+	go func() {
+		done <- s.Watch(&healthpb.HealthCheckRequest{Service: eventsService}, stream)
+	}()
+
+	if got := <-stream.sent; got != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("first watched status = %v, want %v", got, healthpb.HealthCheckResponse_SERVING)
+	}
+
+	h.Shutdown()
+
+	if got := <-stream.sent; got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("watched status after Shutdown() = %v, want %v", got, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Watch() error = %v, want nil", err)
+	}
+}
+
+type watchStream struct {
+	grpc.ServerStream
+
+	ctx  context.Context
+	sent chan healthpb.HealthCheckResponse_ServingStatus
+}
+
+func (s *watchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchStream) Send(resp *healthpb.HealthCheckResponse) error {
+	s.sent <- resp.GetStatus()
+	return nil
+}
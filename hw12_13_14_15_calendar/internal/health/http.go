@@ -0,0 +1,30 @@
+package health
+
+import (
+	"net/http"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/handlers/http"
+)
+
+// LivenessHandler responds OK while the process is able to serve requests at all.
+// It does not run checkers, so that an unavailable dependency does not get the service restarted.
+func (h *Health) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		httphandler.RespondWithJSON(w, http.StatusOK, Report{Status: StatusServing})
+	})
+}
+
+// ReadinessHandler responds OK if the service is ready and Service Unavailable otherwise,
+// with the results of the checks in both cases.
+func (h *Health) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := h.Ready(r.Context())
+
+		code := http.StatusOK
+		if report.Status != StatusServing {
+			code = http.StatusServiceUnavailable
+		}
+
+		httphandler.RespondWithJSON(w, code, report)
+	})
+}
@@ -0,0 +1,434 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+const (
+	componentCalendar = "VCALENDAR"
+	componentEvent    = "VEVENT"
+	componentAlarm    = "VALARM"
+)
+
+var (
+	ErrInvalidCalendar = errors.New("invalid calendar")
+	ErrInvalidEvent    = errors.New("invalid event")
+)
+
+// Event is a VEVENT decoded from a calendar. Err is set if the VEVENT can not be converted to event params.
+type Event struct {
+	UID    string
+	Params storage.CreateOrUpdateEventParams
+	Err    error
+}
+
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Decode reads VEVENTs of a VCALENDAR object. Errors of separate VEVENTs are reported in Event.Err,
+// an error is returned only if the calendar itself is malformed.
+// Times without a time zone are treated as UTC. Events starting on a date are all-day events,
+// dates are midnights UTC.
+func Decode(r io.Reader) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events     []Event
+		stack      []string
+		eventProps []property
+		alarmProps []property
+		inCalendar bool
+	)
+
+	for i, line := range lines {
+		prop, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidCalendar, i+1, err)
+		}
+
+		switch prop.name {
+		case "BEGIN":
+			component := strings.ToUpper(prop.value)
+			if len(stack) == 0 && component != componentCalendar {
+				return nil, fmt.Errorf("%w: line %d: expected BEGIN:VCALENDAR", ErrInvalidCalendar, i+1)
+			}
+			stack = append(stack, component)
+			inCalendar = true
+
+			if component == componentEvent {
+				eventProps = nil
+				alarmProps = nil
+			}
+			continue
+		case "END":
+			component := strings.ToUpper(prop.value)
+			if len(stack) == 0 || stack[len(stack)-1] != component {
+				return nil, fmt.Errorf("%w: line %d: unexpected END:%s", ErrInvalidCalendar, i+1, prop.value)
+			}
+			stack = stack[:len(stack)-1]
+
+			if component == componentEvent {
+				events = append(events, decodeEvent(eventProps, alarmProps))
+			}
+			continue
+		}
+
+		switch {
+		case len(stack) == 0:
+			return nil, fmt.Errorf("%w: line %d: property outside of VCALENDAR", ErrInvalidCalendar, i+1)
+		case stack[len(stack)-1] == componentEvent:
+			eventProps = append(eventProps, prop)
+		case stack[len(stack)-1] == componentAlarm && len(stack) > 1 && stack[len(stack)-2] == componentEvent:
+			alarmProps = append(alarmProps, prop)
+		}
+	}
+
+	if !inCalendar {
+		return nil, fmt.Errorf("%w: no VCALENDAR", ErrInvalidCalendar)
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("%w: unterminated %s", ErrInvalidCalendar, stack[len(stack)-1])
+	}
+
+	return events, nil
+}
+
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+
+	return lines, nil
+}
+
+// parseLine parses a content line "NAME;PARAM=VALUE:VALUE", parameter values may be quoted.
+func parseLine(line string) (property, error) {
+	prop := property{params: make(map[string]string)}
+
+	i := strings.IndexAny(line, ";:")
+	if i <= 0 {
+		return prop, fmt.Errorf("malformed content line %q", line)
+	}
+	prop.name = strings.ToUpper(line[:i])
+
+	for line[i] == ';' {
+		rest := line[i+1:]
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			return prop, fmt.Errorf("malformed parameter in %q", line)
+		}
+		name := strings.ToUpper(rest[:eq])
+		rest = rest[eq+1:]
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				return prop, fmt.Errorf("unterminated quoted parameter in %q", line)
+			}
+			value = rest[1 : end+1]
+			rest = rest[end+2:]
+		} else {
+			end := strings.IndexAny(rest, ";:")
+			if end < 0 {
+				return prop, fmt.Errorf("malformed content line %q", line)
+			}
+			value = rest[:end]
+			rest = rest[end:]
+		}
+
+		prop.params[name] = value
+		i = len(line) - len(rest)
+		if i >= len(line) {
+			return prop, fmt.Errorf("malformed content line %q", line)
+		}
+	}
+
+	if line[i] != ':' {
+		return prop, fmt.Errorf("malformed content line %q", line)
+	}
+	prop.value = line[i+1:]
+
+	return prop, nil
+}
+
+func decodeEvent(props, alarm []property) Event {
+	var (
+		event       Event
+		start, end  *time.Time
+		duration    *time.Duration
+		startIsDate bool
+		hasSummary  bool
+	)
+
+	fail := func(format string, args ...any) Event {
+		event.Err = fmt.Errorf("%w: %s", ErrInvalidEvent, fmt.Sprintf(format, args...))
+		return event
+	}
+
+	for _, prop := range props {
+		switch prop.name {
+		case "UID":
+			event.UID = unescapeText(prop.value)
+		case "SUMMARY":
+			event.Params.Title = unescapeText(prop.value)
+			hasSummary = true
+		case "DESCRIPTION":
+			description := unescapeText(prop.value)
+			event.Params.Description = &description
+		case "DTSTART":
+			t, isDate, err := parseTime(prop)
+			if err != nil {
+				return fail("DTSTART: %v", err)
+			}
+			start, startIsDate = &t, isDate
+			if !isDate {
+				event.Params.TimeZone = prop.params["TZID"]
+			}
+		case "DTEND":
+			t, _, err := parseTime(prop)
+			if err != nil {
+				return fail("DTEND: %v", err)
+			}
+			end = &t
+		case "DURATION":
+			d, err := parseDuration(prop.value)
+			if err != nil {
+				return fail("DURATION: %v", err)
+			}
+			duration = &d
+		case "RRULE":
+			rule := prop.value
+			event.Params.RecurrenceRule = &rule
+		case "EXDATE":
+			for _, value := range strings.Split(prop.value, ",") {
+				t, _, err := parseTime(property{name: prop.name, params: prop.params, value: value})
+				if err != nil {
+					return fail("EXDATE: %v", err)
+				}
+				event.Params.RecurrenceExceptions = append(event.Params.RecurrenceExceptions, t)
+			}
+		}
+	}
+
+	if start == nil {
+		return fail("DTSTART is required")
+	}
+	if !hasSummary {
+		return fail("SUMMARY is required")
+	}
+
+	event.Params.StartTime = *start
+	event.Params.AllDay = startIsDate
+	switch {
+	case end != nil:
+		event.Params.EndTime = *end
+	case duration != nil:
+		event.Params.EndTime = start.Add(*duration)
+	case startIsDate:
+		event.Params.EndTime = start.AddDate(0, 0, 1)
+	default:
+		event.Params.EndTime = *start
+	}
+
+	notifyBefore, err := decodeAlarm(alarm, *start)
+	if err != nil {
+		return fail("VALARM: %v", err)
+	}
+	event.Params.NotifyBefore = notifyBefore
+
+	return event
+}
+
+// decodeAlarm returns the notification offset of the first TRIGGER related to the event start.
+func decodeAlarm(props []property, start time.Time) (*time.Duration, error) {
+	for _, prop := range props {
+		if prop.name != "TRIGGER" {
+			continue
+		}
+
+		if strings.EqualFold(prop.params["VALUE"], "DATE-TIME") {
+			t, _, err := parseTime(prop)
+			if err != nil {
+				return nil, err
+			}
+			before := start.Sub(t)
+			return &before, nil
+		}
+
+		if strings.EqualFold(prop.params["RELATED"], "END") {
+			return nil, errors.New("triggers related to the event end are not supported")
+		}
+
+		d, err := parseDuration(prop.value)
+		if err != nil {
+			return nil, err
+		}
+		if d > 0 {
+			return nil, errors.New("triggers after the event start are not supported")
+		}
+
+		before := -d
+		return &before, nil
+	}
+
+	return nil, nil
+}
+
+func parseTime(prop property) (time.Time, bool, error) {
+	value := prop.value
+
+	if strings.EqualFold(prop.params["VALUE"], "DATE") || len(value) == len(date) {
+		t, err := time.Parse(date, value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date %q", value)
+		}
+		return t, true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(dateTimeUTC, value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date-time %q", value)
+		}
+		return t, false, nil
+	}
+
+	loc := time.UTC
+	if tzid := prop.params["TZID"]; tzid != "" {
+		var err error
+		loc, err = time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("unknown time zone %q", tzid)
+		}
+	}
+
+	t, err := time.ParseInLocation(dateTimeZone, value, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid date-time %q", value)
+	}
+
+	return t, false, nil
+}
+
+// parseDuration parses an RFC 5545 duration such as -PT15M, P1W or P1DT2H30M.
+func parseDuration(s string) (time.Duration, error) {
+	value := s
+	negative := false
+	switch {
+	case strings.HasPrefix(value, "-"):
+		negative = true
+		value = value[1:]
+	case strings.HasPrefix(value, "+"):
+		value = value[1:]
+	}
+
+	if !strings.HasPrefix(value, "P") || len(value) < 3 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	value = value[1:]
+
+	var (
+		d      time.Duration
+		inTime bool
+		number string
+	)
+
+	for _, c := range value {
+		var unit time.Duration
+		switch {
+		case c >= '0' && c <= '9':
+			number += string(c)
+			continue
+		case c == 'T' && !inTime && number == "":
+			inTime = true
+			continue
+		case c == 'W' && !inTime:
+			unit = 7 * 24 * time.Hour
+		case c == 'D' && !inTime:
+			unit = 24 * time.Hour
+		case c == 'H' && inTime:
+			unit = time.Hour
+		case c == 'M' && inTime:
+			unit = time.Minute
+		case c == 'S' && inTime:
+			unit = time.Second
+		default:
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		d += time.Duration(n) * unit
+		number = ""
+	}
+
+	if number != "" {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	if negative {
+		d = -d
+	}
+
+	return d, nil
+}
+
+func unescapeText(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,175 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+const (
+	ContentType = "text/calendar; charset=utf-8"
+
+	prodID       = "-//otus-go-hw//calendar//EN"
+	dateTimeUTC  = "20060102T150405Z"
+	dateTimeZone = "20060102T150405"
+	date         = "20060102"
+
+	maxLineOctets = 75
+)
+
+// Encode writes the events as a VCALENDAR object. Recurring events are written as series with RRULE and EXDATE.
+// stamp is used as DTSTAMP of every VEVENT.
+func Encode(w io.Writer, events []storage.Event, stamp time.Time) error {
+	bw := bufio.NewWriter(w)
+	e := &encoder{w: bw}
+
+	e.line("BEGIN:VCALENDAR")
+	e.line("VERSION:2.0")
+	e.line("PRODID:" + prodID)
+	e.line("CALSCALE:GREGORIAN")
+
+	for _, event := range events {
+		e.event(event, stamp)
+	}
+
+	e.line("END:VCALENDAR")
+
+	if e.err != nil {
+		return e.err
+	}
+
+	return bw.Flush()
+}
+
+type encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (e *encoder) event(event storage.Event, stamp time.Time) {
+	e.line("BEGIN:VEVENT")
+	e.line("UID:" + escapeText(event.ID))
+	e.line("DTSTAMP:" + stamp.UTC().Format(dateTimeUTC))
+	params, layout, loc := timeFormat(event)
+	e.line("DTSTART" + params + event.StartTime.In(loc).Format(layout))
+	e.line("DTEND" + params + event.EndTime.In(loc).Format(layout))
+	e.line("SUMMARY:" + escapeText(event.Title))
+
+	if event.Description != nil {
+		e.line("DESCRIPTION:" + escapeText(*event.Description))
+	}
+
+	if event.RecurrenceRule != nil {
+		e.line("RRULE:" + *event.RecurrenceRule)
+
+		if len(event.RecurrenceExceptions) > 0 {
+			exdates := make([]string, len(event.RecurrenceExceptions))
+			for i, ex := range event.RecurrenceExceptions {
+				exdates[i] = ex.In(loc).Format(layout)
+			}
+			e.line("EXDATE" + params + strings.Join(exdates, ","))
+		}
+	}
+
+	if event.NotifyBefore != nil {
+		e.line("BEGIN:VALARM")
+		e.line("ACTION:DISPLAY")
+		e.line("DESCRIPTION:" + escapeText(event.Title))
+		e.line("TRIGGER:" + formatDuration(-*event.NotifyBefore))
+		e.line("END:VALARM")
+	}
+
+	e.line("END:VEVENT")
+}
+
+// timeFormat returns the parameters, the layout and the location of date and date-time values of the event.
+// Timed events are written in the time zone they recur in, so that importers keep the series wall clock time.
+func timeFormat(event storage.Event) (string, string, *time.Location) {
+	if event.AllDay {
+		return ";VALUE=DATE:", date, time.UTC
+	}
+
+	if loc := event.Location(); event.TimeZone != "" && loc.String() == event.TimeZone {
+		return ";TZID=" + event.TimeZone + ":", dateTimeZone, loc
+	}
+
+	return ":", dateTimeUTC, time.UTC
+}
+
+// line writes a content line folded to 75 octets without splitting UTF-8 sequences.
+func (e *encoder) line(s string) {
+	if e.err != nil {
+		return
+	}
+
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+
+		if _, e.err = e.w.WriteString(s[:cut] + "\r\n "); e.err != nil {
+			return
+		}
+		s = s[cut:]
+		// The leading space of a continuation line counts towards its length.
+		limit = maxLineOctets - 1
+	}
+
+	_, e.err = e.w.WriteString(s + "\r\n")
+}
+
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// formatDuration formats d as an RFC 5545 duration, e.g. -PT15M or P1DT2H.
+func formatDuration(d time.Duration) string {
+	var b strings.Builder
+	if d < 0 {
+		b.WriteByte('-')
+		d = -d
+	}
+	b.WriteByte('P')
+	d = d.Truncate(time.Second)
+
+	day := 24 * time.Hour
+	if days := d / day; days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+		d %= day
+	}
+
+	if d == 0 {
+		if b.Len() <= 2 {
+			b.WriteString("T0S")
+		}
+		return b.String()
+	}
+
+	b.WriteByte('T')
+	if h := d / time.Hour; h > 0 {
+		fmt.Fprintf(&b, "%dH", h)
+		d %= time.Hour
+	}
+	if m := d / time.Minute; m > 0 {
+		fmt.Fprintf(&b, "%dM", m)
+		d %= time.Minute
+	}
+	if s := d / time.Second; s > 0 {
+		fmt.Fprintf(&b, "%dS", s)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,231 @@
+package ical
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	description := "Line one\nLine two; with, separators \\ and a long tail to force folding of this content line"
+	notifyBefore := 90 * time.Minute
+	rule := "FREQ=WEEKLY;BYDAY=MO,WE"
+	start := time.Date(2025, time.May, 5, 10, 0, 0, 0, time.UTC)
+
+	events := []storage.Event{
+		{
+			ID:                   "1",
+			Title:                "Встреча с командой, которая обязательно должна быть перенесена в более длинную строку",
+			StartTime:            start,
+			EndTime:              start.Add(time.Hour),
+			Description:          &description,
+			NotifyBefore:         &notifyBefore,
+			RecurrenceRule:       &rule,
+			RecurrenceExceptions: []time.Time{start.AddDate(0, 0, 7)},
+			TimeZone:             "Europe/Berlin",
+		},
+		{
+			ID:        "2",
+			Title:     "Lunch",
+			StartTime: start.Add(3 * time.Hour),
+			EndTime:   start.Add(4 * time.Hour),
+		},
+		{
+			ID:        "3",
+			Title:     "Vacation",
+			StartTime: time.Date(2025, time.May, 12, 0, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2025, time.May, 17, 0, 0, 0, 0, time.UTC),
+			AllDay:    true,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, events, start); err != nil {
+		t.Fatalf("Encode() error = %v, want nil", err)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("line %q is %d octets long, want at most %d", line, len(line), maxLineOctets)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line %q is not valid UTF-8", line)
+		}
+	}
+
+	decoded, err := Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode() error = %v, want nil", err)
+	}
+
+	if len(decoded) != len(events) {
+		t.Fatalf("Decode() returned %d events, want %d", len(decoded), len(events))
+	}
+
+	for i, got := range decoded {
+		want := events[i]
+		if got.Err != nil {
+			t.Fatalf("events[%d].Err = %v, want nil", i, got.Err)
+		}
+		if got.UID != want.ID || got.Params.Title != want.Title ||
+			!got.Params.StartTime.Equal(want.StartTime) || !got.Params.EndTime.Equal(want.EndTime) ||
+			got.Params.AllDay != want.AllDay || got.Params.TimeZone != want.TimeZone {
+			t.Errorf("events[%d] = %+v, want %+v", i, got, want)
+		}
+	}
+
+	first := decoded[0].Params
+	if first.Description == nil || *first.Description != description {
+		t.Errorf("Description = %v, want %q", first.Description, description)
+	}
+	if first.NotifyBefore == nil || *first.NotifyBefore != notifyBefore {
+		t.Errorf("NotifyBefore = %v, want %v", first.NotifyBefore, notifyBefore)
+	}
+	if first.RecurrenceRule == nil || *first.RecurrenceRule != rule {
+		t.Errorf("RecurrenceRule = %v, want %q", first.RecurrenceRule, rule)
+	}
+	if len(first.RecurrenceExceptions) != 1 || !first.RecurrenceExceptions[0].Equal(start.AddDate(0, 0, 7)) {
+		t.Errorf("RecurrenceExceptions = %v, want [%v]", first.RecurrenceExceptions, start.AddDate(0, 0, 7))
+	}
+	if decoded[1].Params.NotifyBefore != nil {
+		t.Errorf("NotifyBefore = %v, want nil", *decoded[1].Params.NotifyBefore)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	calendar := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VTIMEZONE",
+		"TZID:Europe/Berlin",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"UID:zoned",
+		"SUMMARY:Zoned",
+		"DTSTART;TZID=\"Europe/Berlin\":20250505T100000",
+		"DURATION:PT1H30M",
+		"BEGIN:VALARM",
+		"TRIGGER;RELATED=START:-P1D",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:all-day",
+		"SUMMARY:Holi",
+		" day",
+		"DTSTART;VALUE=DATE:20250501",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:broken",
+		"SUMMARY:Broken",
+		"DTSTART:2025-05-01",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:no-summary",
+		"DTSTART:20250501T100000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\n")
+
+	events, err := Decode(strings.NewReader(calendar))
+	if err != nil {
+		t.Fatalf("Decode() error = %v, want nil", err)
+	}
+	if len(events) != 4 {
+		t.Fatalf("Decode() returned %d events, want 4", len(events))
+	}
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zoned := events[0].Params
+	if wantStart := time.Date(2025, time.May, 5, 10, 0, 0, 0, berlin); !zoned.StartTime.Equal(wantStart) {
+		t.Errorf("StartTime = %v, want %v", zoned.StartTime, wantStart)
+	}
+	if d := zoned.EndTime.Sub(zoned.StartTime); d != 90*time.Minute {
+		t.Errorf("duration = %v, want 1h30m", d)
+	}
+	if zoned.NotifyBefore == nil || *zoned.NotifyBefore != 24*time.Hour {
+		t.Errorf("NotifyBefore = %v, want 24h", zoned.NotifyBefore)
+	}
+
+	allDay := events[1].Params
+	if allDay.Title != "Holiday" {
+		t.Errorf("Title = %q, want %q", allDay.Title, "Holiday")
+	}
+	if !allDay.AllDay {
+		t.Errorf("AllDay = false, want true")
+	}
+	if zoned.AllDay {
+		t.Errorf("AllDay of a timed event = true, want false")
+	}
+	if !allDay.EndTime.Equal(allDay.StartTime.AddDate(0, 0, 1)) {
+		t.Errorf("EndTime = %v, want a day after %v", allDay.EndTime, allDay.StartTime)
+	}
+
+	for _, i := range []int{2, 3} {
+		if !errors.Is(events[i].Err, ErrInvalidEvent) {
+			t.Errorf("events[%d].Err = %v, want %v", i, events[i].Err, ErrInvalidEvent)
+		}
+	}
+}
+
+func TestDecode_InvalidCalendar(t *testing.T) {
+	tests := []struct {
+		name     string
+		calendar string
+	}{
+		{"Empty", ""},
+		{"No calendar", "BEGIN:VEVENT\nEND:VEVENT"},
+		{"Unterminated", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nEND:VCALENDAR"},
+		{"Malformed line", "BEGIN:VCALENDAR\nSUMMARY\nEND:VCALENDAR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(strings.NewReader(tt.calendar))
+			if !errors.Is(err, ErrInvalidCalendar) {
+				t.Errorf("Decode() error = %v, want %v", err, ErrInvalidCalendar)
+			}
+		})
+	}
+}
+
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"PT0S", 0},
+		{"-PT15M", -15 * time.Minute},
+		{"P1DT2H30M", 26*time.Hour + 30*time.Minute},
+		{"P2W", 14 * 24 * time.Hour},
+		{"PT1H0M5S", time.Hour + 5*time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseDuration(tt.value)
+			if err != nil || got != tt.want {
+				t.Errorf("parseDuration(%q) = %v, %v, want %v", tt.value, got, err, tt.want)
+			}
+
+			back, err := parseDuration(formatDuration(tt.want))
+			if err != nil || back != tt.want {
+				t.Errorf("parseDuration(formatDuration(%v)) = %v, %v", tt.want, back, err)
+			}
+		})
+	}
+
+	for _, value := range []string{"", "P", "PT", "1H", "PT1D", "P1H", "PT5"} {
+		if _, err := parseDuration(value); err == nil {
+			t.Errorf("parseDuration(%q) error = nil, want error", value)
+		}
+	}
+}
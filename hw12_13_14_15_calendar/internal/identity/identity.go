@@ -0,0 +1,22 @@
+package identity
+
+import "context"
+
+const (
+	// HeaderName is the HTTP header carrying the ID of the calling user.
+	HeaderName = "X-User-ID"
+	// MetadataKey is the gRPC metadata key carrying the ID of the calling user.
+	MetadataKey = "x-user-id"
+)
+
+type userIDKey struct{}
+
+func NewContext(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// FromContext returns the ID of the calling user if the request was made on behalf of a user.
+func FromContext(ctx context.Context) (string, bool) {
+	userID, ok := ctx.Value(userIDKey{}).(string)
+	return userID, ok && userID != ""
+}
@@ -0,0 +1,209 @@
+// Package metrics collects Prometheus metrics of the calendar service.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// UnmatchedRoute labels HTTP requests which match no route, so that unknown paths do not create new series.
+const UnmatchedRoute = "unmatched"
+
+type Config struct {
+	// Namespace prefixes names of the metrics.
+	Namespace string
+	// Buckets are upper bounds of latency histograms in seconds, prometheus.DefBuckets if empty.
+	Buckets []float64
+	// PerOwner labels business metrics with owner IDs. It is only feasible for a limited number of owners.
+	PerOwner bool
+}
+
+// Metrics holds the metrics of the service. Methods of a nil Metrics do nothing,
+// so that components work the same way with metrics disabled.
+type Metrics struct {
+	registry *prometheus.Registry
+	perOwner bool
+
+	httpRequests    *prometheus.CounterVec
+	httpDuration    *prometheus.HistogramVec
+	grpcRequests    *prometheus.CounterVec
+	grpcDuration    *prometheus.HistogramVec
+	storageDuration *prometheus.HistogramVec
+	storageErrors   *prometheus.CounterVec
+	eventsCreated   *prometheus.CounterVec
+	eventsDeleted   *prometheus.CounterVec
+	responses       *prometheus.CounterVec
+}
+
+func New(cfg Config) *Metrics {
+	buckets := cfg.Buckets
+	if len(buckets) == 0 {
+		buckets = prometheus.DefBuckets
+	}
+
+	var ownerLabels []string
+	if cfg.PerOwner {
+		ownerLabels = []string{"owner_id"}
+	}
+
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		perOwner: cfg.PerOwner,
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.Namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "HTTP requests by method, route and status code.",
+		}, []string{"method", "route", "code"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.Namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of HTTP requests by method and route.",
+			Buckets:   buckets,
+		}, []string{"method", "route"}),
+		grpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.Namespace,
+			Subsystem: "grpc",
+			Name:      "requests_total",
+			Help:      "gRPC calls by method and status code.",
+		}, []string{"method", "code"}),
+		grpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.Namespace,
+			Subsystem: "grpc",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of gRPC calls by method.",
+			Buckets:   buckets,
+		}, []string{"method"}),
+		storageDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.Namespace,
+			Subsystem: "storage",
+			Name:      "operation_duration_seconds",
+			Help:      "Latency of storage operations.",
+			Buckets:   buckets,
+		}, []string{"operation"}),
+		storageErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.Namespace,
+			Subsystem: "storage",
+			Name:      "errors_total",
+			Help:      "Storage operations which returned an error.",
+		}, []string{"operation"}),
+		eventsCreated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.Namespace,
+			Subsystem: "events",
+			Name:      "created_total",
+			Help:      "Created events.",
+		}, ownerLabels),
+		eventsDeleted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.Namespace,
+			Subsystem: "events",
+			Name:      "deleted_total",
+			Help:      "Events moved to the trash.",
+		}, nil),
+		responses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.Namespace,
+			Subsystem: "events",
+			Name:      "attendee_responses_total",
+			Help:      "Responses of attendees to invitations by status.",
+		}, []string{"status"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.grpcRequests,
+		m.grpcDuration,
+		m.storageDuration,
+		m.storageErrors,
+		m.eventsCreated,
+		m.eventsDeleted,
+		m.responses,
+	)
+
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Register adds metrics of another component, e.g. a connection pool.
+func (m *Metrics) Register(c prometheus.Collector) error {
+	if m == nil {
+		return nil
+	}
+
+	return m.registry.Register(c)
+}
+
+// ObserveHTTPRequest records a served request. The route is the pattern it matched.
+func (m *Metrics) ObserveHTTPRequest(method, route string, code int, d time.Duration) {
+	if m == nil {
+		return
+	}
+
+	if route == "" {
+		route = UnmatchedRoute
+	}
+
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(code)).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(d.Seconds())
+}
+
+// ObserveGRPCCall records a call of the full gRPC method name.
+func (m *Metrics) ObserveGRPCCall(method, code string, d time.Duration) {
+	if m == nil {
+		return
+	}
+
+	m.grpcRequests.WithLabelValues(method, code).Inc()
+	m.grpcDuration.WithLabelValues(method).Observe(d.Seconds())
+}
+
+func (m *Metrics) ObserveStorageOperation(operation string, d time.Duration, err error) {
+	if m == nil {
+		return
+	}
+
+	m.storageDuration.WithLabelValues(operation).Observe(d.Seconds())
+	if err != nil {
+		m.storageErrors.WithLabelValues(operation).Inc()
+	}
+}
+
+func (m *Metrics) EventCreated(ownerID string) {
+	if m == nil {
+		return
+	}
+
+	if m.perOwner {
+		m.eventsCreated.WithLabelValues(ownerID).Inc()
+		return
+	}
+
+	m.eventsCreated.WithLabelValues().Inc()
+}
+
+func (m *Metrics) EventDeleted() {
+	if m == nil {
+		return
+	}
+
+	m.eventsDeleted.WithLabelValues().Inc()
+}
+
+func (m *Metrics) AttendeeResponded(status string) {
+	if m == nil {
+		return
+	}
+
+	m.responses.WithLabelValues(status).Inc()
+}
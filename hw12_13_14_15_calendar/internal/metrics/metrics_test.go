@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+	memorystorage "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+const ownerID = "123e4567-e89b-12d3-a456-426614174000"
+
+func TestMetrics_Nil(_ *testing.T) {
+	var m *Metrics
+
+	m.ObserveHTTPRequest(http.MethodGet, "GET /api/events", http.StatusOK, time.Second)
+	m.ObserveGRPCCall("/event.Events/GetEvent", "OK", time.Second)
+	m.ObserveStorageOperation("GetEvent", time.Second, nil)
+	m.EventCreated(ownerID)
+	m.EventDeleted()
+	m.AttendeeResponded("accepted")
+}
+
+func TestMetrics_ObserveHTTPRequest(t *testing.T) {
+	m := New(Config{Namespace: "calendar"})
+
+	m.ObserveHTTPRequest(http.MethodGet, "GET /api/events/{id}", http.StatusOK, time.Millisecond)
+	m.ObserveHTTPRequest(http.MethodGet, "GET /api/events/{id}", http.StatusNotFound, time.Millisecond)
+	m.ObserveHTTPRequest(http.MethodGet, "", http.StatusNotFound, time.Millisecond)
+
+	tests := []struct {
+		route string
+		code  string
+		want  float64
+	}{
+		{route: "GET /api/events/{id}", code: "200", want: 1},
+		{route: "GET /api/events/{id}", code: "404", want: 1},
+		{route: UnmatchedRoute, code: "404", want: 1},
+	}
+
+	for _, tt := range tests {
+		got := testutil.ToFloat64(m.httpRequests.WithLabelValues(http.MethodGet, tt.route, tt.code))
+		if got != tt.want {
+			t.Errorf("requests of %s with %s = %v, want %v", tt.route, tt.code, got, tt.want)
+		}
+	}
+
+	if got := testutil.CollectAndCount(m.httpDuration); got != 2 {
+		t.Errorf("duration series = %d, want 2", got)
+	}
+}
+
+func TestMetrics_Handler(t *testing.T) {
+	m := New(Config{Namespace: "calendar"})
+	m.ObserveGRPCCall("/event.Events/GetEvent", "NotFound", time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body, _ := io.ReadAll(rec.Body)
+	want := `calendar_grpc_requests_total{code="NotFound",method="/event.Events/GetEvent"} 1`
+	if !strings.Contains(string(body), want) {
+		t.Errorf("Handler() body does not contain %q", want)
+	}
+}
+
+func TestStorage(t *testing.T) {
+	ctx := context.Background()
+	m := New(Config{PerOwner: true})
+	s := NewStorage(memorystorage.NewStorage(), m)
+
+	event, err := s.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+		Title:     "Meeting",
+		StartTime: time.Now(),
+		EndTime:   time.Now().Add(time.Hour),
+		OwnerID:   ownerID,
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v, want nil", err)
+	}
+
+	if err := s.AddAttendee(ctx, event.ID, ownerID); err != nil {
+		t.Fatalf("AddAttendee() error = %v, want nil", err)
+	}
+	if err := s.SetAttendeeStatus(ctx, event.ID, ownerID, storage.AttendeeAccepted); err != nil {
+		t.Fatalf("SetAttendeeStatus() error = %v, want nil", err)
+	}
+	if err := s.DeleteEvent(ctx, event.ID); err != nil {
+		t.Fatalf("DeleteEvent() error = %v, want nil", err)
+	}
+	if _, err := s.GetEvent(ctx, event.ID); !errors.Is(err, storage.ErrEventNotFound) {
+		t.Fatalf("GetEvent() error = %v, want %v", err, storage.ErrEventNotFound)
+	}
+
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"created", testutil.ToFloat64(m.eventsCreated.WithLabelValues(ownerID)), 1},
+		{"deleted", testutil.ToFloat64(m.eventsDeleted), 1},
+		{"accepted", testutil.ToFloat64(m.responses.WithLabelValues(string(storage.AttendeeAccepted))), 1},
+		{"GetEvent errors", testutil.ToFloat64(m.storageErrors.WithLabelValues("GetEvent")), 1},
+		{"CreateEvent errors", testutil.ToFloat64(m.storageErrors.WithLabelValues("CreateEvent")), 0},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if got := testutil.CollectAndCount(m.storageDuration); got != 5 {
+		t.Errorf("storage operation series = %d, want 5", got)
+	}
+}
+
+func TestPoolCollector(t *testing.T) {
+	// The pool connects lazily, so its statistics are available without a database.
+	pool, err := pgxpool.New(context.Background(), "postgres://calendar@localhost:1/calendar?pool_max_conns=7")
+	if err != nil {
+		t.Fatalf("pgxpool.New() error = %v, want nil", err)
+	}
+	defer pool.Close()
+
+	m := New(Config{Namespace: "calendar"})
+	if err := m.Register(NewPoolCollector("calendar", pool)); err != nil {
+		t.Fatalf("Register() error = %v, want nil", err)
+	}
+
+	want := `
+# HELP calendar_db_pool_max_connections Maximum size of the pool.
+# TYPE calendar_db_pool_max_connections gauge
+calendar_db_pool_max_connections 7
+`
+	if err := testutil.GatherAndCompare(m.registry, strings.NewReader(want),
+		"calendar_db_pool_max_connections"); err != nil {
+		t.Errorf("GatherAndCompare() error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,205 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/app"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+// Storage measures operations of the wrapped storage and counts business events.
+type Storage struct {
+	next    app.Storage
+	metrics *Metrics
+}
+
+var _ app.Storage = (*Storage)(nil)
+
+func NewStorage(next app.Storage, metrics *Metrics) *Storage {
+	return &Storage{next: next, metrics: metrics}
+}
+
+func (s *Storage) observe(operation string, start time.Time, err error) {
+	s.metrics.ObserveStorageOperation(operation, time.Since(start), err)
+}
+
+func (s *Storage) CreateEvent(ctx context.Context, params storage.CreateOrUpdateEventParams) (*storage.Event, error) {
+	start := time.Now()
+	event, err := s.next.CreateEvent(ctx, params)
+	s.observe("CreateEvent", start, err)
+
+	if err == nil {
+		s.metrics.EventCreated(event.OwnerID)
+	}
+
+	return event, err
+}
+
+func (s *Storage) GetEvent(ctx context.Context, id string) (*storage.Event, error) {
+	start := time.Now()
+	event, err := s.next.GetEvent(ctx, id)
+	s.observe("GetEvent", start, err)
+
+	return event, err
+}
+
+func (s *Storage) UpdateEvent(ctx context.Context, event storage.Event) (*storage.Event, error) {
+	start := time.Now()
+	updated, err := s.next.UpdateEvent(ctx, event)
+	s.observe("UpdateEvent", start, err)
+
+	return updated, err
+}
+
+func (s *Storage) DeleteEvent(ctx context.Context, id string) error {
+	start := time.Now()
+	err := s.next.DeleteEvent(ctx, id)
+	s.observe("DeleteEvent", start, err)
+
+	if err == nil {
+		s.metrics.EventDeleted()
+	}
+
+	return err
+}
+
+func (s *Storage) GetDeletedEvent(ctx context.Context, id string) (*storage.Event, error) {
+	start := time.Now()
+	event, err := s.next.GetDeletedEvent(ctx, id)
+	s.observe("GetDeletedEvent", start, err)
+
+	return event, err
+}
+
+func (s *Storage) GetDeletedEvents(ctx context.Context, ownerID string) ([]storage.Event, error) {
+	start := time.Now()
+	events, err := s.next.GetDeletedEvents(ctx, ownerID)
+	s.observe("GetDeletedEvents", start, err)
+
+	return events, err
+}
+
+func (s *Storage) RestoreEvent(ctx context.Context, id string) (*storage.Event, error) {
+	start := time.Now()
+	event, err := s.next.RestoreEvent(ctx, id)
+	s.observe("RestoreEvent", start, err)
+
+	return event, err
+}
+
+func (s *Storage) PurgeDeletedEvents(ctx context.Context, t time.Time) (int64, error) {
+	start := time.Now()
+	purged, err := s.next.PurgeDeletedEvents(ctx, t)
+	s.observe("PurgeDeletedEvents", start, err)
+
+	return purged, err
+}
+
+func (s *Storage) AddAttendee(ctx context.Context, eventID, userID string) error {
+	start := time.Now()
+	err := s.next.AddAttendee(ctx, eventID, userID)
+	s.observe("AddAttendee", start, err)
+
+	return err
+}
+
+func (s *Storage) RemoveAttendee(ctx context.Context, eventID, userID string) error {
+	start := time.Now()
+	err := s.next.RemoveAttendee(ctx, eventID, userID)
+	s.observe("RemoveAttendee", start, err)
+
+	return err
+}
+
+func (s *Storage) SetAttendeeStatus(
+	ctx context.Context,
+	eventID, userID string,
+	status storage.AttendeeStatus,
+) error {
+	start := time.Now()
+	err := s.next.SetAttendeeStatus(ctx, eventID, userID, status)
+	s.observe("SetAttendeeStatus", start, err)
+
+	if err == nil {
+		s.metrics.AttendeeResponded(string(status))
+	}
+
+	return err
+}
+
+func (s *Storage) ListEvents(ctx context.Context, query storage.ListEventsQuery) (storage.EventPage, error) {
+	start := time.Now()
+	page, err := s.next.ListEvents(ctx, query)
+	s.observe("ListEvents", start, err)
+
+	return page, err
+}
+
+func (s *Storage) GetEventsByPeriod(
+	ctx context.Context,
+	ownerID string,
+	start, end time.Time,
+) ([]storage.Event, error) {
+	began := time.Now()
+	events, err := s.next.GetEventsByPeriod(ctx, ownerID, start, end)
+	s.observe("GetEventsByPeriod", began, err)
+
+	return events, err
+}
+
+func (s *Storage) GetOverlappingEvents(
+	ctx context.Context,
+	ownerID string,
+	start, end time.Time,
+) ([]storage.Event, error) {
+	began := time.Now()
+	events, err := s.next.GetOverlappingEvents(ctx, ownerID, start, end)
+	s.observe("GetOverlappingEvents", began, err)
+
+	return events, err
+}
+
+func (s *Storage) GetBusyEvents(
+	ctx context.Context,
+	userIDs []string,
+	start, end time.Time,
+) ([]storage.Event, error) {
+	began := time.Now()
+	events, err := s.next.GetBusyEvents(ctx, userIDs, start, end)
+	s.observe("GetBusyEvents", began, err)
+
+	return events, err
+}
+
+func (s *Storage) GetTimeZone(ctx context.Context, userID string) (string, error) {
+	start := time.Now()
+	timeZone, err := s.next.GetTimeZone(ctx, userID)
+	s.observe("GetTimeZone", start, err)
+
+	return timeZone, err
+}
+
+func (s *Storage) SetTimeZone(ctx context.Context, userID, timeZone string) error {
+	start := time.Now()
+	err := s.next.SetTimeZone(ctx, userID, timeZone)
+	s.observe("SetTimeZone", start, err)
+
+	return err
+}
+
+func (s *Storage) GetEventsToNotify(ctx context.Context, from, to time.Time) ([]storage.Event, error) {
+	start := time.Now()
+	events, err := s.next.GetEventsToNotify(ctx, from, to)
+	s.observe("GetEventsToNotify", start, err)
+
+	return events, err
+}
+
+func (s *Storage) DeleteEventsBefore(ctx context.Context, t time.Time) (int64, error) {
+	start := time.Now()
+	deleted, err := s.next.DeleteEventsBefore(ctx, t)
+	s.observe("DeleteEventsBefore", start, err)
+
+	return deleted, err
+}
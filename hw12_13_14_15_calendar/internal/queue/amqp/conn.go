@@ -0,0 +1,66 @@
+package amqpqueue
+
+import (
+	"context"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// connection and channel are the parts of the AMQP client used by the queue, so that it can be tested
+// without a broker.
+type connection interface {
+	Channel() (channel, error)
+	IsClosed() bool
+	Close() error
+}
+
+type channel interface {
+	ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error
+	QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error)
+	QueueBind(name, key, exchange string, noWait bool, args amqp.Table) error
+	Qos(prefetchCount, prefetchSize int, global bool) error
+	Confirm(noWait bool) error
+	Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool,
+		args amqp.Table) (<-chan amqp.Delivery, error)
+	// Publish publishes the message in confirm mode, the returned confirmation resolves once the broker
+	// accepted or rejected it.
+	Publish(ctx context.Context, exchange, key string, msg amqp.Publishing) (confirmation, error)
+	IsClosed() bool
+	Close() error
+}
+
+type confirmation interface {
+	WaitContext(ctx context.Context) (bool, error)
+}
+
+type dialer func(url string) (connection, error)
+
+func dialAMQP(url string) (connection, error) {
+	conn, err := amqp.Dial(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return amqpConnection{conn}, nil
+}
+
+type amqpConnection struct {
+	*amqp.Connection
+}
+
+func (c amqpConnection) Channel() (channel, error) {
+	ch, err := c.Connection.Channel()
+	if err != nil {
+		return nil, err
+	}
+
+	return amqpChannel{ch}, nil
+}
+
+type amqpChannel struct {
+	*amqp.Channel
+}
+
+func (c amqpChannel) Publish(ctx context.Context, exchange, key string, msg amqp.Publishing) (confirmation, error) {
+	return c.PublishWithDeferredConfirmWithContext(ctx, exchange, key, false, false, msg)
+}
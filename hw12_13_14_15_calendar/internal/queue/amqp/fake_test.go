@@ -0,0 +1,296 @@
+package amqpqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+var errBrokerDown = errors.New("broker is down")
+
+// fakeBroker is an in-process stand-in for a broker with a single queue. Like a real broker, it redelivers
+// messages nacked with requeue and ones left unacknowledged by a closed channel.
+type fakeBroker struct {
+	mu      sync.Mutex
+	down    bool
+	dials   int
+	conns   []*fakeConnection
+	ready   [][]byte
+	unacked map[uint64]unackedMessage
+	lastTag uint64
+	// changed is closed and replaced whenever messages become ready.
+	changed chan struct{}
+}
+
+type unackedMessage struct {
+	body []byte
+	ch   *fakeChannel
+}
+
+func newFakeBroker() *fakeBroker {
+	return &fakeBroker{
+		unacked: make(map[uint64]unackedMessage),
+		changed: make(chan struct{}),
+	}
+}
+
+func (b *fakeBroker) dial(string) (connection, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.down {
+		return nil, errBrokerDown
+	}
+
+	b.dials++
+	conn := &fakeConnection{broker: b}
+	b.conns = append(b.conns, conn)
+
+	return conn, nil
+}
+
+func (b *fakeBroker) Dials() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.dials
+}
+
+// restart closes all connections, the broker refuses new ones while it is down.
+func (b *fakeBroker) restart(down bool) {
+	b.mu.Lock()
+	b.down = down
+	conns := b.conns
+	b.conns = nil
+	b.mu.Unlock()
+
+	for _, conn := range conns {
+		conn.Close()
+	}
+}
+
+func (b *fakeBroker) publish(body []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.ready = append(b.ready, append([]byte(nil), body...))
+	b.notify()
+}
+
+// take returns the next ready message for the channel, or the channel closed once messages become ready.
+func (b *fakeBroker) take(ch *fakeChannel) ([]byte, uint64, <-chan struct{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if ch.IsClosed() || len(b.ready) == 0 {
+		return nil, 0, b.changed
+	}
+
+	body := b.ready[0]
+	b.ready = b.ready[1:]
+	b.lastTag++
+	b.unacked[b.lastTag] = unackedMessage{body: body, ch: ch}
+
+	return body, b.lastTag, nil
+}
+
+// requeue returns unacknowledged messages of the closed channel to the head of the queue.
+func (b *fakeBroker) requeue(ch *fakeChannel) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for tag, msg := range b.unacked {
+		if msg.ch == ch {
+			delete(b.unacked, tag)
+			b.ready = append([][]byte{msg.body}, b.ready...)
+		}
+	}
+	b.notify()
+}
+
+// notify wakes up consumers waiting for messages. Caller must hold b.mu.
+func (b *fakeBroker) notify() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+func (b *fakeBroker) Ack(tag uint64, _ bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.unacked[tag]; !ok {
+		return fmt.Errorf("unknown delivery tag %d", tag)
+	}
+	delete(b.unacked, tag)
+
+	return nil
+}
+
+func (b *fakeBroker) Nack(tag uint64, _ bool, requeue bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	msg, ok := b.unacked[tag]
+	if !ok {
+		return fmt.Errorf("unknown delivery tag %d", tag)
+	}
+	delete(b.unacked, tag)
+
+	if requeue {
+		b.ready = append([][]byte{msg.body}, b.ready...)
+		b.notify()
+	}
+
+	return nil
+}
+
+func (b *fakeBroker) Reject(tag uint64, requeue bool) error {
+	return b.Nack(tag, false, requeue)
+}
+
+type fakeConnection struct {
+	broker *fakeBroker
+
+	mu       sync.Mutex
+	closed   bool
+	channels []*fakeChannel
+}
+
+func (c *fakeConnection) Channel() (channel, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil, amqp.ErrClosed
+	}
+
+	ch := &fakeChannel{broker: c.broker, done: make(chan struct{})}
+	c.channels = append(c.channels, ch)
+
+	return ch, nil
+}
+
+func (c *fakeConnection) IsClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.closed
+}
+
+func (c *fakeConnection) Close() error {
+	c.mu.Lock()
+	c.closed = true
+	channels := c.channels
+	c.channels = nil
+	c.mu.Unlock()
+
+	for _, ch := range channels {
+		ch.Close()
+	}
+
+	return nil
+}
+
+type fakeChannel struct {
+	broker    *fakeBroker
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (c *fakeChannel) ExchangeDeclare(string, string, bool, bool, bool, bool, amqp.Table) error {
+	return c.err()
+}
+
+func (c *fakeChannel) QueueDeclare(name string, _, _, _, _ bool, _ amqp.Table) (amqp.Queue, error) {
+	return amqp.Queue{Name: name}, c.err()
+}
+
+func (c *fakeChannel) QueueBind(string, string, string, bool, amqp.Table) error {
+	return c.err()
+}
+
+func (c *fakeChannel) Qos(int, int, bool) error {
+	return c.err()
+}
+
+func (c *fakeChannel) Confirm(bool) error {
+	return c.err()
+}
+
+func (c *fakeChannel) Consume(string, string, bool, bool, bool, bool, amqp.Table) (<-chan amqp.Delivery, error) {
+	if err := c.err(); err != nil {
+		return nil, err
+	}
+
+	messages := make(chan amqp.Delivery)
+
+	go func() {
+		defer close(messages)
+
+		for {
+			body, tag, changed := c.broker.take(c)
+			if changed != nil {
+				select {
+				case <-c.done:
+					return
+				case <-changed:
+					continue
+				}
+			}
+
+			select {
+			case <-c.done:
+				// The broker requeues the message as the channel is closed.
+				return
+			case messages <- amqp.Delivery{Acknowledger: c.broker, DeliveryTag: tag, Body: body}:
+			}
+		}
+	}()
+
+	return messages, nil
+}
+
+func (c *fakeChannel) Publish(_ context.Context, _, _ string, msg amqp.Publishing) (confirmation, error) {
+	if err := c.err(); err != nil {
+		return nil, err
+	}
+
+	c.broker.publish(msg.Body)
+
+	return ackedConfirmation{}, nil
+}
+
+func (c *fakeChannel) IsClosed() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *fakeChannel) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		c.broker.requeue(c)
+	})
+
+	return nil
+}
+
+func (c *fakeChannel) err() error {
+	if c.IsClosed() {
+		return amqp.ErrClosed
+	}
+
+	return nil
+}
+
+type ackedConfirmation struct{}
+
+func (ackedConfirmation) WaitContext(context.Context) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,301 @@
+package amqpqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/logger"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/queue"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+var ErrNotConfirmed = errors.New("message was not confirmed by broker")
+
+type Config struct {
+	URL            string
+	Exchange       string
+	ExchangeType   string
+	Queue          string
+	RoutingKey     string
+	Prefetch       int
+	ReconnectDelay time.Duration
+}
+
+type Queue struct {
+	logger logger.Logger
+	cfg    Config
+	dial   dialer
+
+	mu        sync.Mutex
+	closed    bool
+	conn      connection
+	publishCh channel
+}
+
+func New(logger logger.Logger, cfg Config) (*Queue, error) {
+	return newQueue(logger, cfg, dialAMQP)
+}
+
+func newQueue(logger logger.Logger, cfg Config, dial dialer) (*Queue, error) {
+	if cfg.ExchangeType == "" {
+		cfg.ExchangeType = amqp.ExchangeDirect
+	}
+	if cfg.ReconnectDelay <= 0 {
+		cfg.ReconnectDelay = time.Second
+	}
+
+	q := &Queue{logger: logger, cfg: cfg, dial: dial}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, err := q.connection(); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+func (q *Queue) Publish(ctx context.Context, body []byte) error {
+	q.mu.Lock()
+	ch, err := q.publishChannel()
+	q.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	confirmation, err := ch.Publish(ctx, q.cfg.Exchange, q.cfg.RoutingKey,
+		amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			Timestamp:    time.Now(),
+			Body:         body,
+		})
+	if err != nil {
+		return fmt.Errorf("failed to publish message: %w", err)
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to wait for confirmation: %w", err)
+	}
+	if !acked {
+		return ErrNotConfirmed
+	}
+
+	return nil
+}
+
+func (q *Queue) Consume(ctx context.Context) (<-chan queue.Delivery, error) {
+	ch, messages, err := q.openConsumer()
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make(chan queue.Delivery)
+
+	go func() {
+		defer close(deliveries)
+
+		for {
+			q.forward(ctx, messages, deliveries)
+			ch.Close()
+
+			for {
+				if ctx.Err() != nil || q.isClosed() {
+					return
+				}
+
+				q.logger.Warn("AMQP consumer channel closed, reconnecting",
+					slog.String("delay", q.cfg.ReconnectDelay.String()))
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(q.cfg.ReconnectDelay):
+				}
+
+				ch, messages, err = q.openConsumer()
+				if err == nil {
+					break
+				}
+
+				q.logger.Error("Failed to reopen AMQP consumer", slog.String("error", err.Error()))
+			}
+		}
+	}()
+
+	return deliveries, nil
+}
+
+// Check reports whether the broker is reachable, reconnecting if the connection was lost.
+func (q *Queue) Check(_ context.Context) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	_, err := q.connection()
+	return err
+}
+
+func (q *Queue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return nil
+	}
+	q.closed = true
+
+	if q.conn != nil && !q.conn.IsClosed() {
+		return q.conn.Close()
+	}
+
+	return nil
+}
+
+func (q *Queue) forward(ctx context.Context, messages <-chan amqp.Delivery, deliveries chan<- queue.Delivery) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				_ = msg.Nack(false, true)
+				return
+			case deliveries <- &delivery{msg: msg}:
+			}
+		}
+	}
+}
+
+func (q *Queue) openConsumer() (channel, <-chan amqp.Delivery, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	conn, err := q.connection()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open channel: %w", err)
+	}
+
+	if err := ch.Qos(q.cfg.Prefetch, 0, false); err != nil {
+		ch.Close()
+		return nil, nil, fmt.Errorf("failed to set prefetch: %w", err)
+	}
+
+	messages, err := ch.Consume(q.cfg.Queue, "", false, false, false, false, nil)
+	if err != nil {
+		ch.Close()
+		return nil, nil, fmt.Errorf("failed to start consuming: %w", err)
+	}
+
+	return ch, messages, nil
+}
+
+func (q *Queue) publishChannel() (channel, error) {
+	if q.publishCh != nil && !q.publishCh.IsClosed() {
+		return q.publishCh, nil
+	}
+
+	conn, err := q.connection()
+	if err != nil {
+		return nil, err
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open channel: %w", err)
+	}
+
+	if err := ch.Confirm(false); err != nil {
+		ch.Close()
+		return nil, fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+
+	q.publishCh = ch
+	return ch, nil
+}
+
+// connection returns an open connection, dialing and declaring topology if needed. Caller must hold q.mu.
+func (q *Queue) connection() (connection, error) {
+	if q.closed {
+		return nil, queue.ErrQueueClosed
+	}
+
+	if q.conn != nil && !q.conn.IsClosed() {
+		return q.conn, nil
+	}
+
+	conn, err := q.dial(q.cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to broker: %w", err)
+	}
+
+	if err := q.declare(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	q.conn = conn
+	q.publishCh = nil
+
+	return conn, nil
+}
+
+func (q *Queue) declare(conn connection) error {
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to open channel: %w", err)
+	}
+	defer ch.Close()
+
+	if err := ch.ExchangeDeclare(q.cfg.Exchange, q.cfg.ExchangeType, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare exchange: %w", err)
+	}
+
+	if _, err := ch.QueueDeclare(q.cfg.Queue, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+
+	if err := ch.QueueBind(q.cfg.Queue, q.cfg.RoutingKey, q.cfg.Exchange, false, nil); err != nil {
+		return fmt.Errorf("failed to bind queue: %w", err)
+	}
+
+	return nil
+}
+
+func (q *Queue) isClosed() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.closed
+}
+
+type delivery struct {
+	msg amqp.Delivery
+}
+
+func (d *delivery) Body() []byte {
+	return d.msg.Body
+}
+
+func (d *delivery) Ack() error {
+	return d.msg.Ack(false)
+}
+
+func (d *delivery) Nack(requeue bool) error {
+	return d.msg.Nack(false, requeue)
+}
@@ -0,0 +1,184 @@
+package memoryqueue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/queue"
+)
+
+type Queue struct {
+	mu       sync.RWMutex
+	closed   bool
+	messages chan []byte
+
+	// requeued holds nacked messages, which are consumed before published ones. It is not bounded,
+	// so that requeue never blocks, but it holds no more messages than were delivered and not settled.
+	requeuedMu sync.Mutex
+	requeued   [][]byte
+	// requeuedSignal wakes up a consumer waiting for published messages.
+	requeuedSignal chan struct{}
+}
+
+func New(size int) *Queue {
+	return &Queue{
+		messages:       make(chan []byte, size),
+		requeuedSignal: make(chan struct{}, 1),
+	}
+}
+
+func (q *Queue) Publish(ctx context.Context, body []byte) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		return queue.ErrQueueClosed
+	}
+
+	msg := make([]byte, len(body))
+	copy(msg, body)
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case q.messages <- msg:
+		return nil
+	}
+}
+
+func (q *Queue) Consume(ctx context.Context) (<-chan queue.Delivery, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		return nil, queue.ErrQueueClosed
+	}
+
+	deliveries := make(chan queue.Delivery)
+
+	go func() {
+		defer close(deliveries)
+
+		for {
+			body, ok := q.next(ctx)
+			if !ok {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				// Put the message back so that another consumer can receive it.
+				q.pushRequeued(body)
+				return
+			case deliveries <- &delivery{queue: q, body: body}:
+			}
+		}
+	}()
+
+	return deliveries, nil
+}
+
+// next waits for a requeued or published message, it returns false once ctx is done or the queue is closed.
+func (q *Queue) next(ctx context.Context) ([]byte, bool) {
+	for {
+		if body, ok := q.popRequeued(); ok {
+			return body, true
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, false
+		case <-q.requeuedSignal:
+		case body, ok := <-q.messages:
+			return body, ok
+		}
+	}
+}
+
+func (q *Queue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !q.closed {
+		q.closed = true
+		close(q.messages)
+	}
+
+	return nil
+}
+
+// requeue puts the message back without waiting for room in the buffer, so that it neither blocks
+// the consumer nor gets lost. It fails only if the queue is closed.
+func (q *Queue) requeue(body []byte) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		return fmt.Errorf("failed to requeue message: %w", queue.ErrQueueClosed)
+	}
+
+	q.pushRequeued(body)
+	return nil
+}
+
+func (q *Queue) pushRequeued(body []byte) {
+	q.requeuedMu.Lock()
+	q.requeued = append(q.requeued, body)
+	q.requeuedMu.Unlock()
+
+	select {
+	case q.requeuedSignal <- struct{}{}:
+	default:
+	}
+}
+
+func (q *Queue) popRequeued() ([]byte, bool) {
+	q.requeuedMu.Lock()
+	defer q.requeuedMu.Unlock()
+
+	if len(q.requeued) == 0 {
+		return nil, false
+	}
+
+	body := q.requeued[0]
+	q.requeued[0] = nil
+	q.requeued = q.requeued[1:]
+
+	return body, true
+}
+
+type delivery struct {
+	mu    sync.Mutex
+	queue *Queue
+	body  []byte
+	done  bool
+}
+
+func (d *delivery) Body() []byte {
+	return d.body
+}
+
+func (d *delivery) Ack() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.done = true
+	return nil
+}
+
+func (d *delivery) Nack(requeue bool) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.done {
+		return nil
+	}
+	d.done = true
+
+	if requeue {
+		return d.queue.requeue(d.body)
+	}
+
+	return nil
+}
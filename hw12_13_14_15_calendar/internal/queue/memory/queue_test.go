@@ -0,0 +1,107 @@
+package memoryqueue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/queue"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/queue/queuetest"
+)
+
+func TestQueue_Contract(t *testing.T) {
+	queuetest.Run(t, func(_ *testing.T) queuetest.Queue {
+		return New(10)
+	})
+}
+
+func TestQueue_Closed(t *testing.T) {
+	ctx := context.Background()
+	q := New(1)
+
+	deliveries, err := q.Consume(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q.Close()
+
+	if _, ok := <-deliveries; ok {
+		t.Error("deliveries channel should be closed after Close()")
+	}
+
+	if err := q.Publish(ctx, []byte("late")); !errors.Is(err, queue.ErrQueueClosed) {
+		t.Errorf("Publish() error = %v, want %v", err, queue.ErrQueueClosed)
+	}
+
+	if _, err := q.Consume(ctx); !errors.Is(err, queue.ErrQueueClosed) {
+		t.Errorf("Consume() error = %v, want %v", err, queue.ErrQueueClosed)
+	}
+}
+
+func TestQueue_RequeueFullBuffer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	q := New(1)
+	defer q.Close()
+
+	deliveries, err := q.Consume(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := q.Publish(ctx, []byte("first")); err != nil {
+		t.Fatal(err)
+	}
+	d := queuetest.Receive(t, deliveries)
+	// The consumer holds the second message and the third one fills the buffer.
+	for _, body := range []string{"second", "third"} {
+		if err := q.Publish(ctx, []byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	nacked := make(chan error)
+	go func() {
+		nacked <- d.Nack(true)
+	}()
+
+	select {
+	case err := <-nacked:
+		if err != nil {
+			t.Fatalf("Nack() error = %v, want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Nack() blocked on a full buffer")
+	}
+
+	received := make(map[string]bool)
+	for range 3 {
+		received[string(queuetest.Receive(t, deliveries).Body())] = true
+	}
+	if !received["first"] || !received["second"] || !received["third"] {
+		t.Errorf("received %v, want first, second and third", received)
+	}
+}
+
+func TestQueue_RequeueClosed(t *testing.T) {
+	ctx := context.Background()
+	q := New(1)
+
+	deliveries, err := q.Consume(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Publish(ctx, []byte("late")); err != nil {
+		t.Fatal(err)
+	}
+	d := queuetest.Receive(t, deliveries)
+
+	q.Close()
+
+	if err := d.Nack(true); !errors.Is(err, queue.ErrQueueClosed) {
+		t.Errorf("Nack() error = %v, want %v", err, queue.ErrQueueClosed)
+	}
+}
@@ -0,0 +1,22 @@
+package queue
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrQueueClosed = errors.New("queue closed")
+
+type Producer interface {
+	Publish(ctx context.Context, body []byte) error
+}
+
+type Consumer interface {
+	Consume(ctx context.Context) (<-chan Delivery, error)
+}
+
+type Delivery interface {
+	Body() []byte
+	Ack() error
+	Nack(requeue bool) error
+}
@@ -0,0 +1,153 @@
+// Package queuetest contains contract tests shared by queue implementations.
+package queuetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/queue"
+)
+
+type Queue interface {
+	queue.Producer
+	queue.Consumer
+	Close() error
+}
+
+func Run(t *testing.T, newQueue func(t *testing.T) Queue) {
+	t.Helper()
+
+	t.Run("PublishConsume", func(t *testing.T) { testPublishConsume(t, newQueue(t)) })
+	t.Run("NackRequeue", func(t *testing.T) { testNackRequeue(t, newQueue(t)) })
+	t.Run("NackDrop", func(t *testing.T) { testNackDrop(t, newQueue(t)) })
+	t.Run("Order", func(t *testing.T) { testOrder(t, newQueue(t)) })
+}
+
+func Receive(t *testing.T, deliveries <-chan queue.Delivery) queue.Delivery {
+	t.Helper()
+
+	select {
+	case d, ok := <-deliveries:
+		if !ok {
+			t.Fatal("deliveries channel closed")
+		}
+		return d
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for delivery")
+	}
+
+	return nil
+}
+
+func ExpectEmpty(t *testing.T, deliveries <-chan queue.Delivery) {
+	t.Helper()
+
+	select {
+	case d, ok := <-deliveries:
+		if ok {
+			t.Errorf("unexpected delivery %q", d.Body())
+		}
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func consume(t *testing.T, q Queue) <-chan queue.Delivery {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		cancel()
+		q.Close()
+	})
+
+	deliveries, err := q.Consume(ctx)
+	if err != nil {
+		t.Fatalf("Consume() error = %v, want nil", err)
+	}
+
+	return deliveries
+}
+
+func publish(t *testing.T, q Queue, body string) {
+	t.Helper()
+
+	if err := q.Publish(context.Background(), []byte(body)); err != nil {
+		t.Fatalf("Publish() error = %v, want nil", err)
+	}
+}
+
+func testPublishConsume(t *testing.T, q Queue) {
+	t.Helper()
+
+	deliveries := consume(t, q)
+
+	body := []byte("hello")
+	if err := q.Publish(context.Background(), body); err != nil {
+		t.Fatalf("Publish() error = %v, want nil", err)
+	}
+	body[0] = 'j'
+
+	d := Receive(t, deliveries)
+	if string(d.Body()) != "hello" {
+		t.Errorf("Body() = %q, want %q", d.Body(), "hello")
+	}
+	if err := d.Ack(); err != nil {
+		t.Errorf("Ack() error = %v, want nil", err)
+	}
+
+	ExpectEmpty(t, deliveries)
+}
+
+func testNackRequeue(t *testing.T, q Queue) {
+	t.Helper()
+
+	deliveries := consume(t, q)
+	publish(t, q, "retry me")
+
+	if err := Receive(t, deliveries).Nack(true); err != nil {
+		t.Fatalf("Nack() error = %v, want nil", err)
+	}
+
+	d := Receive(t, deliveries)
+	if string(d.Body()) != "retry me" {
+		t.Errorf("Body() = %q, want %q", d.Body(), "retry me")
+	}
+	if err := d.Ack(); err != nil {
+		t.Errorf("Ack() error = %v, want nil", err)
+	}
+}
+
+func testNackDrop(t *testing.T, q Queue) {
+	t.Helper()
+
+	deliveries := consume(t, q)
+	publish(t, q, "drop me")
+
+	if err := Receive(t, deliveries).Nack(false); err != nil {
+		t.Fatalf("Nack() error = %v, want nil", err)
+	}
+
+	ExpectEmpty(t, deliveries)
+}
+
+func testOrder(t *testing.T, q Queue) {
+	t.Helper()
+
+	deliveries := consume(t, q)
+
+	want := []string{"first", "second", "third"}
+	for _, body := range want {
+		publish(t, q, body)
+	}
+
+	for _, body := range want {
+		d := Receive(t, deliveries)
+		if string(d.Body()) != body {
+			t.Errorf("Body() = %q, want %q", d.Body(), body)
+		}
+		if err := d.Ack(); err != nil {
+			t.Errorf("Ack() error = %v, want nil", err)
+		}
+	}
+}
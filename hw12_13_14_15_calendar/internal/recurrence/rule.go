@@ -0,0 +1,529 @@
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+const (
+	untilFormat     = "20060102T150405Z"
+	untilDateFormat = "20060102"
+
+	// maxPeriods bounds the expansion of rules which never produce an occurrence (e.g. BYMONTHDAY=31 with FEB only).
+	maxPeriods = 100000
+)
+
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// WeekdayNum is a BYDAY entry: a weekday with an optional ordinal (e.g. 2MO, -1FR) inside the month or year.
+type WeekdayNum struct {
+	N   int
+	Day time.Weekday
+}
+
+// Rule is a subset of RFC 5545 RRULE: FREQ, INTERVAL, BYDAY, BYMONTHDAY, COUNT and UNTIL.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	Count      int
+	Until      time.Time
+}
+
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	r := &Rule{Interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			err = r.parseFreq(value)
+		case "INTERVAL":
+			r.Interval, err = parsePositive(value)
+		case "COUNT":
+			r.Count, err = parsePositive(value)
+		case "UNTIL":
+			r.Until, err = parseUntil(value)
+		case "BYDAY":
+			r.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseByMonthDay(value)
+		case "WKST":
+			if strings.ToUpper(value) != "MO" {
+				err = fmt.Errorf("%w: only WKST=MO is supported", ErrInvalidRule)
+			}
+		default:
+			err = fmt.Errorf("%w: unsupported part %q", ErrInvalidRule, key)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			days[i] = d.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, d := range r.ByMonthDay {
+			days[i] = strconv.Itoa(d)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilFormat))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+func (d WeekdayNum) String() string {
+	name := strings.ToUpper(d.Day.String()[:2])
+	if d.N == 0 {
+		return name
+	}
+
+	return strconv.Itoa(d.N) + name
+}
+
+// Between returns starts of occurrences in [from, to) which are not listed in exdates.
+func (r *Rule) Between(dtstart, from, to time.Time, exdates []time.Time) []time.Time {
+	var result []time.Time
+
+	r.iterate(dtstart, r.firstPeriod(dtstart, from), to, func(t time.Time) bool {
+		if !t.Before(to) {
+			return false
+		}
+
+		if !t.Before(from) && !isExcluded(t, exdates) {
+			result = append(result, t)
+		}
+
+		return true
+	})
+
+	return result
+}
+
+// Last returns the start of the last occurrence. It reports false for endless rules
+// and for rules whose occurrences are all excluded.
+func (r *Rule) Last(dtstart time.Time, exdates []time.Time) (time.Time, bool) {
+	if r.Count == 0 && r.Until.IsZero() {
+		return time.Time{}, false
+	}
+
+	var (
+		last  time.Time
+		found bool
+	)
+
+	r.iterate(dtstart, 0, time.Time{}, func(t time.Time) bool {
+		if !isExcluded(t, exdates) {
+			last, found = t, true
+		}
+
+		return true
+	})
+
+	return last, found
+}
+
+// iterate calls fn for every occurrence in chronological order, starting from the given period.
+// Iteration stops when fn returns false, the rule is exhausted or the period starts after stop.
+func (r *Rule) iterate(dtstart time.Time, firstPeriod int, stop time.Time, fn func(time.Time) bool) {
+	count := 0
+	emit := func(t time.Time) bool {
+		if !r.Until.IsZero() && t.After(r.Until) {
+			return false
+		}
+
+		count++
+		if !fn(t) {
+			return false
+		}
+
+		return r.Count == 0 || count < r.Count
+	}
+
+	// DTSTART is always the first occurrence of the set.
+	if firstPeriod == 0 && !emit(dtstart) {
+		return
+	}
+
+	for k := firstPeriod; k < firstPeriod+maxPeriods; k++ {
+		periodStart, candidates := r.candidates(dtstart, k)
+		if !stop.IsZero() && periodStart.After(stop) {
+			return
+		}
+
+		for _, c := range candidates {
+			if !c.After(dtstart) {
+				continue
+			}
+
+			if !emit(c) {
+				return
+			}
+		}
+	}
+}
+
+// firstPeriod returns the index of a period which is guaranteed not to be later than the one containing from.
+// Rules with COUNT are always expanded from the beginning because every occurrence must be counted.
+func (r *Rule) firstPeriod(dtstart, from time.Time) int {
+	if r.Count > 0 || !from.After(dtstart) {
+		return 0
+	}
+
+	var periods int
+	switch r.Freq {
+	case Daily:
+		periods = int(from.Sub(dtstart).Hours() / 24)
+	case Weekly:
+		periods = int(from.Sub(dtstart).Hours() / 24 / 7)
+	case Monthly:
+		periods = (from.Year()-dtstart.Year())*12 + int(from.Month()-dtstart.Month())
+	case Yearly:
+		periods = from.Year() - dtstart.Year()
+	}
+
+	k := periods/r.Interval - 1
+	if k < 0 {
+		return 0
+	}
+
+	return k
+}
+
+// candidates returns the start of the k-th period and sorted occurrence candidates inside it.
+func (r *Rule) candidates(dtstart time.Time, k int) (time.Time, []time.Time) {
+	y, m, d := dtstart.Date()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, dtstart.Hour(), dtstart.Minute(), dtstart.Second(), dtstart.Nanosecond(),
+			dtstart.Location())
+	}
+
+	var (
+		periodStart time.Time
+		result      []time.Time
+	)
+
+	switch r.Freq {
+	case Daily:
+		periodStart = at(y, m, d+k*r.Interval)
+		if r.matchesByDay(periodStart) && r.matchesByMonthDay(periodStart) {
+			result = append(result, periodStart)
+		}
+	case Weekly:
+		offset := (int(dtstart.Weekday()) + 6) % 7
+		periodStart = at(y, m, d-offset+k*r.Interval*7)
+		days := []time.Weekday{dtstart.Weekday()}
+		if len(r.ByDay) > 0 {
+			days = days[:0]
+			for _, wd := range r.ByDay {
+				days = append(days, wd.Day)
+			}
+		}
+		for _, wd := range days {
+			t := periodStart.AddDate(0, 0, (int(wd)+6)%7)
+			if r.matchesByMonthDay(t) {
+				result = append(result, t)
+			}
+		}
+	case Monthly:
+		periodStart = at(y, m+time.Month(k*r.Interval), 1)
+		result = r.expandPeriod(periodStart, periodStart.AddDate(0, 1, 0), func() []time.Time {
+			return dayOfPeriod(periodStart, d)
+		})
+	case Yearly:
+		periodStart = at(y+k*r.Interval, time.January, 1)
+		result = r.expandPeriod(periodStart, periodStart.AddDate(1, 0, 0), func() []time.Time {
+			return dayOfPeriod(at(periodStart.Year(), m, 1), d)
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
+
+	return periodStart, result
+}
+
+// expandPeriod expands BYDAY and BYMONTHDAY inside a month or a year starting at start and ending before end.
+func (r *Rule) expandPeriod(start, end time.Time, byDefault func() []time.Time) []time.Time {
+	if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+		return byDefault()
+	}
+
+	var result []time.Time
+	if len(r.ByDay) > 0 {
+		for _, wd := range r.ByDay {
+			for _, t := range weekdaysInPeriod(start, end, wd) {
+				if r.matchesByMonthDay(t) {
+					result = append(result, t)
+				}
+			}
+		}
+		return result
+	}
+
+	for month := start; month.Before(end); month = month.AddDate(0, 1, 0) {
+		for _, day := range r.ByMonthDay {
+			result = append(result, dayOfPeriod(month, day)...)
+		}
+	}
+
+	return result
+}
+
+func (r *Rule) matchesByDay(t time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+
+	for _, wd := range r.ByDay {
+		if wd.Day == t.Weekday() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Rule) matchesByMonthDay(t time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+
+	dim := daysInMonth(t)
+	for _, day := range r.ByMonthDay {
+		if day == t.Day() || (day < 0 && dim+day+1 == t.Day()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Rule) parseFreq(value string) error {
+	switch freq := Frequency(strings.ToUpper(value)); freq {
+	case Daily, Weekly, Monthly, Yearly:
+		r.Freq = freq
+		return nil
+	default:
+		return fmt.Errorf("%w: unsupported FREQ %q", ErrInvalidRule, value)
+	}
+}
+
+func (r *Rule) validate() error {
+	if r.Freq == "" {
+		return fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+
+	if r.Count > 0 && !r.Until.IsZero() {
+		return fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrInvalidRule)
+	}
+
+	if r.Freq == Daily || r.Freq == Weekly {
+		for _, wd := range r.ByDay {
+			if wd.N != 0 {
+				return fmt.Errorf("%w: BYDAY ordinals are allowed only for MONTHLY and YEARLY", ErrInvalidRule)
+			}
+		}
+	}
+
+	if r.Freq == Weekly && len(r.ByMonthDay) > 0 {
+		return fmt.Errorf("%w: BYMONTHDAY is not allowed for WEEKLY", ErrInvalidRule)
+	}
+
+	return nil
+}
+
+func parsePositive(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%w: %q is not a positive number", ErrInvalidRule, value)
+	}
+
+	return n, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	if t, err := time.Parse(untilFormat, value); err == nil {
+		return t, nil
+	}
+
+	if t, err := time.Parse(untilDateFormat, value); err == nil {
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+
+	return time.Time{}, fmt.Errorf("%w: invalid UNTIL %q", ErrInvalidRule, value)
+}
+
+func parseByDay(value string) ([]WeekdayNum, error) {
+	items := strings.Split(value, ",")
+	result := make([]WeekdayNum, 0, len(items))
+	for _, item := range items {
+		item = strings.ToUpper(strings.TrimSpace(item))
+		if len(item) < 2 {
+			return nil, fmt.Errorf("%w: invalid BYDAY %q", ErrInvalidRule, item)
+		}
+
+		day, ok := weekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid BYDAY %q", ErrInvalidRule, item)
+		}
+
+		var n int
+		if prefix := item[:len(item)-2]; prefix != "" {
+			var err error
+			n, err = strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -53 || n > 53 {
+				return nil, fmt.Errorf("%w: invalid BYDAY %q", ErrInvalidRule, item)
+			}
+		}
+
+		result = append(result, WeekdayNum{N: n, Day: day})
+	}
+
+	return result, nil
+}
+
+func parseByMonthDay(value string) ([]int, error) {
+	items := strings.Split(value, ",")
+	result := make([]int, 0, len(items))
+	for _, item := range items {
+		n, err := strconv.Atoi(strings.TrimSpace(item))
+		if err != nil || n == 0 || n < -31 || n > 31 {
+			return nil, fmt.Errorf("%w: invalid BYMONTHDAY %q", ErrInvalidRule, item)
+		}
+
+		result = append(result, n)
+	}
+
+	return result, nil
+}
+
+// dayOfPeriod returns the given day of the month starting at month, counting from the end for negative days.
+func dayOfPeriod(month time.Time, day int) []time.Time {
+	dim := daysInMonth(month)
+	if day < 0 {
+		day = dim + day + 1
+	}
+
+	if day < 1 || day > dim {
+		return nil
+	}
+
+	return []time.Time{month.AddDate(0, 0, day-1)}
+}
+
+func weekdaysInPeriod(start, end time.Time, wd WeekdayNum) []time.Time {
+	first := start.AddDate(0, 0, (int(wd.Day)-int(start.Weekday())+7)%7)
+
+	var all []time.Time
+	for t := first; t.Before(end); t = t.AddDate(0, 0, 7) {
+		all = append(all, t)
+	}
+
+	switch {
+	case wd.N == 0:
+		return all
+	case wd.N > 0 && wd.N <= len(all):
+		return all[wd.N-1 : wd.N]
+	case wd.N < 0 && -wd.N <= len(all):
+		return all[len(all)+wd.N : len(all)+wd.N+1]
+	default:
+		return nil
+	}
+}
+
+func daysInMonth(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func isExcluded(t time.Time, exdates []time.Time) bool {
+	for _, ex := range exdates {
+		if ex.Equal(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SeriesEnd returns the end of the last occurrence of a recurring event, or nil if the series is endless.
+func SeriesEnd(rule string, start, end time.Time, exdates []time.Time) (*time.Time, error) {
+	r, err := Parse(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Count == 0 && r.Until.IsZero() {
+		return nil, nil
+	}
+
+	last, ok := r.Last(start, exdates)
+	if !ok {
+		return &end, nil
+	}
+
+	seriesEnd := last.Add(end.Sub(start))
+	return &seriesEnd, nil
+}
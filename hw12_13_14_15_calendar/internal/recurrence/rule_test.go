@@ -0,0 +1,272 @@
+package recurrence
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day, hour int) time.Time {
+	return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Daily", "FREQ=DAILY", "FREQ=DAILY"},
+		{"Prefix and case", "RRULE:freq=weekly;byday=mo,we", "FREQ=WEEKLY;BYDAY=MO,WE"},
+		{"Interval and count", "FREQ=WEEKLY;INTERVAL=2;COUNT=10", "FREQ=WEEKLY;INTERVAL=2;COUNT=10"},
+		{"Until", "FREQ=DAILY;UNTIL=20251231T100000Z", "FREQ=DAILY;UNTIL=20251231T100000Z"},
+		{"Until date", "FREQ=DAILY;UNTIL=20251231", "FREQ=DAILY;UNTIL=20251231T235959Z"},
+		{"Monthly ordinals", "FREQ=MONTHLY;BYDAY=-1FR,2MO", "FREQ=MONTHLY;BYDAY=-1FR,2MO"},
+		{"Month days", "FREQ=MONTHLY;BYMONTHDAY=1,-1", "FREQ=MONTHLY;BYMONTHDAY=1,-1"},
+		{"Week start", "FREQ=WEEKLY;WKST=MO", "FREQ=WEEKLY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse() error = %v, want nil", err)
+			}
+			if r.String() != tt.want {
+				t.Errorf("String() = %q, want %q", r.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"Empty", ""},
+		{"No freq", "INTERVAL=2"},
+		{"Unknown freq", "FREQ=HOURLY"},
+		{"Malformed", "FREQ"},
+		{"Zero interval", "FREQ=DAILY;INTERVAL=0"},
+		{"Count and until", "FREQ=DAILY;COUNT=2;UNTIL=20251231"},
+		{"Bad until", "FREQ=DAILY;UNTIL=tomorrow"},
+		{"Bad weekday", "FREQ=WEEKLY;BYDAY=XX"},
+		{"Ordinal in weekly", "FREQ=WEEKLY;BYDAY=1MO"},
+		{"Month day out of range", "FREQ=MONTHLY;BYMONTHDAY=32"},
+		{"Month day in weekly", "FREQ=WEEKLY;BYMONTHDAY=1"},
+		{"Unsupported part", "FREQ=YEARLY;BYMONTH=1"},
+		{"Unsupported week start", "FREQ=WEEKLY;WKST=SU"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.input); !errors.Is(err, ErrInvalidRule) {
+				t.Errorf("Parse(%q) error = %v, want %v", tt.input, err, ErrInvalidRule)
+			}
+		})
+	}
+}
+
+func TestRule_Between(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		from    time.Time
+		to      time.Time
+		exdates []time.Time
+		want    []time.Time
+	}{
+		{
+			name:    "Daily",
+			rule:    "FREQ=DAILY",
+			dtstart: date(2025, time.May, 1, 9),
+			from:    date(2025, time.May, 3, 0),
+			to:      date(2025, time.May, 6, 0),
+			want:    []time.Time{date(2025, time.May, 3, 9), date(2025, time.May, 4, 9), date(2025, time.May, 5, 9)},
+		},
+		{
+			name:    "Daily with interval and weekdays",
+			rule:    "FREQ=DAILY;INTERVAL=2;BYDAY=MO,TU,WE,TH,FR",
+			dtstart: date(2025, time.May, 1, 9), // Thursday.
+			from:    date(2025, time.May, 1, 0),
+			to:      date(2025, time.May, 8, 0),
+			want:    []time.Time{date(2025, time.May, 1, 9), date(2025, time.May, 5, 9), date(2025, time.May, 7, 9)},
+		},
+		{
+			name:    "Weekly standup",
+			rule:    "FREQ=WEEKLY;BYDAY=MO,WE,FR",
+			dtstart: date(2025, time.May, 5, 10), // Monday.
+			from:    date(2025, time.May, 7, 0),
+			to:      date(2025, time.May, 13, 0),
+			want:    []time.Time{date(2025, time.May, 7, 10), date(2025, time.May, 9, 10), date(2025, time.May, 12, 10)},
+		},
+		{
+			name:    "Biweekly",
+			rule:    "FREQ=WEEKLY;INTERVAL=2",
+			dtstart: date(2025, time.May, 6, 10),
+			from:    date(2025, time.May, 1, 0),
+			to:      date(2025, time.June, 10, 0),
+			want:    []time.Time{date(2025, time.May, 6, 10), date(2025, time.May, 20, 10), date(2025, time.June, 3, 10)},
+		},
+		{
+			name:    "Weekly with count",
+			rule:    "FREQ=WEEKLY;COUNT=3",
+			dtstart: date(2025, time.May, 6, 10),
+			from:    date(2025, time.May, 1, 0),
+			to:      date(2025, time.July, 1, 0),
+			want:    []time.Time{date(2025, time.May, 6, 10), date(2025, time.May, 13, 10), date(2025, time.May, 20, 10)},
+		},
+		{
+			name:    "Until is inclusive",
+			rule:    "FREQ=DAILY;UNTIL=20250503T090000Z",
+			dtstart: date(2025, time.May, 1, 9),
+			from:    date(2025, time.May, 1, 0),
+			to:      date(2025, time.May, 10, 0),
+			want:    []time.Time{date(2025, time.May, 1, 9), date(2025, time.May, 2, 9), date(2025, time.May, 3, 9)},
+		},
+		{
+			name:    "Exdates",
+			rule:    "FREQ=DAILY;COUNT=4",
+			dtstart: date(2025, time.May, 1, 9),
+			from:    date(2025, time.May, 1, 0),
+			to:      date(2025, time.May, 10, 0),
+			exdates: []time.Time{date(2025, time.May, 2, 9)},
+			want:    []time.Time{date(2025, time.May, 1, 9), date(2025, time.May, 3, 9), date(2025, time.May, 4, 9)},
+		},
+		{
+			name:    "Monthly skips short months",
+			rule:    "FREQ=MONTHLY",
+			dtstart: date(2025, time.January, 31, 12),
+			from:    date(2025, time.January, 1, 0),
+			to:      date(2025, time.June, 1, 0),
+			want:    []time.Time{date(2025, time.January, 31, 12), date(2025, time.March, 31, 12), date(2025, time.May, 31, 12)},
+		},
+		{
+			name:    "Monthly last day",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=-1",
+			dtstart: date(2025, time.January, 31, 12),
+			from:    date(2025, time.February, 1, 0),
+			to:      date(2025, time.May, 1, 0),
+			want: []time.Time{
+				date(2025, time.February, 28, 12), date(2025, time.March, 31, 12), date(2025, time.April, 30, 12),
+			},
+		},
+		{
+			name:    "Monthly last friday",
+			rule:    "FREQ=MONTHLY;BYDAY=-1FR",
+			dtstart: date(2025, time.May, 30, 18),
+			from:    date(2025, time.June, 1, 0),
+			to:      date(2025, time.September, 1, 0),
+			want:    []time.Time{date(2025, time.June, 27, 18), date(2025, time.July, 25, 18), date(2025, time.August, 29, 18)},
+		},
+		{
+			name:    "Monthly friday 13th",
+			rule:    "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13",
+			dtstart: date(2025, time.June, 13, 0),
+			from:    date(2025, time.June, 14, 0),
+			to:      date(2026, time.June, 1, 0),
+			want:    []time.Time{date(2026, time.February, 13, 0), date(2026, time.March, 13, 0)},
+		},
+		{
+			name:    "Yearly leap day",
+			rule:    "FREQ=YEARLY",
+			dtstart: date(2024, time.February, 29, 8),
+			from:    date(2024, time.March, 1, 0),
+			to:      date(2033, time.January, 1, 0),
+			want:    []time.Time{date(2028, time.February, 29, 8), date(2032, time.February, 29, 8)},
+		},
+		{
+			name:    "Window far from start",
+			rule:    "FREQ=DAILY",
+			dtstart: date(1990, time.January, 1, 9),
+			from:    date(2025, time.May, 1, 0),
+			to:      date(2025, time.May, 2, 0),
+			want:    []time.Time{date(2025, time.May, 1, 9)},
+		},
+		{
+			name:    "Before start",
+			rule:    "FREQ=DAILY",
+			dtstart: date(2025, time.May, 1, 9),
+			from:    date(2025, time.April, 1, 0),
+			to:      date(2025, time.May, 1, 0),
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := r.Between(tt.dtstart, tt.from, tt.to, tt.exdates)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Between() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("Between()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRule_BetweenKeepsWallClockAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone database is not available")
+	}
+
+	r, err := Parse("FREQ=WEEKLY")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dtstart := time.Date(2025, time.March, 24, 9, 0, 0, 0, loc)
+	got := r.Between(dtstart, dtstart, dtstart.AddDate(0, 0, 14), nil)
+	if len(got) != 2 {
+		t.Fatalf("Between() = %v, want 2 occurrences", got)
+	}
+	if got[1].Hour() != 9 || got[1].Sub(got[0]) != 7*24*time.Hour-time.Hour {
+		t.Errorf("Between()[1] = %v, want 09:00 local time after DST switch", got[1])
+	}
+}
+
+func TestRule_Last(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		exdates []time.Time
+		want    time.Time
+		ok      bool
+	}{
+		{"Endless", "FREQ=DAILY", nil, time.Time{}, false},
+		{"Count", "FREQ=WEEKLY;COUNT=3", nil, date(2025, time.May, 15, 9), true},
+		{"Until", "FREQ=DAILY;UNTIL=20250503T120000Z", nil, date(2025, time.May, 3, 9), true},
+		{"Last excluded", "FREQ=DAILY;COUNT=2", []time.Time{date(2025, time.May, 2, 9)}, date(2025, time.May, 1, 9), true},
+		{
+			"All excluded", "FREQ=DAILY;COUNT=2",
+			[]time.Time{date(2025, time.May, 1, 9), date(2025, time.May, 2, 9)},
+			time.Time{},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, ok := r.Last(date(2025, time.May, 1, 9), tt.exdates)
+			if ok != tt.ok || !got.Equal(tt.want) {
+				t.Errorf("Last() = %v, %v, want %v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/logger"
+)
+
+type CleanerStorage interface {
+	DeleteEventsBefore(ctx context.Context, t time.Time) (int64, error)
+	PurgeDeletedEvents(ctx context.Context, t time.Time) (int64, error)
+}
+
+type Cleaner struct {
+	logger         logger.Logger
+	storage        CleanerStorage
+	retention      time.Duration
+	trashRetention time.Duration
+	interval       time.Duration
+
+	purged atomic.Int64
+}
+
+func NewCleaner(
+	logger logger.Logger,
+	storage CleanerStorage,
+	retention, trashRetention, interval time.Duration,
+) *Cleaner {
+	return &Cleaner{
+		logger:         logger,
+		storage:        storage,
+		retention:      retention,
+		trashRetention: trashRetention,
+		interval:       interval,
+	}
+}
+
+func (c *Cleaner) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	c.logger.Info("Cleaner started",
+		slog.String("retention", c.retention.String()),
+		slog.String("trash_retention", c.trashRetention.String()),
+		slog.String("interval", c.interval.String()))
+
+	for {
+		c.purge(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			c.logger.Info("Cleaner stopped", slog.Int64("purged_total", c.Purged()))
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge removes events that ended earlier than now minus the retention period
+// and events that stay in the trash longer than the trash retention period.
+func (c *Cleaner) Purge(ctx context.Context, now time.Time) (int64, error) {
+	deleted, err := c.storage.DeleteEventsBefore(ctx, now.Add(-c.retention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete old events: %w", err)
+	}
+	c.purged.Add(deleted)
+
+	trashed, err := c.storage.PurgeDeletedEvents(ctx, now.Add(-c.trashRetention))
+	if err != nil {
+		return deleted, fmt.Errorf("failed to purge deleted events: %w", err)
+	}
+	c.purged.Add(trashed)
+
+	return deleted + trashed, nil
+}
+
+func (c *Cleaner) Purged() int64 {
+	return c.purged.Load()
+}
+
+func (c *Cleaner) purge(ctx context.Context, now time.Time) {
+	deleted, err := c.Purge(ctx, now)
+	if err != nil {
+		c.logger.Error("Failed to purge old events", slog.String("error", err.Error()))
+		return
+	}
+
+	c.logger.Info("Old events purged",
+		slog.Int64("deleted", deleted),
+		slog.Int64("purged_total", c.Purged()))
+}
@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+	memorystorage "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/memory"
+)
+
+func TestCleaner_Purge(t *testing.T) {
+	ctx := context.Background()
+	st := memorystorage.NewStorage()
+	year := 365 * 24 * time.Hour
+	c := NewCleaner(slog.New(slog.NewTextHandler(io.Discard, nil)), st, year, 30*24*time.Hour, time.Hour)
+
+	now := time.Date(2025, time.May, 10, 12, 0, 0, 0, time.UTC)
+
+	testEvents := []storage.CreateOrUpdateEventParams{
+		{Title: "Two years ago", StartTime: now.Add(-2 * year), EndTime: now.Add(-2 * year).Add(time.Hour)},
+		{Title: "Ends after border", StartTime: now.Add(-year - time.Hour), EndTime: now.Add(-year + time.Hour)},
+		{Title: "Yesterday", StartTime: now.AddDate(0, 0, -1), EndTime: now.AddDate(0, 0, -1).Add(time.Hour)},
+	}
+
+	for _, e := range testEvents {
+		if _, err := st.CreateEvent(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deleted, err := c.Purge(ctx, now)
+	if err != nil {
+		t.Fatalf("Purge() error = %v, want nil", err)
+	}
+	if deleted != 1 {
+		t.Errorf("Purge() deleted = %d, want 1", deleted)
+	}
+
+	if _, err := c.Purge(ctx, now.Add(2*time.Hour)); err != nil {
+		t.Fatalf("Purge() error = %v, want nil", err)
+	}
+	if c.Purged() != 2 {
+		t.Errorf("Purged() = %d, want 2", c.Purged())
+	}
+
+	events, err := st.GetAllEvents(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Title != "Yesterday" {
+		t.Errorf("GetAllEvents() = %+v, want only Yesterday", events)
+	}
+}
+
+func TestCleaner_PurgeTrash(t *testing.T) {
+	ctx := context.Background()
+	st := memorystorage.NewStorage()
+	c := NewCleaner(slog.New(slog.NewTextHandler(io.Discard, nil)), st, 365*24*time.Hour, time.Hour, time.Hour)
+
+	now := time.Now()
+	event, err := st.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+		Title: "Deleted", StartTime: now, EndTime: now.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := st.DeleteEvent(ctx, event.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if deleted, err := c.Purge(ctx, now); err != nil || deleted != 0 {
+		t.Errorf("Purge() = %d, %v, want 0, nil", deleted, err)
+	}
+
+	if deleted, err := c.Purge(ctx, now.Add(2*time.Hour)); err != nil || deleted != 1 {
+		t.Errorf("Purge() = %d, %v, want 1, nil", deleted, err)
+	}
+
+	if _, err := st.GetDeletedEvent(ctx, event.ID); err == nil {
+		t.Errorf("GetDeletedEvent() error = nil, want purged event to be missing")
+	}
+}
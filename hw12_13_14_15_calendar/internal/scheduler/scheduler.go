@@ -0,0 +1,138 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/app"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/logger"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/queue"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+type Storage interface {
+	GetEventsToNotify(ctx context.Context, from, to time.Time) ([]storage.Event, error)
+}
+
+// Scheduler is not safe for concurrent use.
+type Scheduler struct {
+	logger   logger.Logger
+	storage  Storage
+	producer queue.Producer
+	interval time.Duration
+
+	// published holds notifications of the window which failed to publish completely,
+	// so that its retry publishes only the rest.
+	published map[notificationKey]struct{}
+}
+
+// notificationKey identifies a notification of an event or of an occurrence of a recurring event.
+type notificationKey struct {
+	eventID string
+	start   int64
+}
+
+func New(logger logger.Logger, storage Storage, producer queue.Producer, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		logger:   logger,
+		storage:  storage,
+		producer: producer,
+		interval: interval,
+
+		published: make(map[notificationKey]struct{}),
+	}
+}
+
+func (s *Scheduler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.logger.Info("Scheduler started", slog.String("interval", s.interval.String()))
+
+	lastScan := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Scheduler stopped")
+			return nil
+		case now := <-ticker.C:
+			sent, err := s.Scan(ctx, lastScan, now)
+			if err != nil {
+				s.logger.Error("Failed to scan events", slog.String("error", err.Error()))
+				continue
+			}
+
+			s.logger.Debug("Events scanned",
+				slog.String("from", lastScan.String()),
+				slog.String("to", now.String()),
+				slog.Int("notifications", sent))
+			lastScan = now
+		}
+	}
+}
+
+// Scan publishes notifications for events whose notification time falls into [from, to).
+// If publishing fails, the window should be scanned again: notifications published by the failed scan are skipped.
+func (s *Scheduler) Scan(ctx context.Context, from, to time.Time) (int, error) {
+	events, err := s.storage.GetEventsToNotify(ctx, from, to)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get events to notify: %w", err)
+	}
+
+	notifications, err := notificationsFor(events, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, n := range notifications {
+		key := notificationKey{eventID: n.EventID, start: n.Date.UnixNano()}
+		if _, ok := s.published[key]; ok {
+			continue
+		}
+
+		body, err := json.Marshal(n)
+		if err != nil {
+			return sent, fmt.Errorf("failed to marshal notification: %w", err)
+		}
+
+		if err := s.producer.Publish(ctx, body); err != nil {
+			return sent, fmt.Errorf("failed to publish notification: %w", err)
+		}
+
+		s.published[key] = struct{}{}
+		sent++
+	}
+
+	clear(s.published)
+
+	return sent, nil
+}
+
+// notificationsFor builds notifications for events and occurrences of recurring events
+// whose notification time falls into [from, to).
+func notificationsFor(events []storage.Event, from, to time.Time) ([]storage.Notification, error) {
+	notifications := make([]storage.Notification, 0, len(events))
+
+	for _, event := range events {
+		if !event.IsRecurring() {
+			notifications = append(notifications, storage.NewNotification(event))
+			continue
+		}
+
+		notifyBefore := *event.NotifyBefore
+		occurrences, err := app.ExpandOccurrences([]storage.Event{event}, from.Add(notifyBefore), to.Add(notifyBefore))
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand recurring event: %w", err)
+		}
+
+		for _, occurrence := range occurrences {
+			notifications = append(notifications, storage.NewNotification(occurrence))
+		}
+	}
+
+	return notifications, nil
+}
@@ -0,0 +1,213 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	memoryqueue "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/queue/memory"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+	memorystorage "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/memory"
+)
+
+func TestScheduler_Scan(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	st := memorystorage.NewStorage()
+	q := memoryqueue.New(10)
+	s := New(slog.New(slog.NewTextHandler(io.Discard, nil)), st, q, time.Minute)
+
+	base := time.Date(2025, time.May, 10, 12, 0, 0, 0, time.UTC)
+	notifyBefore := 30 * time.Minute
+
+	created, err := st.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+		Title:        "Meeting",
+		StartTime:    base.Add(time.Hour),
+		EndTime:      base.Add(2 * time.Hour),
+		OwnerID:      "123e4567-e89b-12d3-a456-426614174000",
+		NotifyBefore: &notifyBefore,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sent, err := s.Scan(ctx, base, base.Add(30*time.Minute))
+	if err != nil {
+		t.Fatalf("Scan() error = %v, want nil", err)
+	}
+	if sent != 0 {
+		t.Errorf("Scan() sent = %d, want 0", sent)
+	}
+
+	sent, err = s.Scan(ctx, base.Add(30*time.Minute), base.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Scan() error = %v, want nil", err)
+	}
+	if sent != 1 {
+		t.Fatalf("Scan() sent = %d, want 1", sent)
+	}
+
+	deliveries, err := q.Consume(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var n storage.Notification
+	if err := json.Unmarshal((<-deliveries).Body(), &n); err != nil {
+		t.Fatal(err)
+	}
+
+	want := storage.Notification{
+		EventID: created.ID,
+		Title:   "Meeting",
+		Date:    base.Add(time.Hour),
+		OwnerID: "123e4567-e89b-12d3-a456-426614174000",
+	}
+	if n != want {
+		t.Errorf("Notification = %+v, want %+v", n, want)
+	}
+}
+
+func TestScheduler_ScanClosedQueue(t *testing.T) {
+	ctx := context.Background()
+	st := memorystorage.NewStorage()
+	q := memoryqueue.New(1)
+	s := New(slog.New(slog.NewTextHandler(io.Discard, nil)), st, q, time.Minute)
+
+	base := time.Now()
+	notifyBefore := time.Minute
+	_, err := st.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+		Title:        "Meeting",
+		StartTime:    base.Add(time.Minute),
+		EndTime:      base.Add(time.Hour),
+		NotifyBefore: &notifyBefore,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q.Close()
+
+	if _, err := s.Scan(ctx, base.Add(-time.Minute), base.Add(time.Minute)); err == nil {
+		t.Error("Scan() error = nil, want error for closed queue")
+	}
+}
+
+func TestScheduler_ScanRecurringEvent(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	st := memorystorage.NewStorage()
+	q := memoryqueue.New(10)
+	s := New(slog.New(slog.NewTextHandler(io.Discard, nil)), st, q, time.Minute)
+
+	start := time.Date(2025, time.May, 5, 10, 0, 0, 0, time.UTC)
+	notifyBefore := 15 * time.Minute
+	rule := "FREQ=DAILY"
+
+	_, err := st.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+		Title:          "Standup",
+		StartTime:      start,
+		EndTime:        start.Add(15 * time.Minute),
+		NotifyBefore:   &notifyBefore,
+		RecurrenceRule: &rule,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from := start.AddDate(0, 0, 3).Add(-20 * time.Minute)
+	sent, err := s.Scan(ctx, from, from.Add(10*time.Minute))
+	if err != nil {
+		t.Fatalf("Scan() error = %v, want nil", err)
+	}
+	if sent != 1 {
+		t.Fatalf("Scan() sent = %d, want 1", sent)
+	}
+
+	deliveries, err := q.Consume(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var n storage.Notification
+	if err := json.Unmarshal((<-deliveries).Body(), &n); err != nil {
+		t.Fatal(err)
+	}
+	if !n.Date.Equal(start.AddDate(0, 0, 3)) {
+		t.Errorf("Notification date = %v, want %v", n.Date, start.AddDate(0, 0, 3))
+	}
+}
+
+// flakyProducer fails the publish with the given number once and records published messages.
+type flakyProducer struct {
+	failAt    int
+	calls     int
+	published [][]byte
+}
+
+func (p *flakyProducer) Publish(_ context.Context, body []byte) error {
+	p.calls++
+	if p.calls == p.failAt {
+		return errors.New("broker is unavailable")
+	}
+
+	p.published = append(p.published, body)
+	return nil
+}
+
+func TestScheduler_ScanRetryPublishesOnlyUnsent(t *testing.T) {
+	ctx := context.Background()
+	st := memorystorage.NewStorage()
+	p := &flakyProducer{failAt: 2}
+	s := New(slog.New(slog.NewTextHandler(io.Discard, nil)), st, p, time.Minute)
+
+	base := time.Date(2025, time.May, 10, 12, 0, 0, 0, time.UTC)
+	notifyBefore := 30 * time.Minute
+	for _, title := range []string{"First", "Second", "Third"} {
+		if _, err := st.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+			Title:        title,
+			StartTime:    base.Add(time.Hour),
+			EndTime:      base.Add(2 * time.Hour),
+			NotifyBefore: &notifyBefore,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	from, to := base.Add(30*time.Minute), base.Add(time.Hour)
+	sent, err := s.Scan(ctx, from, to)
+	if err == nil {
+		t.Fatal("Scan() error = nil, want publish error")
+	}
+	if sent != 1 {
+		t.Errorf("Scan() sent = %d, want 1", sent)
+	}
+
+	sent, err = s.Scan(ctx, from, to)
+	if err != nil {
+		t.Fatalf("Scan() retry error = %v, want nil", err)
+	}
+	if sent != 2 {
+		t.Errorf("Scan() retry sent = %d, want 2", sent)
+	}
+
+	titles := make(map[string]int)
+	for _, body := range p.published {
+		var n storage.Notification
+		if err := json.Unmarshal(body, &n); err != nil {
+			t.Fatal(err)
+		}
+		titles[n.Title]++
+	}
+	for _, title := range []string{"First", "Second", "Third"} {
+		if titles[title] != 1 {
+			t.Errorf("notifications of %s = %d, want 1", title, titles[title])
+		}
+	}
+}
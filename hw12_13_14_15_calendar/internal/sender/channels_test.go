@@ -0,0 +1,89 @@
+package sender
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+func makeNotification() storage.Notification {
+	return storage.Notification{
+		EventID: "event-1",
+		Title:   "Meeting",
+		Date:    time.Date(2025, time.May, 10, 12, 0, 0, 0, time.UTC),
+		OwnerID: "owner-1",
+	}
+}
+
+func TestFileChannel_Send(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.log")
+	ch := NewFileChannel(path)
+	n := makeNotification()
+
+	for i := 0; i < 2; i++ {
+		if err := ch.Send(context.Background(), n); err != nil {
+			t.Fatalf("Send() error = %v, want nil", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("lines = %d, want 2", len(lines))
+	}
+
+	var got storage.Notification
+	if err := json.Unmarshal([]byte(lines[0]), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got != n {
+		t.Errorf("notification = %+v, want %+v", got, n)
+	}
+}
+
+func TestWebhookChannel_Send(t *testing.T) {
+	var got storage.Notification
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	n := makeNotification()
+	if err := NewWebhookChannel(srv.URL, time.Second).Send(context.Background(), n); err != nil {
+		t.Fatalf("Send() error = %v, want nil", err)
+	}
+	if got != n {
+		t.Errorf("notification = %+v, want %+v", got, n)
+	}
+}
+
+func TestWebhookChannel_SendError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	if err := NewWebhookChannel(srv.URL, time.Second).Send(context.Background(), makeNotification()); err == nil {
+		t.Error("Send() error = nil, want error")
+	}
+}
@@ -0,0 +1,232 @@
+package sender
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/logger"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/queue"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+// publishTimeout bounds publishing of partially delivered notifications, it is done on shutdown
+// when the context of Run is already canceled.
+const publishTimeout = 5 * time.Second
+
+// Queue is consumed for notifications. Notifications delivered through some of the channels only
+// are published back to it, addressed to the failed channels.
+type Queue interface {
+	queue.Consumer
+	queue.Producer
+}
+
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, n storage.Notification) error
+}
+
+type RetryPolicy struct {
+	Attempts       int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+type Stats struct {
+	Delivered int64
+	Failed    int64
+}
+
+// message is a notification addressed to the named channels, or to all of them if Channels is empty.
+type message struct {
+	storage.Notification
+	Channels []string `json:"channels,omitempty"`
+}
+
+type Sender struct {
+	logger   logger.Logger
+	queue    Queue
+	channels []Channel
+	retry    RetryPolicy
+
+	delivered atomic.Int64
+	failed    atomic.Int64
+}
+
+func New(logger logger.Logger, q Queue, retry RetryPolicy, channels ...Channel) *Sender {
+	if retry.Attempts < 1 {
+		retry.Attempts = 1
+	}
+
+	return &Sender{
+		logger:   logger,
+		queue:    q,
+		channels: channels,
+		retry:    retry,
+	}
+}
+
+func (s *Sender) Run(ctx context.Context) error {
+	deliveries, err := s.queue.Consume(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start consuming: %w", err)
+	}
+
+	s.logger.Info("Sender started", slog.Int("channels", len(s.channels)))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case d, ok := <-deliveries:
+			if !ok {
+				return nil
+			}
+			s.handle(ctx, d)
+		}
+	}
+}
+
+func (s *Sender) Stats() Stats {
+	return Stats{
+		Delivered: s.delivered.Load(),
+		Failed:    s.failed.Load(),
+	}
+}
+
+func (s *Sender) handle(ctx context.Context, d queue.Delivery) {
+	var msg message
+	if err := json.Unmarshal(d.Body(), &msg); err != nil {
+		s.failed.Add(1)
+		s.logger.Error("Failed to decode notification", slog.String("error", err.Error()))
+		s.nack(d, false)
+		return
+	}
+	n := msg.Notification
+
+	channels := s.channelsFor(msg)
+
+	var (
+		sendErr error
+		failed  []string
+	)
+	for _, ch := range channels {
+		if err := s.sendWithRetry(ctx, ch, n); err != nil {
+			sendErr = errors.Join(sendErr, fmt.Errorf("%s: %w", ch.Name(), err))
+			failed = append(failed, ch.Name())
+		}
+	}
+
+	if sendErr != nil {
+		s.failed.Add(1)
+		s.logger.Error("Failed to deliver notification",
+			slog.String("event_id", n.EventID),
+			slog.String("error", sendErr.Error()))
+
+		// Retry if we were interrupted by shutdown, so the notification is not lost.
+		switch {
+		case ctx.Err() == nil:
+			s.nack(d, false)
+		case len(failed) == len(channels):
+			s.nack(d, true)
+		default:
+			s.retryFailed(d, msg, failed)
+		}
+		return
+	}
+
+	if err := d.Ack(); err != nil {
+		s.logger.Error("Failed to ack notification", slog.String("error", err.Error()))
+		return
+	}
+
+	s.delivered.Add(1)
+	s.logger.Debug("Notification delivered", slog.String("event_id", n.EventID))
+}
+
+// channelsFor returns the channels the message is addressed to.
+func (s *Sender) channelsFor(msg message) []Channel {
+	if len(msg.Channels) == 0 {
+		return s.channels
+	}
+
+	channels := make([]Channel, 0, len(msg.Channels))
+	for _, ch := range s.channels {
+		if slices.Contains(msg.Channels, ch.Name()) {
+			channels = append(channels, ch)
+		}
+	}
+
+	return channels
+}
+
+// retryFailed publishes the message again addressed to the failed channels only, so that the channels
+// which delivered it do not send it twice. The whole message is requeued if it cannot be published.
+func (s *Sender) retryFailed(d queue.Delivery, msg message, failed []string) {
+	msg.Channels = failed
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		s.logger.Error("Failed to encode notification", slog.String("error", err.Error()))
+		s.nack(d, true)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	if err := s.queue.Publish(ctx, body); err != nil {
+		s.logger.Error("Failed to publish notification for failed channels", slog.String("error", err.Error()))
+		s.nack(d, true)
+		return
+	}
+
+	if err := d.Ack(); err != nil {
+		s.logger.Error("Failed to ack notification", slog.String("error", err.Error()))
+	}
+}
+
+func (s *Sender) sendWithRetry(ctx context.Context, ch Channel, n storage.Notification) error {
+	backoff := s.retry.InitialBackoff
+
+	var err error
+	for attempt := 1; attempt <= s.retry.Attempts; attempt++ {
+		if err = ch.Send(ctx, n); err == nil {
+			return nil
+		}
+
+		if attempt == s.retry.Attempts {
+			break
+		}
+
+		s.logger.Warn("Notification delivery failed, retrying",
+			slog.String("channel", ch.Name()),
+			slog.Int("attempt", attempt),
+			slog.String("backoff", backoff.String()),
+			slog.String("error", err.Error()))
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if s.retry.MaxBackoff > 0 && backoff > s.retry.MaxBackoff {
+			backoff = s.retry.MaxBackoff
+		}
+	}
+
+	return err
+}
+
+func (s *Sender) nack(d queue.Delivery, requeue bool) {
+	if err := d.Nack(requeue); err != nil {
+		s.logger.Error("Failed to nack notification", slog.String("error", err.Error()))
+	}
+}
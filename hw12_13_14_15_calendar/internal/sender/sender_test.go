@@ -0,0 +1,181 @@
+package sender
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	memoryqueue "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/queue/memory"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/queue/queuetest"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+type fakeChannel struct {
+	name     string
+	mu       sync.Mutex
+	failures int
+	calls    int
+	sent     []storage.Notification
+}
+
+func (c *fakeChannel) Name() string {
+	return c.name
+}
+
+func (c *fakeChannel) Send(_ context.Context, n storage.Notification) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls++
+	if c.calls <= c.failures {
+		return errors.New("temporary failure")
+	}
+
+	c.sent = append(c.sent, n)
+	return nil
+}
+
+func (c *fakeChannel) Calls() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.calls
+}
+
+func publish(t *testing.T, q *memoryqueue.Queue, body []byte) {
+	t.Helper()
+
+	if err := q.Publish(context.Background(), body); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func runSender(t *testing.T, s *Sender, done func() bool) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	finished := make(chan error)
+	go func() {
+		finished <- s.Run(ctx)
+	}()
+
+	deadline := time.After(2 * time.Second)
+	for !done() {
+		select {
+		case err := <-finished:
+			t.Fatalf("Run() finished early: %v", err)
+		case <-deadline:
+			t.Fatal("timeout waiting for sender")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	if err := <-finished; err != nil {
+		t.Errorf("Run() error = %v, want nil", err)
+	}
+}
+
+func TestSender_Deliver(t *testing.T) {
+	q := memoryqueue.New(10)
+	defer q.Close()
+
+	ch := &fakeChannel{name: "fake", failures: 2}
+	s := New(slog.New(slog.NewTextHandler(io.Discard, nil)), q, RetryPolicy{
+		Attempts:       3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     2 * time.Millisecond,
+	}, ch)
+
+	n := storage.Notification{
+		EventID: "event-1",
+		Title:   "Meeting",
+		Date:    time.Date(2025, time.May, 10, 12, 0, 0, 0, time.UTC),
+		OwnerID: "owner-1",
+	}
+	body, err := json.Marshal(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publish(t, q, body)
+
+	runSender(t, s, func() bool { return s.Stats().Delivered == 1 })
+
+	if ch.Calls() != 3 {
+		t.Errorf("Send() calls = %d, want 3", ch.Calls())
+	}
+	if len(ch.sent) != 1 || ch.sent[0] != n {
+		t.Errorf("sent = %+v, want [%+v]", ch.sent, n)
+	}
+	if s.Stats().Failed != 0 {
+		t.Errorf("Stats().Failed = %d, want 0", s.Stats().Failed)
+	}
+}
+
+func TestSender_Failed(t *testing.T) {
+	q := memoryqueue.New(10)
+	defer q.Close()
+
+	ch := &fakeChannel{name: "fake", failures: 100}
+	s := New(slog.New(slog.NewTextHandler(io.Discard, nil)), q, RetryPolicy{
+		Attempts:       2,
+		InitialBackoff: time.Millisecond,
+	}, ch)
+
+	publish(t, q, []byte(`{"eventId":"event-1"}`))
+	publish(t, q, []byte(`not a json`))
+
+	runSender(t, s, func() bool { return s.Stats().Failed == 2 })
+
+	if ch.Calls() != 2 {
+		t.Errorf("Send() calls = %d, want 2", ch.Calls())
+	}
+	if s.Stats().Delivered != 0 {
+		t.Errorf("Stats().Delivered = %d, want 0", s.Stats().Delivered)
+	}
+}
+
+func TestSender_RetryFailedChannels(t *testing.T) {
+	q := memoryqueue.New(10)
+	defer q.Close()
+
+	delivered := &fakeChannel{name: "log"}
+	failing := &fakeChannel{name: "webhook", failures: 1}
+	s := New(slog.New(slog.NewTextHandler(io.Discard, nil)), q, RetryPolicy{Attempts: 1}, delivered, failing)
+
+	n := storage.Notification{EventID: "event-1", Title: "Meeting", OwnerID: "owner-1"}
+	body, err := json.Marshal(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publish(t, q, body)
+
+	// Shutdown interrupts the delivery after the log channel delivered the notification.
+	consumeCtx, stopConsuming := context.WithCancel(context.Background())
+	deliveries, err := q.Consume(consumeCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := queuetest.Receive(t, deliveries)
+	stopConsuming()
+
+	shutdownCtx, shutdown := context.WithCancel(context.Background())
+	shutdown()
+	s.handle(shutdownCtx, d)
+
+	runSender(t, s, func() bool { return s.Stats().Delivered == 1 })
+
+	if delivered.Calls() != 1 {
+		t.Errorf("log channel Send() calls = %d, want 1", delivered.Calls())
+	}
+	if failing.Calls() != 2 || len(failing.sent) != 1 || failing.sent[0] != n {
+		t.Errorf("webhook channel Send() calls = %d, sent = %+v, want 2, [%+v]", failing.Calls(), failing.sent, n)
+	}
+}
@@ -0,0 +1,78 @@
+package internalgrpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/helpers"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/identity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// eventsServicePrefix limits identity checks to the calendar service, leaving reflection open.
+const eventsServicePrefix = "/event.Events/"
+
+func IdentityInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if !strings.HasPrefix(info.FullMethod, eventsServicePrefix) {
+		return handler(ctx, req)
+	}
+
+	ctx, err := identityContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func StreamIdentityInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if !strings.HasPrefix(info.FullMethod, eventsServicePrefix) {
+		return handler(srv, ss)
+	}
+
+	ctx, err := identityContext(ss.Context())
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+}
+
+// identityContext puts the calling user from the x-user-id metadata into the context.
+func identityContext(ctx context.Context) (context.Context, error) {
+	var userID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(identity.MetadataKey); len(values) > 0 {
+			userID = values[0]
+		}
+	}
+
+	if !helpers.IsValidUUID(userID) {
+		return nil, status.Error(codes.Unauthenticated, identity.MetadataKey+" metadata is required and must be uuid")
+	}
+
+	return identity.NewContext(ctx, userID), nil
+}
+
+// contextStream replaces the context of the stream, e.g. with one carrying the caller.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/metrics"
 	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/middleware"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
@@ -11,13 +12,43 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-func LoggingInterceptor(
-	ctx context.Context,
-	req interface{},
-	info *grpc.UnaryServerInfo,
-	handler grpc.UnaryHandler,
-) (interface{}, error) {
-	start := time.Now()
+// LoggingInterceptor writes the access log and records call metrics, m may be nil.
+func LoggingInterceptor(m *metrics.Metrics) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+		logCall(ctx, m, info.FullMethod, time.Since(start), err)
+
+		return resp, err
+	}
+}
+
+// StreamLoggingInterceptor writes the access log and records call metrics, m may be nil.
+func StreamLoggingInterceptor(m *metrics.Metrics) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+		logCall(ss.Context(), m, info.FullMethod, time.Since(start), err)
+
+		return err
+	}
+}
+
+func logCall(ctx context.Context, m *metrics.Metrics, method string, latency time.Duration, err error) {
+	code := status.Code(err)
+	m.ObserveGRPCCall(method, code.String(), latency)
 
 	p, _ := peer.FromContext(ctx)
 	ip := middleware.ExtractIP(p.Addr.String())
@@ -29,19 +60,15 @@ func LoggingInterceptor(
 		}
 	}
 
-	resp, err := handler(ctx, req)
-
 	logLine := middleware.CommonLogFormat(
 		ip,
 		"gRPC",
-		info.FullMethod,
+		method,
 		"HTTP/2",
-		int(status.Code(err)),
-		time.Since(start),
+		int(code),
+		latency,
 		userAgent,
 	)
 
 	middleware.WriteLogToFile(logLine)
-
-	return resp, err
 }
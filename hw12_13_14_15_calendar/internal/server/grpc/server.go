@@ -3,27 +3,64 @@ package internalgrpc
 import (
 	"fmt"
 	"net"
+	"time"
 
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/health"
 	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/logger"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/metrics"
 	pb "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/pb/event"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
 type Server struct {
 	grpcServer *grpc.Server
 	logger     logger.Logger
+	metrics    *metrics.Metrics
+	health     *health.GRPCServer
 }
 
-func NewServer(logger logger.Logger, eventHandler pb.EventsServer) *Server {
+type Option func(*Server)
+
+// WithMetrics records call metrics.
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(s *Server) {
+		s.metrics = m
+	}
+}
+
+// WithHealth serves the grpc.health.v1 service, watched statuses are checked every interval.
+func WithHealth(h *health.Health, interval time.Duration) Option {
+	return func(s *Server) {
+		s.health = health.NewGRPCServer(h, interval, pb.Events_ServiceDesc.ServiceName)
+	}
+}
+
+func NewServer(logger logger.Logger, eventHandler pb.EventsServer, opts ...Option) *Server {
+	server := &Server{logger: logger}
+	for _, opt := range opts {
+		opt(server)
+	}
+
 	s := grpc.NewServer(
-		grpc.UnaryInterceptor(LoggingInterceptor),
+		grpc.ChainUnaryInterceptor(TracingInterceptor, LoggingInterceptor(server.metrics), IdentityInterceptor),
+		grpc.ChainStreamInterceptor(
+			StreamTracingInterceptor,
+			StreamLoggingInterceptor(server.metrics),
+			StreamIdentityInterceptor,
+		),
 	)
 	pb.RegisterEventsServer(s, eventHandler)
+	if server.health != nil {
+		healthpb.RegisterHealthServer(s, server.health)
+	}
 
 	reflection.Register(s)
 
-	return &Server{logger: logger, grpcServer: s}
+	server.grpcServer = s
+
+	return server
 }
 
 func (s *Server) Run(addr string) error {
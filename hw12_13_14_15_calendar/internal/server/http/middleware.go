@@ -2,9 +2,20 @@ package internalhttp
 
 import (
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/handlers/http"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/helpers"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/identity"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/metrics"
 	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/middleware"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type loggingResponseWriter struct {
@@ -17,7 +28,9 @@ func (lrw *loggingResponseWriter) WriteHeader(code int) {
 	lrw.ResponseWriter.WriteHeader(code)
 }
 
-func loggingMiddleware(next http.Handler) http.Handler {
+// loggingMiddleware writes the access log and records request metrics. The route is the pattern
+// the mux matched, which it sets on the request passed to it.
+func loggingMiddleware(next http.Handler, m *metrics.Metrics) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 
@@ -27,6 +40,9 @@ func loggingMiddleware(next http.Handler) http.Handler {
 		}
 
 		next.ServeHTTP(lrw, r)
+		latency := time.Since(start)
+
+		m.ObserveHTTPRequest(r.Method, r.Pattern, lrw.statusCode, latency)
 
 		logLine := middleware.CommonLogFormat(
 			middleware.ExtractIP(r.RemoteAddr),
@@ -34,10 +50,59 @@ func loggingMiddleware(next http.Handler) http.Handler {
 			r.URL.Path,
 			r.Proto,
 			lrw.statusCode,
-			time.Since(start),
+			latency,
 			r.UserAgent(),
 		)
 
 		middleware.WriteLogToFile(logLine)
 	})
 }
+
+// tracingMiddleware continues the trace of the caller from the W3C traceparent header, or starts a new one.
+// It has to wrap the mux directly or through middlewares passing the request on, so that the matched
+// pattern set by the mux is visible here.
+func tracingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracing.Tracer().Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.URLPath(r.URL.Path),
+				semconv.UserAgentOriginal(r.UserAgent()),
+			))
+		defer span.End()
+
+		lrw := &loggingResponseWriter{
+			ResponseWriter: w,
+			statusCode:     http.StatusOK,
+		}
+
+		r = r.WithContext(ctx)
+		next.ServeHTTP(lrw, r)
+
+		if _, route, ok := strings.Cut(r.Pattern, " "); ok {
+			span.SetName(r.Method + " " + route)
+			span.SetAttributes(semconv.HTTPRoute(route))
+		}
+
+		span.SetAttributes(semconv.HTTPResponseStatusCode(lrw.statusCode))
+		if lrw.statusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(lrw.statusCode))
+		}
+	})
+}
+
+// identityMiddleware puts the calling user from the X-User-ID header into the request context.
+func identityMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID := r.Header.Get(identity.HeaderName)
+		if !helpers.IsValidUUID(userID) {
+			httphandler.RespondWithJSON(w, http.StatusUnauthorized,
+				httphandler.Error(identity.HeaderName+" header is required and must be uuid"))
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(identity.NewContext(r.Context(), userID)))
+	})
+}
@@ -10,42 +10,95 @@ import (
 
 	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/app"
 	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/handlers/http"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/health"
 	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/logger"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/metrics"
 )
 
 type Server struct {
-	logger logger.Logger
-	app    app.Application
-	server *http.Server
+	logger      logger.Logger
+	app         app.Application
+	server      *http.Server
+	metrics     *metrics.Metrics
+	metricsPath string
+	health      *health.Health
 }
 
-func NewServer(logger logger.Logger, app app.Application, addr string) *Server {
+type Option func(*Server)
+
+// WithMetrics records request metrics and serves all metrics at the path.
+func WithMetrics(m *metrics.Metrics, path string) Option {
+	return func(s *Server) {
+		s.metrics = m
+		s.metricsPath = path
+	}
+}
+
+// WithHealth serves liveness at /livez and readiness at /readyz.
+func WithHealth(h *health.Health) Option {
+	return func(s *Server) {
+		s.health = h
+	}
+}
+
+func NewServer(logger logger.Logger, app app.Application, addr string, opts ...Option) *Server {
+	s := &Server{
+		logger: logger,
+		app:    app,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
 	mux := http.NewServeMux()
+	// API routes are registered on the same mux, so that the matched pattern is visible to loggingMiddleware.
+	api := func(pattern string, handler http.HandlerFunc) {
+		mux.Handle(pattern, identityMiddleware(handler))
+	}
 
 	eventH := httphandler.NewEventHandler(app)
 
-	mux.HandleFunc("POST /api/events", eventH.Create)
-	mux.HandleFunc("PUT /api/events/{id}", eventH.Update)
-	mux.HandleFunc("DELETE /api/events/{id}", eventH.Delete)
-	mux.HandleFunc("GET /api/events/{id}", eventH.Get)
-	mux.HandleFunc("GET /api/events", eventH.GetAll)
-	mux.HandleFunc("GET /api/events/day", eventH.GetDayEvents)
-	mux.HandleFunc("GET /api/events/week", eventH.GetWeekEvents)
-	mux.HandleFunc("GET /api/events/month", eventH.GetMonthEvents)
+	api("POST /api/events", eventH.Create)
+	api("PUT /api/events/{id}", eventH.Update)
+	api("DELETE /api/events/{id}", eventH.Delete)
+	api("GET /api/events/{id}", eventH.Get)
+	api("POST /api/events/{id}/restore", eventH.Restore)
+	api("GET /api/events/trash", eventH.ListTrash)
+	api("POST /api/events/{id}/attendees", eventH.InviteAttendee)
+	api("PUT /api/events/{id}/attendees/{userId}", eventH.RespondToEvent)
+	api("DELETE /api/events/{id}/attendees/{userId}", eventH.RemoveAttendee)
+	api("GET /api/events", eventH.List)
+	api("GET /api/events/day", eventH.GetDayEvents)
+	api("GET /api/events/week", eventH.GetWeekEvents)
+	api("GET /api/events/month", eventH.GetMonthEvents)
+	api("GET /api/events/export.ics", eventH.ExportICal)
+	api("POST /api/events/import", eventH.ImportICal)
+	api("POST /api/freebusy", eventH.FreeBusy)
+	api("POST /api/slots", eventH.FindSlots)
+	api("GET /api/settings/timezone", eventH.GetTimeZone)
+	api("PUT /api/settings/timezone", eventH.SetTimeZone)
 
-	m := loggingMiddleware(mux)
+	// Metrics and probes are served past tracingMiddleware, so that they do not produce traces.
+	root := http.NewServeMux()
+	root.Handle("/", tracingMiddleware(loggingMiddleware(mux, s.metrics)))
+	if s.metrics != nil {
+		root.Handle("GET "+s.metricsPath, loggingMiddleware(s.metrics.Handler(), s.metrics))
+	}
+	if s.health != nil {
+		root.Handle("GET /livez", s.health.LivenessHandler())
+		root.Handle("GET /readyz", s.health.ReadinessHandler())
+	}
 
-	return &Server{
-		logger: logger,
-		app:    app,
-		server: &http.Server{
-			Addr:         addr,
-			Handler:      m,
-			ReadTimeout:  10 * time.Second,
-			WriteTimeout: 10 * time.Second,
-			IdleTimeout:  60 * time.Second,
-		},
+	s.server = &http.Server{
+		Addr:         addr,
+		Handler:      root,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+		IdleTimeout:  60 * time.Second,
 	}
+
+	return s
 }
 
 func (s *Server) Start() error {
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrAttendeeNotFound      = errors.New("attendee not found")
+	ErrInvalidAttendeeStatus = errors.New("invalid attendee status")
+	ErrOwnerAttendee         = errors.New("event owner can not be an attendee")
+)
+
+// AttendeeStatus is the participation status of an attendee, as PARTSTAT in RFC 5545.
+type AttendeeStatus string
+
+const (
+	AttendeeNeedsAction AttendeeStatus = "needs-action"
+	AttendeeAccepted    AttendeeStatus = "accepted"
+	AttendeeDeclined    AttendeeStatus = "declined"
+	AttendeeTentative   AttendeeStatus = "tentative"
+)
+
+type Attendee struct {
+	UserID string         `db:"user_id"`
+	Status AttendeeStatus `db:"status"`
+}
+
+func (s AttendeeStatus) Validate() error {
+	switch s {
+	case AttendeeNeedsAction, AttendeeAccepted, AttendeeDeclined, AttendeeTentative:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidAttendeeStatus, s)
+	}
+}
+
+// Attendee returns the attendee of the event with the user ID.
+func (e Event) Attendee(userID string) (Attendee, bool) {
+	for _, a := range e.Attendees {
+		if a.UserID == userID {
+			return a, true
+		}
+	}
+
+	return Attendee{}, false
+}
+
+// Attends reports whether the user is invited to the event and has not declined it.
+func (e Event) Attends(userID string) bool {
+	a, ok := e.Attendee(userID)
+	return ok && a.Status != AttendeeDeclined
+}
@@ -2,29 +2,126 @@ package storage
 
 import (
 	"errors"
+	"sync"
 	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/recurrence"
 )
 
 var (
 	ErrEventNotFound      = errors.New("event not found")
 	ErrEventAlreadyExists = errors.New("event already exists")
+	ErrDateBusy           = errors.New("date is busy")
+	ErrInvalidInterval    = errors.New("event end time is before start time")
+	ErrForbidden          = errors.New("event belongs to another owner")
+	ErrVersionConflict    = errors.New("event was modified concurrently")
 )
 
 type Event struct {
-	ID           string         `db:"id"`
-	Title        string         `db:"title"`
-	StartTime    time.Time      `db:"start_time"`
-	EndTime      time.Time      `db:"end_time"`
-	Description  *string        `db:"description"`
-	OwnerID      string         `db:"owner_id"`
-	NotifyBefore *time.Duration `db:"notify_before"`
+	ID                   string         `db:"id"`
+	Title                string         `db:"title"`
+	StartTime            time.Time      `db:"start_time"`
+	EndTime              time.Time      `db:"end_time"`
+	Description          *string        `db:"description"`
+	OwnerID              string         `db:"owner_id"`
+	NotifyBefore         *time.Duration `db:"notify_before"`
+	RecurrenceRule       *string        `db:"rrule"`
+	RecurrenceExceptions []time.Time    `db:"exdates"`
+	// Version is incremented on every update. When passed to UpdateEvent,
+	// a non-zero Version is the version the event is expected to have.
+	Version int64 `db:"version"`
+	// DeletedAt is set when the event is moved to the trash.
+	DeletedAt *time.Time `db:"deleted_at"`
+	// Attendees are stored separately from the event and are not changed by UpdateEvent.
+	Attendees []Attendee `db:"-"`
+	// AllDay events last whole dates. StartTime and EndTime are UTC midnights of the first date
+	// and of the date after the last one, see Localize.
+	AllDay bool `db:"all_day"`
+	// TimeZone is the IANA time zone recurring events are expanded in, so that their occurrences keep
+	// the wall clock time across DST changes. All-day events and events without one recur in UTC.
+	TimeZone string `db:"time_zone"`
 }
 
 type CreateOrUpdateEventParams struct {
-	Title        string
-	StartTime    time.Time
-	EndTime      time.Time
-	Description  *string
-	OwnerID      string
-	NotifyBefore *time.Duration
+	Title                string
+	StartTime            time.Time
+	EndTime              time.Time
+	Description          *string
+	OwnerID              string
+	NotifyBefore         *time.Duration
+	RecurrenceRule       *string
+	RecurrenceExceptions []time.Time
+	AllDay               bool
+	TimeZone             string
+}
+
+func (e Event) IsDeleted() bool {
+	return e.DeletedAt != nil
+}
+
+func (e Event) IsRecurring() bool {
+	return e.RecurrenceRule != nil
+}
+
+// SeriesEnd returns the end of the last occurrence of the event, or nil if the event recurs endlessly.
+func (e Event) SeriesEnd() (*time.Time, error) {
+	if !e.IsRecurring() {
+		end := e.EndTime
+		return &end, nil
+	}
+
+	return recurrence.SeriesEnd(*e.RecurrenceRule, e.StartTime.In(e.Location()), e.EndTime, e.RecurrenceExceptions)
+}
+
+// Location returns the time zone the event recurs in, see TimeZone.
+func (e Event) Location() *time.Location {
+	return SeriesLocation(e.TimeZone, e.AllDay)
+}
+
+var locations sync.Map
+
+// SeriesLocation returns the time zone a series with the TimeZone recurs in, UTC if it is empty or unknown.
+func SeriesLocation(timeZone string, allDay bool) *time.Location {
+	if allDay || timeZone == "" {
+		return time.UTC
+	}
+
+	if loc, ok := locations.Load(timeZone); ok {
+		return loc.(*time.Location)
+	}
+
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return time.UTC
+	}
+	locations.Store(timeZone, loc)
+
+	return loc
+}
+
+// Localize renders the event in the location. Timed events keep their instants, while dates of all-day events
+// do not depend on a time zone, so they are moved to midnights of the same dates in the location.
+func (e Event) Localize(loc *time.Location) Event {
+	if !e.AllDay {
+		e.StartTime = e.StartTime.In(loc)
+		e.EndTime = e.EndTime.In(loc)
+		return e
+	}
+
+	e.StartTime = floatingDate(e.StartTime, loc)
+	e.EndTime = floatingDate(e.EndTime, loc)
+	if len(e.RecurrenceExceptions) > 0 {
+		exceptions := make([]time.Time, len(e.RecurrenceExceptions))
+		for i, ex := range e.RecurrenceExceptions {
+			exceptions[i] = floatingDate(ex, loc)
+		}
+		e.RecurrenceExceptions = exceptions
+	}
+
+	return e
+}
+
+func floatingDate(t time.Time, loc *time.Location) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
 }
@@ -0,0 +1,136 @@
+package storage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidQuery  = errors.New("invalid list query")
+	ErrInvalidCursor = errors.New("invalid page cursor")
+)
+
+type SortField string
+
+const (
+	SortByStartTime SortField = "start_time"
+	SortByTitle     SortField = "title"
+)
+
+// ListEventsQuery selects a page of stored events. Recurring events are not expanded.
+type ListEventsQuery struct {
+	// OwnerID limits events to the owner, events of all owners are listed if it is empty.
+	OwnerID string
+	// From and To limit events to ones starting in [From, To).
+	From *time.Time
+	To   *time.Time
+	// Title limits events to ones whose title contains it, ignoring case.
+	Title      string
+	SortBy     SortField
+	Descending bool
+	PageSize   int
+	// Cursor is the NextCursor of the previous page.
+	Cursor string
+}
+
+type EventPage struct {
+	Events []Event
+	// NextCursor is empty on the last page.
+	NextCursor string
+}
+
+// Cursor is a decoded position in a listing: the sort key and the ID of the last event of a page.
+type Cursor struct {
+	SortBy     SortField  `json:"s"`
+	Descending bool       `json:"d,omitempty"`
+	StartTime  *time.Time `json:"t,omitempty"`
+	Title      *string    `json:"n,omitempty"`
+	ID         string     `json:"i"`
+}
+
+func (q ListEventsQuery) Validate() error {
+	switch q.SortBy {
+	case SortByStartTime, SortByTitle:
+	default:
+		return fmt.Errorf("%w: unknown sort field %q", ErrInvalidQuery, q.SortBy)
+	}
+
+	if q.PageSize <= 0 {
+		return fmt.Errorf("%w: page size must be positive", ErrInvalidQuery)
+	}
+
+	if q.From != nil && q.To != nil && !q.From.Before(*q.To) {
+		return fmt.Errorf("%w: from must be before to", ErrInvalidQuery)
+	}
+
+	return nil
+}
+
+// Matches reports whether the event passes the filters of the query.
+func (q ListEventsQuery) Matches(event Event) bool {
+	if q.OwnerID != "" && event.OwnerID != q.OwnerID {
+		return false
+	}
+	if q.From != nil && event.StartTime.Before(*q.From) {
+		return false
+	}
+	if q.To != nil && !event.StartTime.Before(*q.To) {
+		return false
+	}
+
+	return q.Title == "" || strings.Contains(strings.ToLower(event.Title), strings.ToLower(q.Title))
+}
+
+// NewCursor returns the cursor pointing after the event in the listing of the query.
+func (q ListEventsQuery) NewCursor(event Event) string {
+	c := Cursor{SortBy: q.SortBy, Descending: q.Descending, ID: event.ID}
+
+	switch q.SortBy {
+	case SortByTitle:
+		c.Title = &event.Title
+	case SortByStartTime:
+		startTime := event.StartTime.UTC()
+		c.StartTime = &startTime
+	}
+
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor decodes the cursor of the query, nil is returned for the first page.
+// The cursor must have been created for the same sort order.
+func (q ListEventsQuery) DecodeCursor() (*Cursor, error) {
+	if q.Cursor == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(q.Cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	if _, err := uuid.Parse(c.ID); err != nil {
+		return nil, fmt.Errorf("%w: invalid event ID", ErrInvalidCursor)
+	}
+
+	if c.SortBy != q.SortBy || c.Descending != q.Descending {
+		return nil, fmt.Errorf("%w: cursor was created for another sort order", ErrInvalidCursor)
+	}
+
+	if (c.SortBy == SortByTitle && c.Title == nil) || (c.SortBy == SortByStartTime && c.StartTime == nil) {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,115 @@
+package memorystorage
+
+import (
+	"sort"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+// span is the time taken by an event from its start to the end of its last occurrence.
+type span struct {
+	id      string
+	start   time.Time
+	end     time.Time
+	endless bool
+}
+
+func eventSpan(event storage.Event) span {
+	sp := span{id: event.ID, start: event.StartTime, end: event.EndTime}
+
+	if event.IsRecurring() {
+		end, err := event.SeriesEnd()
+		if err != nil || end == nil {
+			sp.endless = true
+		} else {
+			sp.end = *end
+		}
+	}
+
+	return sp
+}
+
+// intersects reports whether the span intersects [start, end).
+// Spans without duration intersect periods they start in.
+func (sp span) intersects(start, end time.Time) bool {
+	if !sp.start.Before(end) {
+		return false
+	}
+
+	return sp.endless || sp.end.After(start) || (sp.end.Equal(sp.start) && !sp.start.Before(start))
+}
+
+// periodIndex finds events intersecting a period without scanning all events. Single events are kept
+// sorted by start, so only events starting between the period start minus the longest single event
+// and the period end are checked. Series may last for years and are kept aside.
+type periodIndex struct {
+	single    []span
+	maxSingle time.Duration
+	recurring map[string]span
+}
+
+func newPeriodIndex() *periodIndex {
+	return &periodIndex{recurring: make(map[string]span)}
+}
+
+func (x *periodIndex) add(event storage.Event) {
+	sp := eventSpan(event)
+	if event.IsRecurring() {
+		x.recurring[sp.id] = sp
+		return
+	}
+
+	// maxSingle is not decreased on removal, it only widens the scanned range.
+	if d := sp.end.Sub(sp.start); d > x.maxSingle {
+		x.maxSingle = d
+	}
+
+	i := sort.Search(len(x.single), func(i int) bool { return !spanLess(x.single[i], sp) })
+	x.single = append(x.single, span{})
+	copy(x.single[i+1:], x.single[i:])
+	x.single[i] = sp
+}
+
+func (x *periodIndex) remove(event storage.Event) {
+	if _, ok := x.recurring[event.ID]; ok {
+		delete(x.recurring, event.ID)
+		return
+	}
+
+	sp := span{id: event.ID, start: event.StartTime}
+	i := sort.Search(len(x.single), func(i int) bool { return !spanLess(x.single[i], sp) })
+	if i < len(x.single) && x.single[i].id == event.ID {
+		x.single = append(x.single[:i], x.single[i+1:]...)
+	}
+}
+
+// find returns IDs of events intersecting [start, end).
+func (x *periodIndex) find(start, end time.Time) []string {
+	from := start.Add(-x.maxSingle)
+	lo := sort.Search(len(x.single), func(i int) bool { return !x.single[i].start.Before(from) })
+	hi := sort.Search(len(x.single), func(i int) bool { return !x.single[i].start.Before(end) })
+
+	var ids []string
+	for _, sp := range x.single[lo:max(lo, hi)] {
+		if sp.intersects(start, end) {
+			ids = append(ids, sp.id)
+		}
+	}
+
+	for _, sp := range x.recurring {
+		if sp.intersects(start, end) {
+			ids = append(ids, sp.id)
+		}
+	}
+
+	return ids
+}
+
+func spanLess(a, b span) bool {
+	if !a.start.Equal(b.start) {
+		return a.start.Before(b.start)
+	}
+
+	return a.id < b.id
+}
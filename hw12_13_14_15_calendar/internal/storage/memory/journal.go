@@ -0,0 +1,282 @@
+package memorystorage
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+// Records of the log are framed as a 4-byte length, a 4-byte CRC-32 of the payload and the JSON payload.
+const (
+	recordHeaderSize = 8
+	maxRecordSize    = 64 << 20
+)
+
+const (
+	opPutEvent     = "put"
+	opRemoveEvents = "remove"
+	opSetTimeZone  = "time_zone"
+)
+
+// record is a change of the storage state. Records hold resulting values rather than operations,
+// so applying a record again gives the same state.
+type record struct {
+	Op       string         `json:"op"`
+	Event    *storage.Event `json:"event,omitempty"`
+	IDs      []string       `json:"ids,omitempty"`
+	UserID   string         `json:"userId,omitempty"`
+	TimeZone string         `json:"timeZone,omitempty"`
+}
+
+func putEventRecord(event storage.Event) record {
+	return record{Op: opPutEvent, Event: &event}
+}
+
+func removeEventsRecord(ids []string) record {
+	return record{Op: opRemoveEvents, IDs: ids}
+}
+
+func setTimeZoneRecord(userID, timeZone string) record {
+	return record{Op: opSetTimeZone, UserID: userID, TimeZone: timeZone}
+}
+
+func encodeRecord(r record) ([]byte, error) {
+	payload, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode log record: %w", err)
+	}
+
+	size := len(payload)
+	if size > maxRecordSize {
+		return nil, fmt.Errorf("log record of %d bytes is too large", size)
+	}
+
+	buf := make([]byte, recordHeaderSize+size)
+	binary.BigEndian.PutUint32(buf[0:4], uint32(size))
+	binary.BigEndian.PutUint32(buf[4:8], crc32.ChecksumIEEE(payload))
+	copy(buf[recordHeaderSize:], payload)
+
+	return buf, nil
+}
+
+// readRecords reads records until the end of the log or the first torn or corrupt record,
+// which is what a crash in the middle of an append leaves. It returns the records and the size
+// of the log prefix holding them.
+func readRecords(r io.Reader) ([]record, int64, error) {
+	br := bufio.NewReader(r)
+
+	var (
+		records []record
+		valid   int64
+		header  [recordHeaderSize]byte
+	)
+	for {
+		if _, err := io.ReadFull(br, header[:]); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return records, valid, nil
+			}
+			return nil, 0, fmt.Errorf("failed to read log: %w", err)
+		}
+
+		size := binary.BigEndian.Uint32(header[0:4])
+		if size > maxRecordSize {
+			return records, valid, nil
+		}
+
+		payload := make([]byte, size)
+		if _, err := io.ReadFull(br, payload); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return records, valid, nil
+			}
+			return nil, 0, fmt.Errorf("failed to read log: %w", err)
+		}
+
+		rec, ok := decodeRecord(binary.BigEndian.Uint32(header[4:8]), payload)
+		if !ok {
+			return records, valid, nil
+		}
+
+		records = append(records, rec)
+		valid += int64(recordHeaderSize) + int64(size)
+	}
+}
+
+func decodeRecord(checksum uint32, payload []byte) (record, bool) {
+	var r record
+	if crc32.ChecksumIEEE(payload) != checksum {
+		return r, false
+	}
+
+	if err := json.Unmarshal(payload, &r); err != nil {
+		return r, false
+	}
+
+	return r, true
+}
+
+// journal appends records to the log file.
+type journal struct {
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	policy SyncPolicy
+	dirty  bool
+	// err is set when the log could not be restored after a failed append.
+	err error
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// openJournal opens the log for appending after its first size bytes, a torn tail is cut off.
+func openJournal(path string, size int64, policy SyncPolicy, interval time.Duration) (*journal, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log: %w", err)
+	}
+
+	j := &journal{file: file, policy: policy}
+	if err := j.reset(size); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	if policy == SyncInterval {
+		j.stop = make(chan struct{})
+		j.done = make(chan struct{})
+		go j.syncEvery(interval)
+	}
+
+	return j, nil
+}
+
+// append writes the record. If it can not be written completely, the log is cut back,
+// so that later records are not appended after a torn one.
+func (j *journal) append(r record) error {
+	data, err := encodeRecord(r)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.err != nil {
+		return j.err
+	}
+
+	if _, err := j.file.Write(data); err != nil {
+		return j.rollback(fmt.Errorf("failed to write log: %w", err))
+	}
+
+	if j.policy == SyncAlways {
+		if err := j.file.Sync(); err != nil {
+			return j.rollback(fmt.Errorf("failed to sync log: %w", err))
+		}
+	} else {
+		j.dirty = true
+	}
+
+	j.size += int64(len(data))
+	return nil
+}
+
+func (j *journal) rollback(err error) error {
+	if resetErr := j.reset(j.size); resetErr != nil {
+		j.err = fmt.Errorf("log is unusable after %w: %w", err, resetErr)
+	}
+
+	return err
+}
+
+// reset cuts the log to size bytes and continues appending there.
+func (j *journal) reset(size int64) error {
+	if err := j.file.Truncate(size); err != nil {
+		return fmt.Errorf("failed to truncate log: %w", err)
+	}
+
+	if _, err := j.file.Seek(size, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to seek log: %w", err)
+	}
+
+	if err := j.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync log: %w", err)
+	}
+
+	j.size = size
+	j.dirty = false
+	return nil
+}
+
+// truncate empties the log once its records are in a snapshot.
+func (j *journal) truncate() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.err != nil {
+		return j.err
+	}
+
+	if err := j.reset(0); err != nil {
+		j.err = err
+		return err
+	}
+
+	return nil
+}
+
+func (j *journal) syncEvery(interval time.Duration) {
+	defer close(j.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-j.stop:
+			return
+		case <-ticker.C:
+			// A failed sync is retried on the next tick and reported by close.
+			_ = j.sync()
+		}
+	}
+}
+
+func (j *journal) sync() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if !j.dirty {
+		return nil
+	}
+
+	if err := j.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync log: %w", err)
+	}
+
+	j.dirty = false
+	return nil
+}
+
+func (j *journal) close() error {
+	if j.stop != nil {
+		close(j.stop)
+		<-j.done
+	}
+
+	syncErr := j.sync()
+	if err := j.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log: %w", err)
+	}
+
+	return syncErr
+}
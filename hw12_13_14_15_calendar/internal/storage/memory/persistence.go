@@ -0,0 +1,281 @@
+package memorystorage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+// SyncPolicy tells when logged changes are flushed to disk.
+type SyncPolicy string
+
+const (
+	// SyncAlways flushes every change before it is acknowledged.
+	SyncAlways SyncPolicy = "always"
+	// SyncInterval flushes changes periodically, a crash of the machine loses changes of the last interval.
+	SyncInterval SyncPolicy = "interval"
+	// SyncNever leaves flushing to the operating system.
+	SyncNever SyncPolicy = "never"
+)
+
+const (
+	logFileName      = "events.log"
+	snapshotFileName = "snapshot.json"
+)
+
+var errClosed = errors.New("storage is closed")
+
+// ParseSyncPolicy checks that s names a known policy.
+func ParseSyncPolicy(s string) (SyncPolicy, error) {
+	switch p := SyncPolicy(s); p {
+	case SyncAlways, SyncInterval, SyncNever:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown sync policy: %s", s)
+	}
+}
+
+// Persistence configures files the storage is kept in.
+type Persistence struct {
+	// Dir holds the append-only log of changes and the snapshot of the state.
+	Dir  string
+	Sync SyncPolicy
+	// SyncInterval is the flush period of SyncInterval.
+	SyncInterval time.Duration
+	// SnapshotEvery is the number of logged changes after which the state is written to the snapshot
+	// and the log is emptied. If it is not positive, the snapshot is only written on Close.
+	SnapshotEvery int
+}
+
+type snapshot struct {
+	Events    []storage.Event   `json:"events"`
+	TimeZones map[string]string `json:"timeZones"`
+}
+
+// Open loads the storage from the snapshot and the log in the directory and logs further changes there.
+// A record torn by a crash at the end of the log is dropped, all completely written changes are recovered.
+func Open(p Persistence) (*Storage, error) {
+	if _, err := ParseSyncPolicy(string(p.Sync)); err != nil {
+		return nil, err
+	}
+	if p.Sync == SyncInterval && p.SyncInterval <= 0 {
+		return nil, fmt.Errorf("sync interval must be positive, got %v", p.SyncInterval)
+	}
+
+	if err := os.MkdirAll(p.Dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	s := NewStorage()
+	if err := s.loadSnapshot(filepath.Join(p.Dir, snapshotFileName)); err != nil {
+		return nil, err
+	}
+
+	logPath := filepath.Join(p.Dir, logFileName)
+	size, err := s.replayLog(logPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range s.events {
+		if !event.IsDeleted() {
+			s.index.add(event)
+		}
+	}
+
+	j, err := openJournal(logPath, size, p.Sync, p.SyncInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	s.journal = j
+	s.persistence = p
+	return s, nil
+}
+
+// Close writes the snapshot and closes the log. A storage created by NewStorage needs no closing.
+func (s *Storage) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.journal == nil || s.closed {
+		return nil
+	}
+	s.closed = true
+
+	var snapshotErr error
+	if s.logged > 0 {
+		snapshotErr = s.snapshot()
+	}
+
+	return errors.Join(snapshotErr, s.journal.close())
+}
+
+// commit logs the change and then applies it. Without persistence the change is only applied.
+// The caller must hold the write lock.
+func (s *Storage) commit(r record, apply func()) error {
+	if s.journal == nil {
+		apply()
+		return nil
+	}
+
+	if s.closed {
+		return errClosed
+	}
+
+	if err := s.journal.append(r); err != nil {
+		return err
+	}
+	apply()
+
+	s.logged++
+	if s.persistence.SnapshotEvery > 0 && s.logged >= s.persistence.SnapshotEvery {
+		// The change is already in the log, a failed snapshot is retried after the next one.
+		_ = s.snapshot()
+	}
+
+	return nil
+}
+
+// snapshot atomically replaces the snapshot with the current state and empties the log.
+// If the log is not emptied, its records are applied again on load, which gives the same state.
+func (s *Storage) snapshot() error {
+	snap := snapshot{Events: make([]storage.Event, 0, len(s.events)), TimeZones: s.timeZones}
+	for _, event := range s.events {
+		snap.Events = append(snap.Events, event)
+	}
+	sort.Slice(snap.Events, func(i, j int) bool { return snap.Events[i].ID < snap.Events[j].ID })
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	if err := writeFileAtomic(filepath.Join(s.persistence.Dir, snapshotFileName), data); err != nil {
+		return err
+	}
+
+	if err := s.journal.truncate(); err != nil {
+		return err
+	}
+
+	s.logged = 0
+	return nil
+}
+
+func (s *Storage) loadSnapshot(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+
+	for _, event := range snap.Events {
+		s.events[event.ID] = event
+	}
+	for userID, timeZone := range snap.TimeZones {
+		s.timeZones[userID] = timeZone
+	}
+
+	return nil
+}
+
+// replayLog applies the records of the log and returns the size of its intact part.
+func (s *Storage) replayLog(path string) (int64, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to open log: %w", err)
+	}
+	defer file.Close()
+
+	records, size, err := readRecords(file)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, r := range records {
+		if err := s.replay(r); err != nil {
+			return 0, err
+		}
+	}
+
+	return size, nil
+}
+
+func (s *Storage) replay(r record) error {
+	switch r.Op {
+	case opPutEvent:
+		if r.Event == nil {
+			return fmt.Errorf("log record %q has no event", r.Op)
+		}
+		s.events[r.Event.ID] = *r.Event
+	case opRemoveEvents:
+		for _, id := range r.IDs {
+			delete(s.events, id)
+		}
+	case opSetTimeZone:
+		s.timeZones[r.UserID] = r.TimeZone
+	default:
+		return fmt.Errorf("unknown log record %q", r.Op)
+	}
+
+	return nil
+}
+
+// writeFileAtomic replaces the file, so that it has either the old or the new content after a crash.
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot: %w", err)
+	}
+
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to replace snapshot: %w", err)
+	}
+
+	return syncDir(filepath.Dir(path))
+}
+
+// syncDir flushes the directory entry, so that a renamed file survives a crash.
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open directory: %w", err)
+	}
+	defer dir.Close()
+
+	if err := dir.Sync(); err != nil {
+		return fmt.Errorf("failed to sync directory: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,291 @@
+package memorystorage
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/app"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/storagetest"
+)
+
+func TestOpen_Contract(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) app.Storage {
+		t.Helper()
+
+		return openTestStorage(t, t.TempDir(), 3)
+	})
+}
+
+func TestOpen_Reopen(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s := openTestStorage(t, dir, 0)
+	changeStorage(t, s)
+	want := dumpState(t, s)
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() error = %v, want nil", err)
+	}
+	if _, err := s.CreateEvent(ctx, makeCreateOrUpdateEventParams()); err == nil {
+		t.Errorf("CreateEvent() after Close() error = nil, want error")
+	}
+
+	reopened := openTestStorage(t, dir, 0)
+	if got := dumpState(t, reopened); got != want {
+		t.Errorf("state after reopening = %s, want %s", got, want)
+	}
+
+	params := makeCreateOrUpdateEventParams()
+	events, err := reopened.GetEventsByPeriod(ctx, "", params.StartTime.Add(-time.Hour), params.EndTime)
+	if err != nil {
+		t.Fatalf("GetEventsByPeriod() error = %v, want nil", err)
+	}
+	if len(events) != 2 {
+		t.Errorf("GetEventsByPeriod() returned %d events, want 2", len(events))
+	}
+}
+
+func TestOpen_Snapshot(t *testing.T) {
+	dir := t.TempDir()
+
+	s := openTestStorage(t, dir, 2)
+	for _, tz := range []string{"Europe/Moscow", "Asia/Tokyo", "UTC"} {
+		if err := s.SetTimeZone(context.Background(), storagetest.OwnerID, tz); err != nil {
+			t.Fatalf("SetTimeZone() error = %v, want nil", err)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, snapshotFileName)); err != nil {
+		t.Fatalf("snapshot is not written: %v", err)
+	}
+
+	records := readLog(t, dir)
+	if len(records) != 1 || records[0].TimeZone != "UTC" {
+		t.Errorf("log after snapshot = %+v, want only the last change", records)
+	}
+
+	// A crash leaves the log behind, it is applied over the snapshot.
+	crash(t, s)
+
+	reopened := openTestStorage(t, dir, 2)
+	tz, err := reopened.GetTimeZone(context.Background(), storagetest.OwnerID)
+	if err != nil || tz != "UTC" {
+		t.Errorf("GetTimeZone() = %q, %v, want %q, nil", tz, err, "UTC")
+	}
+}
+
+func TestOpen_TornLog(t *testing.T) {
+	dir := t.TempDir()
+
+	s := openTestStorage(t, dir, 0)
+	sizes := []int64{0}
+	states := []string{dumpState(t, s)}
+	changeStorage(t, s, func() {
+		sizes = append(sizes, logSize(t, dir))
+		states = append(states, dumpState(t, s))
+	})
+	crash(t, s)
+
+	data, err := os.ReadFile(filepath.Join(dir, logFileName))
+	if err != nil {
+		t.Fatalf("failed to read log: %v", err)
+	}
+
+	for i := 1; i < len(sizes); i++ {
+		start, end := sizes[i-1], sizes[i]
+		cuts := map[int64]string{
+			start + 1:                states[i-1],
+			start + recordHeaderSize: states[i-1],
+			(start + end) / 2:        states[i-1],
+			end - 1:                  states[i-1],
+			end:                      states[i],
+		}
+
+		for cut, want := range cuts {
+			crashed := t.TempDir()
+			if err := os.WriteFile(filepath.Join(crashed, logFileName), data[:cut], 0o600); err != nil {
+				t.Fatalf("failed to write log: %v", err)
+			}
+
+			recovered := openTestStorage(t, crashed, 0)
+			if got := dumpState(t, recovered); got != want {
+				t.Fatalf("state after cutting log at %d = %s, want %s", cut, got, want)
+			}
+
+			// New changes are appended after the last intact record and survive reopening.
+			if err := recovered.SetTimeZone(context.Background(), storagetest.OtherOwnerID, "Asia/Tokyo"); err != nil {
+				t.Fatalf("SetTimeZone() error = %v, want nil", err)
+			}
+			want = dumpState(t, recovered)
+			crash(t, recovered)
+
+			if got := dumpState(t, openTestStorage(t, crashed, 0)); got != want {
+				t.Fatalf("state after appending to log cut at %d = %s, want %s", cut, got, want)
+			}
+		}
+	}
+}
+
+func TestOpen_CorruptRecord(t *testing.T) {
+	dir := t.TempDir()
+
+	s := openTestStorage(t, dir, 0)
+	if err := s.SetTimeZone(context.Background(), storagetest.OwnerID, "Europe/Moscow"); err != nil {
+		t.Fatalf("SetTimeZone() error = %v, want nil", err)
+	}
+	want := dumpState(t, s)
+	size := logSize(t, dir)
+	if err := s.SetTimeZone(context.Background(), storagetest.OwnerID, "Asia/Tokyo"); err != nil {
+		t.Fatalf("SetTimeZone() error = %v, want nil", err)
+	}
+	crash(t, s)
+
+	path := filepath.Join(dir, logFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read log: %v", err)
+	}
+	data[size+recordHeaderSize+1] ^= 0xff
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+
+	if got := dumpState(t, openTestStorage(t, dir, 0)); got != want {
+		t.Errorf("state after corrupting the last record = %s, want %s", got, want)
+	}
+}
+
+func TestOpen_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Persistence
+	}{
+		{name: "unknown sync policy", p: Persistence{Dir: t.TempDir(), Sync: "sometimes"}},
+		{name: "no sync interval", p: Persistence{Dir: t.TempDir(), Sync: SyncInterval}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Open(tt.p); err == nil {
+				t.Errorf("Open() error = nil, want error")
+			}
+		})
+	}
+}
+
+func openTestStorage(t *testing.T, dir string, snapshotEvery int) *Storage {
+	t.Helper()
+
+	s, err := Open(Persistence{Dir: dir, Sync: SyncAlways, SnapshotEvery: snapshotEvery})
+	if err != nil {
+		t.Fatalf("Open() error = %v, want nil", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	return s
+}
+
+// changeStorage makes a change of every kind and calls the callbacks after each of them.
+func changeStorage(t *testing.T, s *Storage, after ...func()) {
+	t.Helper()
+
+	ctx := context.Background()
+	step := func(name string, err error) {
+		t.Helper()
+
+		if err != nil {
+			t.Fatalf("%s() error = %v, want nil", name, err)
+		}
+		for _, f := range after {
+			f()
+		}
+	}
+
+	first, err := s.CreateEvent(ctx, makeCreateOrUpdateEventParams())
+	step("CreateEvent", err)
+	second, err := s.CreateEvent(ctx, makeCreateOrUpdateEventParams())
+	step("CreateEvent", err)
+	trashed, err := s.CreateEvent(ctx, makeCreateOrUpdateEventParams())
+	step("CreateEvent", err)
+
+	first.Title = "Updated"
+	_, err = s.UpdateEvent(ctx, *first)
+	step("UpdateEvent", err)
+	step("AddAttendee", s.AddAttendee(ctx, first.ID, storagetest.OtherOwnerID))
+	step("SetAttendeeStatus", s.SetAttendeeStatus(ctx, first.ID, storagetest.OtherOwnerID, storage.AttendeeAccepted))
+	step("DeleteEvent", s.DeleteEvent(ctx, second.ID))
+	_, err = s.RestoreEvent(ctx, second.ID)
+	step("RestoreEvent", err)
+	step("DeleteEvent", s.DeleteEvent(ctx, trashed.ID))
+	_, err = s.PurgeDeletedEvents(ctx, time.Now().Add(time.Hour))
+	step("PurgeDeletedEvents", err)
+	step("SetTimeZone", s.SetTimeZone(ctx, storagetest.OwnerID, "Europe/Moscow"))
+}
+
+// crash closes the log without writing the snapshot.
+func crash(t *testing.T, s *Storage) {
+	t.Helper()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	if err := s.journal.close(); err != nil {
+		t.Fatalf("failed to close log: %v", err)
+	}
+}
+
+func dumpState(t *testing.T, s *Storage) string {
+	t.Helper()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snap := snapshot{TimeZones: s.timeZones}
+	for _, event := range s.events {
+		snap.Events = append(snap.Events, event)
+	}
+	sort.Slice(snap.Events, func(i, j int) bool { return snap.Events[i].ID < snap.Events[j].ID })
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		t.Fatalf("failed to encode state: %v", err)
+	}
+
+	return string(data)
+}
+
+func logSize(t *testing.T, dir string) int64 {
+	t.Helper()
+
+	info, err := os.Stat(filepath.Join(dir, logFileName))
+	if err != nil {
+		t.Fatalf("failed to stat log: %v", err)
+	}
+
+	return info.Size()
+}
+
+func readLog(t *testing.T, dir string) []record {
+	t.Helper()
+
+	file, err := os.Open(filepath.Join(dir, logFileName))
+	if err != nil {
+		t.Fatalf("failed to open log: %v", err)
+	}
+	defer file.Close()
+
+	records, _, err := readRecords(file)
+	if err != nil {
+		t.Fatalf("readRecords() error = %v, want nil", err)
+	}
+
+	return records
+}
@@ -3,6 +3,7 @@ package memorystorage
 import (
 	"context"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -11,13 +12,25 @@ import (
 )
 
 type Storage struct {
-	mu     sync.RWMutex
-	events map[string]storage.Event
+	mu        sync.RWMutex
+	events    map[string]storage.Event
+	timeZones map[string]string
+	// index contains events which are not in the trash.
+	index *periodIndex
+
+	// journal logs changes of a storage opened by Open, it is nil for a storage which is not persisted.
+	journal     *journal
+	persistence Persistence
+	// logged is the number of changes logged since the last snapshot.
+	logged int
+	closed bool
 }
 
 func NewStorage() *Storage {
 	return &Storage{
-		events: make(map[string]storage.Event),
+		events:    make(map[string]storage.Event),
+		timeZones: make(map[string]string),
+		index:     newPeriodIndex(),
 	}
 }
 
@@ -36,16 +49,26 @@ func (s *Storage) CreateEvent(ctx context.Context, params storage.CreateOrUpdate
 		}
 
 		event := storage.Event{
-			ID:           id,
-			Title:        params.Title,
-			StartTime:    params.StartTime,
-			EndTime:      params.EndTime,
-			Description:  params.Description,
-			OwnerID:      params.OwnerID,
-			NotifyBefore: params.NotifyBefore,
+			ID:                   id,
+			Title:                params.Title,
+			StartTime:            params.StartTime,
+			EndTime:              params.EndTime,
+			Description:          params.Description,
+			OwnerID:              params.OwnerID,
+			NotifyBefore:         params.NotifyBefore,
+			RecurrenceRule:       params.RecurrenceRule,
+			RecurrenceExceptions: params.RecurrenceExceptions,
+			Version:              1,
+			AllDay:               params.AllDay,
+			TimeZone:             params.TimeZone,
 		}
 
-		s.events[event.ID] = event
+		if err := s.commit(putEventRecord(event), func() {
+			s.events[event.ID] = event
+			s.index.add(event)
+		}); err != nil {
+			return nil, err
+		}
 		return &event, nil
 	}
 }
@@ -59,14 +82,46 @@ func (s *Storage) GetEvent(ctx context.Context, id string) (*storage.Event, erro
 		defer s.mu.RUnlock()
 
 		event, exists := s.events[id]
-		if !exists {
+		if !exists || event.IsDeleted() {
 			return nil, storage.ErrEventNotFound
 		}
 		return &event, nil
 	}
 }
 
-func (s *Storage) UpdateEvent(ctx context.Context, event storage.Event) error {
+func (s *Storage) UpdateEvent(ctx context.Context, event storage.Event) (*storage.Event, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		existing, exists := s.events[event.ID]
+		if !exists || existing.IsDeleted() {
+			return nil, storage.ErrEventNotFound
+		}
+
+		if event.Version != 0 && event.Version != existing.Version {
+			return nil, storage.ErrVersionConflict
+		}
+
+		event.Version = existing.Version + 1
+		event.DeletedAt = nil
+		event.Attendees = existing.Attendees
+		if err := s.commit(putEventRecord(event), func() {
+			s.events[event.ID] = event
+			s.index.remove(existing)
+			s.index.add(event)
+		}); err != nil {
+			return nil, err
+		}
+		return &event, nil
+	}
+}
+
+// DeleteEvent moves the event to the trash.
+func (s *Storage) DeleteEvent(ctx context.Context, id string) error {
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -74,15 +129,147 @@ func (s *Storage) UpdateEvent(ctx context.Context, event storage.Event) error {
 		s.mu.Lock()
 		defer s.mu.Unlock()
 
-		if _, exists := s.events[event.ID]; !exists {
+		event, exists := s.events[id]
+		if !exists || event.IsDeleted() {
 			return storage.ErrEventNotFound
 		}
-		s.events[event.ID] = event
-		return nil
+
+		deletedAt := time.Now().UTC()
+		event.DeletedAt = &deletedAt
+		event.Version++
+		return s.commit(putEventRecord(event), func() {
+			s.events[id] = event
+			s.index.remove(event)
+		})
 	}
 }
 
-func (s *Storage) DeleteEvent(ctx context.Context, id string) error {
+// GetDeletedEvent returns the event if it is in the trash.
+func (s *Storage) GetDeletedEvent(ctx context.Context, id string) (*storage.Event, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		event, exists := s.events[id]
+		if !exists || !event.IsDeleted() {
+			return nil, storage.ErrEventNotFound
+		}
+		return &event, nil
+	}
+}
+
+// GetDeletedEvents returns events in the trash of the owner, or of all owners if ownerID is empty.
+// Recently deleted events go first.
+func (s *Storage) GetDeletedEvents(ctx context.Context, ownerID string) ([]storage.Event, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		var result []storage.Event
+		for _, event := range s.events {
+			if event.IsDeleted() && (ownerID == "" || event.OwnerID == ownerID) {
+				result = append(result, event)
+			}
+		}
+
+		sort.Slice(result, func(i, j int) bool {
+			return result[i].DeletedAt.After(*result[j].DeletedAt)
+		})
+
+		return result, nil
+	}
+}
+
+// RestoreEvent moves the event out of the trash.
+func (s *Storage) RestoreEvent(ctx context.Context, id string) (*storage.Event, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		event, exists := s.events[id]
+		if !exists || !event.IsDeleted() {
+			return nil, storage.ErrEventNotFound
+		}
+
+		event.DeletedAt = nil
+		event.Version++
+		if err := s.commit(putEventRecord(event), func() {
+			s.events[id] = event
+			s.index.add(event)
+		}); err != nil {
+			return nil, err
+		}
+		return &event, nil
+	}
+}
+
+// PurgeDeletedEvents removes events moved to the trash before t.
+func (s *Storage) PurgeDeletedEvents(ctx context.Context, t time.Time) (int64, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	default:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		var ids []string
+		for id, event := range s.events {
+			if event.IsDeleted() && event.DeletedAt.Before(t) {
+				ids = append(ids, id)
+			}
+		}
+
+		if len(ids) == 0 {
+			return 0, nil
+		}
+
+		if err := s.commit(removeEventsRecord(ids), func() {
+			for _, id := range ids {
+				delete(s.events, id)
+			}
+		}); err != nil {
+			return 0, err
+		}
+		return int64(len(ids)), nil
+	}
+}
+
+// AddAttendee invites the user to the event, inviting an attendee again keeps the status.
+func (s *Storage) AddAttendee(ctx context.Context, eventID, userID string) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		event, exists := s.events[eventID]
+		if !exists || event.IsDeleted() {
+			return storage.ErrEventNotFound
+		}
+
+		if _, ok := event.Attendee(userID); ok {
+			return nil
+		}
+
+		attendees := make([]storage.Attendee, 0, len(event.Attendees)+1)
+		attendees = append(attendees, event.Attendees...)
+		attendees = append(attendees, storage.Attendee{UserID: userID, Status: storage.AttendeeNeedsAction})
+		event.Attendees = attendees
+		return s.putEvent(event)
+	}
+}
+
+func (s *Storage) RemoveAttendee(ctx context.Context, eventID, userID string) error {
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -90,15 +277,70 @@ func (s *Storage) DeleteEvent(ctx context.Context, id string) error {
 		s.mu.Lock()
 		defer s.mu.Unlock()
 
-		if _, exists := s.events[id]; !exists {
+		event, exists := s.events[eventID]
+		if !exists || event.IsDeleted() {
 			return storage.ErrEventNotFound
 		}
-		delete(s.events, id)
-		return nil
+
+		attendees := make([]storage.Attendee, 0, len(event.Attendees))
+		for _, a := range event.Attendees {
+			if a.UserID != userID {
+				attendees = append(attendees, a)
+			}
+		}
+
+		if len(attendees) == len(event.Attendees) {
+			return storage.ErrAttendeeNotFound
+		}
+
+		event.Attendees = attendees
+		return s.putEvent(event)
 	}
 }
 
-func (s *Storage) GetAllEvents(ctx context.Context) ([]storage.Event, error) {
+func (s *Storage) SetAttendeeStatus(
+	ctx context.Context,
+	eventID, userID string,
+	status storage.AttendeeStatus,
+) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		event, exists := s.events[eventID]
+		if !exists || event.IsDeleted() {
+			return storage.ErrEventNotFound
+		}
+
+		if _, ok := event.Attendee(userID); !ok {
+			return storage.ErrAttendeeNotFound
+		}
+
+		attendees := make([]storage.Attendee, len(event.Attendees))
+		for i, a := range event.Attendees {
+			if a.UserID == userID {
+				a.Status = status
+			}
+			attendees[i] = a
+		}
+
+		event.Attendees = attendees
+		return s.putEvent(event)
+	}
+}
+
+// putEvent stores the event whose period is not changed. The caller must hold the write lock.
+func (s *Storage) putEvent(event storage.Event) error {
+	return s.commit(putEventRecord(event), func() {
+		s.events[event.ID] = event
+	})
+}
+
+// GetAllEvents returns events of the owner, or events of all owners if ownerID is empty.
+func (s *Storage) GetAllEvents(ctx context.Context, ownerID string) ([]storage.Event, error) {
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -108,13 +350,134 @@ func (s *Storage) GetAllEvents(ctx context.Context) ([]storage.Event, error) {
 
 		events := make([]storage.Event, 0, len(s.events))
 		for _, e := range s.events {
-			events = append(events, e)
+			if !e.IsDeleted() && (ownerID == "" || e.OwnerID == ownerID) {
+				events = append(events, e)
+			}
 		}
 		return events, nil
 	}
 }
 
-func (s *Storage) GetEventsByPeriod(ctx context.Context, start, end time.Time) ([]storage.Event, error) {
+func (s *Storage) ListEvents(ctx context.Context, query storage.ListEventsQuery) (storage.EventPage, error) {
+	cursor, err := query.DecodeCursor()
+	if err != nil {
+		return storage.EventPage{}, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return storage.EventPage{}, ctx.Err()
+	default:
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		var events []storage.Event
+		for _, event := range s.events {
+			if !event.IsDeleted() && query.Matches(event) && afterCursor(query, cursor, event) {
+				events = append(events, event)
+			}
+		}
+
+		sort.Slice(events, func(i, j int) bool {
+			c := compareEvents(query.SortBy, events[i], events[j])
+			if query.Descending {
+				return c > 0
+			}
+			return c < 0
+		})
+
+		page := storage.EventPage{Events: events}
+		if len(events) > query.PageSize {
+			page.Events = events[:query.PageSize]
+			page.NextCursor = query.NewCursor(page.Events[query.PageSize-1])
+		}
+
+		return page, nil
+	}
+}
+
+// GetEventsByPeriod returns events intersecting the period owned or attended by the user with ownerID,
+// or events of all owners if ownerID is empty. Recurring events which may have occurrences there
+// are returned unexpanded.
+func (s *Storage) GetEventsByPeriod(
+	ctx context.Context,
+	ownerID string,
+	start, end time.Time,
+) ([]storage.Event, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		return s.eventsInPeriod(start, end, func(event storage.Event) bool {
+			return ownerID == "" || event.OwnerID == ownerID || event.Attends(ownerID)
+		}), nil
+	}
+}
+
+// GetOverlappingEvents returns events of the owner intersecting [start, end).
+// Recurring events which may have occurrences there are returned unexpanded.
+func (s *Storage) GetOverlappingEvents(
+	ctx context.Context,
+	ownerID string,
+	start, end time.Time,
+) ([]storage.Event, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		return s.eventsInPeriod(start, end, func(event storage.Event) bool {
+			return event.OwnerID == ownerID
+		}), nil
+	}
+}
+
+// GetBusyEvents returns events owned or attended by any of the users which intersect [start, end).
+// Recurring events which may have occurrences there are returned unexpanded.
+func (s *Storage) GetBusyEvents(
+	ctx context.Context,
+	userIDs []string,
+	start, end time.Time,
+) ([]storage.Event, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		return s.eventsInPeriod(start, end, func(event storage.Event) bool {
+			return busyFor(event, userIDs)
+		}), nil
+	}
+}
+
+// eventsInPeriod returns events intersecting [start, end) which match the filter, sorted by start.
+// The caller must hold the lock.
+func (s *Storage) eventsInPeriod(start, end time.Time, match func(storage.Event) bool) []storage.Event {
+	var result []storage.Event
+	for _, id := range s.index.find(start, end) {
+		if event := s.events[id]; match(event) {
+			result = append(result, event)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].StartTime.Equal(result[j].StartTime) {
+			return result[i].StartTime.Before(result[j].StartTime)
+		}
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
+
+func (s *Storage) GetEventsToNotify(ctx context.Context, from, to time.Time) ([]storage.Event, error) {
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -124,8 +487,19 @@ func (s *Storage) GetEventsByPeriod(ctx context.Context, start, end time.Time) (
 
 		var result []storage.Event
 		for _, event := range s.events {
-			if (event.StartTime.Equal(start) || event.StartTime.After(start)) &&
-				event.StartTime.Before(end) {
+			if event.NotifyBefore == nil || event.IsDeleted() {
+				continue
+			}
+
+			notifyAt := event.StartTime.Add(-*event.NotifyBefore)
+			if event.IsRecurring() {
+				if notifyAt.Before(to) && seriesEndsAfter(event, from) {
+					result = append(result, event)
+				}
+				continue
+			}
+
+			if !notifyAt.Before(from) && notifyAt.Before(to) {
 				result = append(result, event)
 			}
 		}
@@ -137,3 +511,121 @@ func (s *Storage) GetEventsByPeriod(ctx context.Context, start, end time.Time) (
 		return result, nil
 	}
 }
+
+func (s *Storage) DeleteEventsBefore(ctx context.Context, t time.Time) (int64, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	default:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		var ids []string
+		for id, event := range s.events {
+			if !seriesEndsAfter(event, t) {
+				ids = append(ids, id)
+			}
+		}
+
+		if len(ids) == 0 {
+			return 0, nil
+		}
+
+		if err := s.commit(removeEventsRecord(ids), func() {
+			for _, id := range ids {
+				if event := s.events[id]; !event.IsDeleted() {
+					s.index.remove(event)
+				}
+				delete(s.events, id)
+			}
+		}); err != nil {
+			return 0, err
+		}
+		return int64(len(ids)), nil
+	}
+}
+
+// GetTimeZone returns the default time zone of the user, empty if it is not set.
+func (s *Storage) GetTimeZone(ctx context.Context, userID string) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	default:
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		return s.timeZones[userID], nil
+	}
+}
+
+func (s *Storage) SetTimeZone(ctx context.Context, userID, timeZone string) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		return s.commit(setTimeZoneRecord(userID, timeZone), func() {
+			s.timeZones[userID] = timeZone
+		})
+	}
+}
+
+func seriesEndsAfter(event storage.Event, t time.Time) bool {
+	end, err := event.SeriesEnd()
+	if err != nil || end == nil {
+		return true
+	}
+
+	return !end.Before(t)
+}
+
+// busyFor reports whether the event takes time of any of the users.
+func busyFor(event storage.Event, userIDs []string) bool {
+	for _, id := range userIDs {
+		if event.OwnerID == id || event.Attends(id) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compareEvents compares events by the sort field and then by ID.
+func compareEvents(sortBy storage.SortField, a, b storage.Event) int {
+	var c int
+	switch sortBy {
+	case storage.SortByTitle:
+		c = strings.Compare(a.Title, b.Title)
+	case storage.SortByStartTime:
+		c = a.StartTime.Compare(b.StartTime)
+	}
+
+	if c == 0 {
+		c = strings.Compare(a.ID, b.ID)
+	}
+
+	return c
+}
+
+func afterCursor(query storage.ListEventsQuery, cursor *storage.Cursor, event storage.Event) bool {
+	if cursor == nil {
+		return true
+	}
+
+	last := storage.Event{ID: cursor.ID}
+	if cursor.StartTime != nil {
+		last.StartTime = *cursor.StartTime
+	}
+	if cursor.Title != nil {
+		last.Title = *cursor.Title
+	}
+
+	c := compareEvents(query.SortBy, event, last)
+	if query.Descending {
+		return c < 0
+	}
+
+	return c > 0
+}
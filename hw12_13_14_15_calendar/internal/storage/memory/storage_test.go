@@ -8,7 +8,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/app"
 	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/storagetest"
 )
 
 func makeCreateOrUpdateEventParams() storage.CreateOrUpdateEventParams {
@@ -58,7 +60,7 @@ func TestStorage_GetEvent(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	allEvents, err := s.GetAllEvents(ctx)
+	allEvents, err := s.GetAllEvents(ctx, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -99,13 +101,13 @@ func TestStorage_UpdateEvent(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	allEvents, err := s.GetAllEvents(ctx)
+	allEvents, err := s.GetAllEvents(ctx, "")
 	if err != nil {
 		t.Fatal(err)
 	}
 	eventID := allEvents[0].ID
 
-	err = s.UpdateEvent(ctx, storage.Event{ID: "nonexistent-id", Title: "Updated"})
+	_, err = s.UpdateEvent(ctx, storage.Event{ID: "nonexistent-id", Title: "Updated"})
 	if !errors.Is(err, storage.ErrEventNotFound) {
 		t.Errorf("UpdateEvent() error = %v, want %v", err, storage.ErrEventNotFound)
 	}
@@ -117,9 +119,25 @@ func TestStorage_UpdateEvent(t *testing.T) {
 		EndTime:   allEvents[0].EndTime,
 		OwnerID:   allEvents[0].OwnerID,
 	}
-	err = s.UpdateEvent(ctx, updatedEvent)
+	updated, err := s.UpdateEvent(ctx, updatedEvent)
 	if err != nil {
-		t.Errorf("UpdateEvent() error = %v, want nil", err)
+		t.Fatalf("UpdateEvent() error = %v, want nil", err)
+	}
+	if updated.Version != 2 {
+		t.Errorf("UpdateEvent() version = %v, want 2", updated.Version)
+	}
+
+	staleEvent := updatedEvent
+	staleEvent.Version = 1
+	_, err = s.UpdateEvent(ctx, staleEvent)
+	if !errors.Is(err, storage.ErrVersionConflict) {
+		t.Errorf("UpdateEvent() with stale version error = %v, want %v", err, storage.ErrVersionConflict)
+	}
+
+	currentEvent := updatedEvent
+	currentEvent.Version = 2
+	if _, err := s.UpdateEvent(ctx, currentEvent); err != nil {
+		t.Errorf("UpdateEvent() with current version error = %v, want nil", err)
 	}
 
 	gotEvent, err := s.GetEvent(ctx, eventID)
@@ -132,7 +150,7 @@ func TestStorage_UpdateEvent(t *testing.T) {
 
 	cancelCtx, cancel := context.WithCancel(ctx)
 	cancel()
-	err = s.UpdateEvent(cancelCtx, updatedEvent)
+	_, err = s.UpdateEvent(cancelCtx, updatedEvent)
 	if !errors.Is(err, context.Canceled) {
 		t.Errorf("UpdateEvent() with canceled context error = %v, want %v", err, context.Canceled)
 	}
@@ -153,7 +171,7 @@ func TestStorage_DeleteEvent(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	allEvents, err := s.GetAllEvents(ctx)
+	allEvents, err := s.GetAllEvents(ctx, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -177,11 +195,162 @@ func TestStorage_DeleteEvent(t *testing.T) {
 	}
 }
 
+func TestStorage_Trash(t *testing.T) {
+	s := NewStorage()
+	ctx := context.Background()
+
+	event, err := s.CreateEvent(ctx, makeCreateOrUpdateEventParams())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.RestoreEvent(ctx, event.ID); !errors.Is(err, storage.ErrEventNotFound) {
+		t.Errorf("RestoreEvent() of not deleted event error = %v, want %v", err, storage.ErrEventNotFound)
+	}
+
+	if err := s.DeleteEvent(ctx, event.ID); err != nil {
+		t.Fatalf("DeleteEvent() error = %v, want nil", err)
+	}
+	if err := s.DeleteEvent(ctx, event.ID); !errors.Is(err, storage.ErrEventNotFound) {
+		t.Errorf("DeleteEvent() of deleted event error = %v, want %v", err, storage.ErrEventNotFound)
+	}
+
+	if _, err := s.UpdateEvent(ctx, *event); !errors.Is(err, storage.ErrEventNotFound) {
+		t.Errorf("UpdateEvent() of deleted event error = %v, want %v", err, storage.ErrEventNotFound)
+	}
+
+	all, err := s.GetAllEvents(ctx, "")
+	if err != nil || len(all) != 0 {
+		t.Errorf("GetAllEvents() = %+v, %v, want no events", all, err)
+	}
+
+	period, err := s.GetEventsByPeriod(ctx, "", event.StartTime.Add(-time.Hour), event.EndTime)
+	if err != nil || len(period) != 0 {
+		t.Errorf("GetEventsByPeriod() = %+v, %v, want no events", period, err)
+	}
+
+	trash, err := s.GetDeletedEvents(ctx, event.OwnerID)
+	if err != nil || len(trash) != 1 || trash[0].DeletedAt == nil {
+		t.Fatalf("GetDeletedEvents() = %+v, %v, want the deleted event", trash, err)
+	}
+
+	if trash, _ := s.GetDeletedEvents(ctx, "owner-2"); len(trash) != 0 {
+		t.Errorf("GetDeletedEvents() of another owner = %+v, want no events", trash)
+	}
+
+	restored, err := s.RestoreEvent(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("RestoreEvent() error = %v, want nil", err)
+	}
+	if restored.DeletedAt != nil || restored.Version != 3 {
+		t.Errorf("RestoreEvent() = %+v, want not deleted event with version 3", restored)
+	}
+
+	if _, err := s.GetEvent(ctx, event.ID); err != nil {
+		t.Errorf("GetEvent() after RestoreEvent() error = %v, want nil", err)
+	}
+}
+
+func TestStorage_PurgeDeletedEvents(t *testing.T) {
+	s := NewStorage()
+	ctx := context.Background()
+
+	kept, err := s.CreateEvent(ctx, makeCreateOrUpdateEventParams())
+	if err != nil {
+		t.Fatal(err)
+	}
+	deleted, err := s.CreateEvent(ctx, makeCreateOrUpdateEventParams())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteEvent(ctx, deleted.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	purged, err := s.PurgeDeletedEvents(ctx, time.Now().Add(-time.Hour))
+	if err != nil || purged != 0 {
+		t.Errorf("PurgeDeletedEvents() = %v, %v, want 0, nil", purged, err)
+	}
+
+	purged, err = s.PurgeDeletedEvents(ctx, time.Now().Add(time.Hour))
+	if err != nil || purged != 1 {
+		t.Errorf("PurgeDeletedEvents() = %v, %v, want 1, nil", purged, err)
+	}
+
+	if _, err := s.GetDeletedEvent(ctx, deleted.ID); !errors.Is(err, storage.ErrEventNotFound) {
+		t.Errorf("GetDeletedEvent() of purged event error = %v, want %v", err, storage.ErrEventNotFound)
+	}
+	if _, err := s.GetEvent(ctx, kept.ID); err != nil {
+		t.Errorf("GetEvent() error = %v, want nil", err)
+	}
+}
+
+func TestStorage_Attendees(t *testing.T) {
+	s := NewStorage()
+	ctx := context.Background()
+	guest := "223e4567-e89b-12d3-a456-426614174000"
+
+	event, err := s.CreateEvent(ctx, makeCreateOrUpdateEventParams())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.AddAttendee(ctx, "nonexistent-id", guest); !errors.Is(err, storage.ErrEventNotFound) {
+		t.Errorf("AddAttendee() error = %v, want %v", err, storage.ErrEventNotFound)
+	}
+	err = s.SetAttendeeStatus(ctx, event.ID, guest, storage.AttendeeAccepted)
+	if !errors.Is(err, storage.ErrAttendeeNotFound) {
+		t.Errorf("SetAttendeeStatus() error = %v, want %v", err, storage.ErrAttendeeNotFound)
+	}
+
+	for range 2 {
+		if err := s.AddAttendee(ctx, event.ID, guest); err != nil {
+			t.Fatalf("AddAttendee() error = %v, want nil", err)
+		}
+	}
+
+	period, err := s.GetEventsByPeriod(ctx, guest, event.StartTime, event.EndTime)
+	if err != nil || len(period) != 1 {
+		t.Errorf("GetEventsByPeriod() of attendee = %+v, %v, want the event", period, err)
+	}
+
+	if err := s.SetAttendeeStatus(ctx, event.ID, guest, storage.AttendeeDeclined); err != nil {
+		t.Fatalf("SetAttendeeStatus() error = %v, want nil", err)
+	}
+
+	updated := *event
+	updated.Title = "Updated"
+	if _, err := s.UpdateEvent(ctx, updated); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.GetEvent(ctx, event.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []storage.Attendee{{UserID: guest, Status: storage.AttendeeDeclined}}
+	if len(got.Attendees) != 1 || got.Attendees[0] != want[0] {
+		t.Errorf("Attendees = %+v, want %+v", got.Attendees, want)
+	}
+
+	period, err = s.GetEventsByPeriod(ctx, guest, event.StartTime, event.EndTime)
+	if err != nil || len(period) != 0 {
+		t.Errorf("GetEventsByPeriod() of declined attendee = %+v, %v, want no events", period, err)
+	}
+
+	if err := s.RemoveAttendee(ctx, event.ID, guest); err != nil {
+		t.Errorf("RemoveAttendee() error = %v, want nil", err)
+	}
+	if err := s.RemoveAttendee(ctx, event.ID, guest); !errors.Is(err, storage.ErrAttendeeNotFound) {
+		t.Errorf("RemoveAttendee() error = %v, want %v", err, storage.ErrAttendeeNotFound)
+	}
+}
+
 func TestStorage_GetAllEvents(t *testing.T) {
 	s := NewStorage()
 	ctx := context.Background()
 
-	allEvents, err := s.GetAllEvents(ctx)
+	allEvents, err := s.GetAllEvents(ctx, "")
 	if err != nil {
 		t.Errorf("GetAllEvents() error = %v, want nil", err)
 	}
@@ -206,7 +375,7 @@ func TestStorage_GetAllEvents(t *testing.T) {
 		}
 	}
 
-	allEvents, err = s.GetAllEvents(ctx)
+	allEvents, err = s.GetAllEvents(ctx, "")
 	if err != nil {
 		t.Errorf("GetAllEvents() error = %v, want nil", err)
 	}
@@ -216,12 +385,287 @@ func TestStorage_GetAllEvents(t *testing.T) {
 
 	cancelCtx, cancel := context.WithCancel(ctx)
 	cancel()
-	_, err = s.GetAllEvents(cancelCtx)
+	_, err = s.GetAllEvents(cancelCtx, "")
 	if !errors.Is(err, context.Canceled) {
 		t.Errorf("GetAllEvents() with canceled context error = %v, want %v", err, context.Canceled)
 	}
 }
 
+func TestStorage_GetEventsToNotify(t *testing.T) {
+	s := NewStorage()
+	ctx := context.Background()
+
+	base := time.Date(2025, time.May, 10, 12, 0, 0, 0, time.UTC)
+	tenMinutes := 10 * time.Minute
+	hour := time.Hour
+
+	testEvents := []storage.CreateOrUpdateEventParams{
+		{
+			Title:        "In window",
+			StartTime:    base.Add(15 * time.Minute),
+			EndTime:      base.Add(time.Hour),
+			NotifyBefore: &tenMinutes,
+		},
+		{
+			Title:        "Early",
+			StartTime:    base.Add(2 * time.Hour),
+			EndTime:      base.Add(3 * time.Hour),
+			NotifyBefore: &hour,
+		},
+		{
+			Title:        "Too late",
+			StartTime:    base.Add(2 * time.Hour),
+			EndTime:      base.Add(3 * time.Hour),
+			NotifyBefore: &tenMinutes,
+		},
+		{
+			Title:     "Without notification",
+			StartTime: base.Add(5 * time.Minute),
+			EndTime:   base.Add(time.Hour),
+		},
+	}
+
+	for _, e := range testEvents {
+		if _, err := s.CreateEvent(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	events, err := s.GetEventsToNotify(ctx, base, base.Add(time.Hour+time.Minute))
+	if err != nil {
+		t.Fatalf("GetEventsToNotify() error = %v, want nil", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("GetEventsToNotify() length = %v, want 2", len(events))
+	}
+	if events[0].Title != "In window" || events[1].Title != "Early" {
+		t.Errorf("GetEventsToNotify() = [%s, %s], want [In window, Early]", events[0].Title, events[1].Title)
+	}
+
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = s.GetEventsToNotify(cancelCtx, base, base.Add(time.Hour))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("GetEventsToNotify() with canceled context error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestStorage_DeleteEventsBefore(t *testing.T) {
+	s := NewStorage()
+	ctx := context.Background()
+
+	base := time.Date(2025, time.May, 10, 12, 0, 0, 0, time.UTC)
+
+	testEvents := []storage.CreateOrUpdateEventParams{
+		{Title: "Old", StartTime: base.AddDate(-2, 0, 0), EndTime: base.AddDate(-2, 0, 0).Add(time.Hour)},
+		{Title: "Ends at border", StartTime: base.Add(-time.Hour), EndTime: base},
+		{Title: "Fresh", StartTime: base, EndTime: base.Add(time.Hour)},
+	}
+
+	for _, e := range testEvents {
+		if _, err := s.CreateEvent(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deleted, err := s.DeleteEventsBefore(ctx, base)
+	if err != nil {
+		t.Fatalf("DeleteEventsBefore() error = %v, want nil", err)
+	}
+	if deleted != 1 {
+		t.Errorf("DeleteEventsBefore() deleted = %v, want 1", deleted)
+	}
+
+	allEvents, err := s.GetAllEvents(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(allEvents) != 2 {
+		t.Errorf("GetAllEvents() length = %v, want 2", len(allEvents))
+	}
+
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = s.DeleteEventsBefore(cancelCtx, base)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("DeleteEventsBefore() with canceled context error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestStorage_GetOverlappingEvents(t *testing.T) {
+	s := NewStorage()
+	ctx := context.Background()
+
+	base := time.Date(2025, time.May, 10, 12, 0, 0, 0, time.UTC)
+	owner := "owner"
+	daily := "FREQ=DAILY"
+
+	testEvents := []storage.CreateOrUpdateEventParams{
+		{Title: "Before", StartTime: base.Add(-2 * time.Hour), EndTime: base, OwnerID: owner},
+		{Title: "Spanning", StartTime: base.Add(-time.Hour), EndTime: base.Add(time.Hour), OwnerID: owner},
+		{Title: "Inside", StartTime: base.Add(30 * time.Minute), EndTime: base.Add(time.Hour), OwnerID: owner},
+		{Title: "After", StartTime: base.Add(2 * time.Hour), EndTime: base.Add(3 * time.Hour), OwnerID: owner},
+		{
+			Title: "Series", StartTime: base.AddDate(0, 0, -7), EndTime: base.AddDate(0, 0, -7), OwnerID: owner,
+			RecurrenceRule: &daily,
+		},
+		{Title: "Foreign", StartTime: base, EndTime: base.Add(time.Hour), OwnerID: "other"},
+	}
+
+	for _, e := range testEvents {
+		if _, err := s.CreateEvent(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	events, err := s.GetOverlappingEvents(ctx, owner, base, base.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("GetOverlappingEvents() error = %v, want nil", err)
+	}
+
+	want := []string{"Series", "Spanning", "Inside"}
+	if len(events) != len(want) {
+		t.Fatalf("GetOverlappingEvents() = %+v, want %v", events, want)
+	}
+	for i, title := range want {
+		if events[i].Title != title {
+			t.Errorf("events[%d].Title = %v, want %v", i, events[i].Title, title)
+		}
+	}
+}
+
+func TestStorage_RecurringEvents(t *testing.T) {
+	s := NewStorage()
+	ctx := context.Background()
+
+	base := time.Date(2025, time.May, 10, 12, 0, 0, 0, time.UTC)
+	past := base.AddDate(-3, 0, 0)
+	future := base.AddDate(0, 0, 1)
+	endless := "FREQ=DAILY"
+	finished := "FREQ=DAILY;COUNT=3"
+
+	testEvents := []storage.CreateOrUpdateEventParams{
+		{Title: "Endless", StartTime: past, EndTime: past.Add(time.Hour), RecurrenceRule: &endless},
+		{Title: "Finished", StartTime: past, EndTime: past.Add(time.Hour), RecurrenceRule: &finished},
+		{Title: "Future", StartTime: future, EndTime: future.Add(time.Hour), RecurrenceRule: &endless},
+	}
+
+	for _, e := range testEvents {
+		if _, err := s.CreateEvent(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	events, err := s.GetEventsByPeriod(ctx, "", base, base.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("GetEventsByPeriod() error = %v, want nil", err)
+	}
+	if len(events) != 1 || events[0].Title != "Endless" {
+		t.Errorf("GetEventsByPeriod() = %+v, want only Endless", events)
+	}
+
+	deleted, err := s.DeleteEventsBefore(ctx, base.AddDate(-1, 0, 0))
+	if err != nil {
+		t.Fatalf("DeleteEventsBefore() error = %v, want nil", err)
+	}
+	if deleted != 1 {
+		t.Errorf("DeleteEventsBefore() deleted = %v, want 1", deleted)
+	}
+}
+
+func listAllPages(ctx context.Context, t *testing.T, s *Storage, query storage.ListEventsQuery) []storage.Event {
+	t.Helper()
+
+	var events []storage.Event
+	for {
+		page, err := s.ListEvents(ctx, query)
+		if err != nil {
+			t.Fatalf("ListEvents() error = %v, want nil", err)
+		}
+		if len(page.Events) > query.PageSize {
+			t.Fatalf("ListEvents() returned %d events, want at most %d", len(page.Events), query.PageSize)
+		}
+
+		events = append(events, page.Events...)
+		if page.NextCursor == "" {
+			return events
+		}
+		query.Cursor = page.NextCursor
+	}
+}
+
+func TestStorage_ListEvents(t *testing.T) {
+	s := NewStorage()
+	ctx := context.Background()
+
+	base := time.Date(2025, time.May, 10, 12, 0, 0, 0, time.UTC)
+	titles := []string{"Daily sync", "Lunch", "Sync with team", "Review", "Planning", "sync retro", "Demo"}
+
+	for i, title := range titles {
+		_, err := s.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+			Title:     title,
+			StartTime: base.Add(time.Duration(i%3) * time.Hour),
+			EndTime:   base.Add(time.Duration(i%3+1) * time.Hour),
+			OwnerID:   "owner",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err := s.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+		Title: "Foreign sync", StartTime: base, EndTime: base.Add(time.Hour), OwnerID: "other",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	to := base.Add(2 * time.Hour)
+	tests := []struct {
+		name  string
+		query storage.ListEventsQuery
+		want  int
+	}{
+		{"All", storage.ListEventsQuery{SortBy: storage.SortByStartTime}, 8},
+		{"Owner", storage.ListEventsQuery{OwnerID: "owner", SortBy: storage.SortByStartTime}, 7},
+		{"Title", storage.ListEventsQuery{OwnerID: "owner", Title: "SYNC", SortBy: storage.SortByTitle}, 3},
+		{"Period", storage.ListEventsQuery{OwnerID: "owner", To: &to, SortBy: storage.SortByStartTime}, 5},
+		{"Descending", storage.ListEventsQuery{SortBy: storage.SortByTitle, Descending: true}, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := tt.query
+			query.PageSize = 2
+
+			events := listAllPages(ctx, t, s, query)
+			if len(events) != tt.want {
+				t.Fatalf("ListEvents() pages contain %d events, want %d", len(events), tt.want)
+			}
+
+			for i := 1; i < len(events); i++ {
+				c := compareEvents(query.SortBy, events[i-1], events[i])
+				if (!query.Descending && c >= 0) || (query.Descending && c <= 0) {
+					t.Errorf("events %d and %d are out of order: %+v, %+v", i-1, i, events[i-1], events[i])
+				}
+			}
+		})
+	}
+
+	_, err = s.ListEvents(ctx, storage.ListEventsQuery{SortBy: storage.SortByStartTime, PageSize: 2, Cursor: "garbage"})
+	if !errors.Is(err, storage.ErrInvalidCursor) {
+		t.Errorf("ListEvents() with invalid cursor error = %v, want %v", err, storage.ErrInvalidCursor)
+	}
+
+	page, err := s.ListEvents(ctx, storage.ListEventsQuery{SortBy: storage.SortByStartTime, PageSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.ListEvents(ctx, storage.ListEventsQuery{SortBy: storage.SortByTitle, PageSize: 2, Cursor: page.NextCursor})
+	if !errors.Is(err, storage.ErrInvalidCursor) {
+		t.Errorf("ListEvents() with cursor of another sort error = %v, want %v", err, storage.ErrInvalidCursor)
+	}
+}
+
 func TestStorage_ConcurrentAccess(t *testing.T) {
 	s := NewStorage()
 	ctx := context.Background()
@@ -244,7 +688,7 @@ func TestStorage_ConcurrentAccess(t *testing.T) {
 				return
 			}
 
-			allEvents, err := s.GetAllEvents(ctx)
+			allEvents, err := s.GetAllEvents(ctx, "")
 			if err != nil {
 				t.Logf("GetAllEvents failed: %v", err)
 				return
@@ -276,7 +720,7 @@ func TestStorage_ConcurrentAccess(t *testing.T) {
 				EndTime:   params.EndTime,
 				OwnerID:   params.OwnerID,
 			}
-			err = s.UpdateEvent(ctx, updatedEvent)
+			_, err = s.UpdateEvent(ctx, updatedEvent)
 			if err != nil {
 				t.Logf("UpdateEvent failed: %v", err)
 				return
@@ -291,7 +735,7 @@ func TestStorage_ConcurrentAccess(t *testing.T) {
 	}
 	wg.Wait()
 
-	allEvents, err := s.GetAllEvents(ctx)
+	allEvents, err := s.GetAllEvents(ctx, "")
 	if err != nil {
 		t.Errorf("GetAllEvents() error = %v, want nil", err)
 	}
@@ -328,13 +772,14 @@ func TestStorage_ContextTimeout(t *testing.T) {
 		{
 			name: "UpdateEvent",
 			fn: func() error {
-				return s.UpdateEvent(ctx, storage.Event{
+				_, err := s.UpdateEvent(ctx, storage.Event{
 					ID:        "nonexistent-id",
 					Title:     "Test Event",
 					StartTime: time.Now(),
 					EndTime:   time.Now().Add(time.Hour),
 					OwnerID:   "test-owner",
 				})
+				return err
 			},
 			want: context.DeadlineExceeded,
 		},
@@ -345,7 +790,7 @@ func TestStorage_ContextTimeout(t *testing.T) {
 		},
 		{
 			name: "GetAllEvents",
-			fn:   func() error { _, err := s.GetAllEvents(ctx); return err },
+			fn:   func() error { _, err := s.GetAllEvents(ctx, ""); return err },
 			want: context.DeadlineExceeded,
 		},
 	}
@@ -359,3 +804,9 @@ func TestStorage_ContextTimeout(t *testing.T) {
 		})
 	}
 }
+
+func TestStorage_Contract(t *testing.T) {
+	storagetest.Run(t, func(_ *testing.T) app.Storage {
+		return NewStorage()
+	})
+}
@@ -0,0 +1,132 @@
+// Package migrate applies goose migrations embedded into the binary.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/pressly/goose/v3"
+)
+
+// ErrPending is reported by Check while some of the migrations are not applied.
+var ErrPending = errors.New("database has pending migrations")
+
+type Migrator struct {
+	provider *goose.Provider
+}
+
+// New creates a migrator applying migrations from the root of fsys.
+func New(db *sql.DB, dialect goose.Dialect, fsys fs.FS, opts ...goose.ProviderOption) (*Migrator, error) {
+	provider, err := goose.NewProvider(dialect, db, fsys, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migrator: %w", err)
+	}
+
+	return &Migrator{provider: provider}, nil
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up(ctx context.Context) ([]*goose.MigrationResult, error) {
+	results, err := m.provider.Up(ctx)
+	if err != nil {
+		return results, fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	return results, nil
+}
+
+// Down rolls back the last applied migration.
+func (m *Migrator) Down(ctx context.Context) ([]*goose.MigrationResult, error) {
+	result, err := m.provider.Down(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to roll back migration: %w", err)
+	}
+
+	return []*goose.MigrationResult{result}, nil
+}
+
+// Redo rolls back the last applied migration and applies it again.
+func (m *Migrator) Redo(ctx context.Context) ([]*goose.MigrationResult, error) {
+	results, err := m.Down(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := m.provider.UpByOne(ctx)
+	if err != nil {
+		return results, fmt.Errorf("failed to apply migration: %w", err)
+	}
+
+	return append(results, result), nil
+}
+
+// To applies or rolls back migrations, so that version is the last applied one.
+// Version 0 rolls back all migrations.
+func (m *Migrator) To(ctx context.Context, version int64) ([]*goose.MigrationResult, error) {
+	if version < 0 {
+		return nil, fmt.Errorf("invalid version %d", version)
+	}
+
+	current, err := m.provider.GetDBVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database version: %w", err)
+	}
+
+	var results []*goose.MigrationResult
+	if version >= current {
+		results, err = m.provider.UpTo(ctx, version)
+	} else {
+		results, err = m.provider.DownTo(ctx, version)
+	}
+	if err != nil {
+		return results, fmt.Errorf("failed to migrate to version %d: %w", version, err)
+	}
+
+	return results, nil
+}
+
+// Status returns all known migrations in the order of versions.
+func (m *Migrator) Status(ctx context.Context) ([]*goose.MigrationStatus, error) {
+	statuses, err := m.provider.Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get migration status: %w", err)
+	}
+
+	return statuses, nil
+}
+
+// Version returns the last applied migration, 0 if there are none.
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	version, err := m.provider.GetDBVersion(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get database version: %w", err)
+	}
+
+	return version, nil
+}
+
+// HasPending reports whether there are migrations which are not applied.
+func (m *Migrator) HasPending(ctx context.Context) (bool, error) {
+	pending, err := m.provider.HasPending(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to check pending migrations: %w", err)
+	}
+
+	return pending, nil
+}
+
+// Check reports ErrPending unless all migrations are applied, so that the migrator can serve as a readiness check.
+func (m *Migrator) Check(ctx context.Context) error {
+	pending, err := m.HasPending(ctx)
+	if err != nil {
+		return err
+	}
+	if pending {
+		return ErrPending
+	}
+
+	return nil
+}
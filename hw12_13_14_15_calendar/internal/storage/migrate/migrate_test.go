@@ -0,0 +1,146 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/pressly/goose/v3"
+	_ "modernc.org/sqlite"
+)
+
+func newTestMigrator(t *testing.T) (*Migrator, *sql.DB) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v, want nil", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migration := func(table string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte("-- +goose Up\nCREATE TABLE " + table + " (id INTEGER);\n\n" +
+			"-- +goose Down\nDROP TABLE " + table + ";\n")}
+	}
+	fsys := fstest.MapFS{
+		"1_create_a.sql": migration("a"),
+		"2_create_b.sql": migration("b"),
+		"3_create_c.sql": migration("c"),
+	}
+
+	m, err := New(db, goose.DialectSQLite3, fsys)
+	if err != nil {
+		t.Fatalf("New() error = %v, want nil", err)
+	}
+
+	return m, db
+}
+
+func expectVersion(ctx context.Context, t *testing.T, m *Migrator, want int64) {
+	t.Helper()
+
+	got, err := m.Version(ctx)
+	if err != nil {
+		t.Fatalf("Version() error = %v, want nil", err)
+	}
+	if got != want {
+		t.Errorf("Version() = %d, want %d", got, want)
+	}
+}
+
+func expectTables(t *testing.T, db *sql.DB, want int) {
+	t.Helper()
+
+	var got int
+	err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name IN ('a', 'b', 'c')`).
+		Scan(&got)
+	if err != nil {
+		t.Fatalf("failed to count tables: %v", err)
+	}
+	if got != want {
+		t.Errorf("tables = %d, want %d", got, want)
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	m, db := newTestMigrator(t)
+
+	if err := m.Check(ctx); !errors.Is(err, ErrPending) {
+		t.Errorf("Check() before Up() error = %v, want %v", err, ErrPending)
+	}
+
+	results, err := m.Up(ctx)
+	if err != nil {
+		t.Fatalf("Up() error = %v, want nil", err)
+	}
+	if err := m.Check(ctx); err != nil {
+		t.Errorf("Check() after Up() error = %v, want nil", err)
+	}
+	if len(results) != 3 {
+		t.Errorf("Up() applied %d migrations, want 3", len(results))
+	}
+	expectVersion(ctx, t, m, 3)
+	expectTables(t, db, 3)
+
+	if results, err = m.Up(ctx); err != nil || len(results) != 0 {
+		t.Errorf("Up() again = %d migrations, %v, want 0, nil", len(results), err)
+	}
+
+	if _, err := m.Down(ctx); err != nil {
+		t.Fatalf("Down() error = %v, want nil", err)
+	}
+	expectVersion(ctx, t, m, 2)
+	expectTables(t, db, 2)
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() error = %v, want nil", err)
+	}
+	wantStates := []goose.State{goose.StateApplied, goose.StateApplied, goose.StatePending}
+	if len(statuses) != len(wantStates) {
+		t.Fatalf("Status() returned %d migrations, want %d", len(statuses), len(wantStates))
+	}
+	for i, status := range statuses {
+		if status.State != wantStates[i] {
+			t.Errorf("Status()[%d] = %s, want %s", i, status.State, wantStates[i])
+		}
+	}
+
+	results, err = m.Redo(ctx)
+	if err != nil {
+		t.Fatalf("Redo() error = %v, want nil", err)
+	}
+	if len(results) != 2 || results[0].Source.Version != 2 || results[1].Source.Version != 2 {
+		t.Errorf("Redo() = %v, want migration 2 rolled back and applied", results)
+	}
+	expectVersion(ctx, t, m, 2)
+
+	if _, err := m.To(ctx, 0); err != nil {
+		t.Fatalf("To(0) error = %v, want nil", err)
+	}
+	expectVersion(ctx, t, m, 0)
+	expectTables(t, db, 0)
+
+	if _, err := m.To(ctx, 3); err != nil {
+		t.Fatalf("To(3) error = %v, want nil", err)
+	}
+	expectVersion(ctx, t, m, 3)
+
+	if _, err := m.To(ctx, 1); err != nil {
+		t.Fatalf("To(1) error = %v, want nil", err)
+	}
+	expectVersion(ctx, t, m, 1)
+	expectTables(t, db, 1)
+}
+
+func TestMigrator_DownWithoutMigrations(t *testing.T) {
+	m, _ := newTestMigrator(t)
+
+	if _, err := m.Down(context.Background()); err == nil {
+		t.Error("Down() error = nil, want error")
+	}
+}
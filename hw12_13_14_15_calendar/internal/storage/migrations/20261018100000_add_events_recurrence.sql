@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE events
+    ADD COLUMN rrule TEXT,
+    ADD COLUMN exdates TIMESTAMPTZ[],
+    -- Конец последнего повторения, NULL для бесконечных серий.
+    ADD COLUMN recurrence_end TIMESTAMPTZ;
+
+CREATE INDEX idx_events_recurring ON events(start_time) WHERE rrule IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_events_recurring;
+
+ALTER TABLE events
+    DROP COLUMN recurrence_end,
+    DROP COLUMN exdates,
+    DROP COLUMN rrule;
+-- +goose StatementEnd
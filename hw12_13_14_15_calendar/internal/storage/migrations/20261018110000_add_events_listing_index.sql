@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Индекс для постраничного вывода событий владельца (keyset по start_time, id)
+CREATE INDEX idx_events_owner_start_id ON events(owner_id, start_time, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_events_owner_start_id;
+-- +goose StatementEnd
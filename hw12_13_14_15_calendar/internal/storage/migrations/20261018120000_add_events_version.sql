@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Версия события для оптимистичной блокировки, увеличивается при каждом изменении.
+ALTER TABLE events ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE events DROP COLUMN version;
+-- +goose StatementEnd
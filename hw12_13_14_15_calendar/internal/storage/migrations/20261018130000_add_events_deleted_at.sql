@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Время перемещения события в корзину, NULL для неудалённых событий.
+ALTER TABLE events ADD COLUMN deleted_at TIMESTAMPTZ;
+CREATE INDEX idx_events_deleted_at ON events(deleted_at) WHERE deleted_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_events_deleted_at;
+ALTER TABLE events DROP COLUMN deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE event_attendees (
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    status TEXT NOT NULL DEFAULT 'needs-action'
+        CHECK (status IN ('needs-action', 'accepted', 'declined', 'tentative')),
+    invited_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (event_id, user_id)
+);
+
+-- Индекс для выборки событий, в которых участвует пользователь
+CREATE INDEX idx_event_attendees_user ON event_attendees(user_id, event_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE event_attendees;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Настройки пользователя, time_zone - имя часового пояса IANA
+CREATE TABLE user_settings (
+    user_id UUID PRIMARY KEY,
+    time_zone TEXT NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE user_settings;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- all_day - событие на весь день, даты хранятся как полночь UTC
+ALTER TABLE events ADD COLUMN all_day BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE events DROP COLUMN all_day;
+-- +goose StatementEnd
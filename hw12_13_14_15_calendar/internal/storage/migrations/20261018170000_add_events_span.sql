@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Время от начала события до конца последнего повторения, бесконечные серии не ограничены сверху.
+-- События без длительности занимают одну точку и попадают в периоды, в которых начинаются.
+ALTER TABLE events ADD COLUMN span TSTZRANGE GENERATED ALWAYS AS (
+    CASE
+        WHEN rrule IS NULL AND end_time > start_time THEN TSTZRANGE(start_time, end_time)
+        WHEN rrule IS NULL THEN TSTZRANGE(start_time, start_time, '[]')
+        WHEN recurrence_end IS NULL THEN TSTZRANGE(start_time, NULL)
+        WHEN recurrence_end > start_time THEN TSTZRANGE(start_time, recurrence_end)
+        ELSE TSTZRANGE(start_time, start_time, '[]')
+    END
+) STORED;
+
+CREATE INDEX idx_events_span ON events USING GIST (span) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_events_span;
+
+ALTER TABLE events DROP COLUMN span;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- time_zone - часовой пояс IANA, в котором повторяется серия, пустая строка означает UTC.
+-- Существующим событиям без повторений назначается часовой пояс владельца. Серии остаются в UTC,
+-- иначе их recurrence_end и границы пришлось бы пересчитать
+ALTER TABLE events ADD COLUMN time_zone TEXT NOT NULL DEFAULT '';
+
+UPDATE events SET time_zone = user_settings.time_zone
+FROM user_settings
+WHERE user_settings.user_id = events.owner_id AND NOT events.all_day
+    AND events.rrule IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE events DROP COLUMN time_zone;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Индексы для удаления старых событий: у событий без повторений конец - end_time, у серий - recurrence_end
+CREATE INDEX idx_events_single_end ON events(end_time) WHERE rrule IS NULL;
+
+CREATE INDEX idx_events_recurrence_end ON events(recurrence_end) WHERE rrule IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_events_recurrence_end;
+
+DROP INDEX idx_events_single_end;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+package storage
+
+import "time"
+
+type Notification struct {
+	EventID string    `json:"eventId"`
+	Title   string    `json:"title"`
+	Date    time.Time `json:"date"`
+	OwnerID string    `json:"ownerId"`
+}
+
+func NewNotification(event Event) Notification {
+	return Notification{
+		EventID: event.ID,
+		Title:   event.Title,
+		Date:    event.StartTime,
+		OwnerID: event.OwnerID,
+	}
+}
@@ -1,34 +1,51 @@
 package sqlstorage
 
 import (
+	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 
-	"github.com/lib/pq"
-	"github.com/pressly/goose"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/migrate"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/migrations"
+	_ "github.com/jackc/pgx/v5/stdlib" // Registers the pgx driver for database/sql used by goose.
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
 )
 
-func Migrate(dsn string, retryWithoutSSL bool) error {
-	db, err := sql.Open("postgres", dsn)
+// OpenDB opens a database/sql connection, migrations are not applied through pgxpool.
+func OpenDB(dsn string) (*sql.DB, error) {
+	db, err := sql.Open("pgx", dsn)
 	if err != nil {
-		return fmt.Errorf("failed to open connection to database: %w", err)
+		return nil, fmt.Errorf("failed to open connection to database: %w", err)
+	}
+
+	return db, nil
+}
+
+// NewMigrator creates a migrator of the embedded migrations. Instances migrating the same database
+// at once wait for each other on an advisory lock.
+func NewMigrator(db *sql.DB) (*migrate.Migrator, error) {
+	locker, err := lock.NewPostgresSessionLocker()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migration lock: %w", err)
 	}
-	defer db.Close()
 
-	if err := goose.SetDialect("postgres"); err != nil {
-		return fmt.Errorf("failed to set dialect: %w", err)
+	return migrate.New(db, goose.DialectPostgres, migrations.FS, goose.WithSessionLocker(locker))
+}
+
+// Migrate applies all pending migrations.
+func Migrate(ctx context.Context, dsn string) error {
+	db, err := OpenDB(dsn)
+	if err != nil {
+		return err
 	}
+	defer db.Close()
 
-	if err := goose.Up(db, "internal/storage/migrations"); err != nil {
-		if errors.Is(err, pq.ErrSSLNotSupported) && !retryWithoutSSL {
-			err := Migrate(dsn+"?sslmode=disable", true)
-			if err != nil {
-				return fmt.Errorf("failed to run migrations without SSL: %w", err)
-			}
-		} else {
-			return fmt.Errorf("failed to run migrations: %w", err)
-		}
+	m, err := NewMigrator(db)
+	if err != nil {
+		return err
 	}
-	return nil
+
+	_, err = m.Up(ctx)
+	return err
 }
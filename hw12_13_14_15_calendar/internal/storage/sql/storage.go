@@ -2,13 +2,20 @@ package sqlstorage
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/recurrence"
 	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+const eventColumns = `id, title, start_time, end_time, description, owner_id, notify_before, rrule, exdates, version,
+	deleted_at, all_day, time_zone`
+
 type Storage struct {
 	db *pgxpool.Pool
 }
@@ -18,37 +25,62 @@ func New(db *pgxpool.Pool) *Storage {
 }
 
 func (s *Storage) CreateEvent(ctx context.Context, params storage.CreateOrUpdateEventParams) (*storage.Event, error) {
+	recurrenceEnd, err := seriesEnd(params.RecurrenceRule, params.StartTime, params.EndTime, params.RecurrenceExceptions,
+		storage.SeriesLocation(params.TimeZone, params.AllDay))
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		INSERT INTO events (title, start_time, end_time, description, owner_id, notify_before)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		ON CONFLICT (id) DO NOTHING`
+		INSERT INTO events (title, start_time, end_time, description, owner_id, notify_before, rrule, exdates,
+			recurrence_end, all_day, time_zone)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (id) DO NOTHING
+		RETURNING ` + eventColumns
 
-	_, err := s.db.Exec(ctx, query, params.Title, params.StartTime, params.EndTime, params.Description, params.OwnerID,
-		params.NotifyBefore)
+	event, err := scanEvent(s.db.QueryRow(ctx, query, params.Title, params.StartTime, params.EndTime,
+		params.Description, params.OwnerID, params.NotifyBefore, params.RecurrenceRule, params.RecurrenceExceptions,
+		recurrenceEnd, params.AllDay, params.TimeZone))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrEventAlreadyExists
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create event: %w", err)
 	}
-	return nil, nil
+
+	return &event, nil
 }
 
 func (s *Storage) GetEvent(ctx context.Context, id string) (*storage.Event, error) {
 	query := `
-		SELECT id, title, start_time, end_time, description, owner_id, notify_before
-		FROM events 
-		WHERE id = $1`
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE id = $1 AND deleted_at IS NULL`
 
-	var event storage.Event
-
-	err := s.db.QueryRow(ctx, query, id).Scan(&event.ID, &event.Title, &event.StartTime, &event.EndTime,
-		&event.Description, &event.OwnerID, &event.NotifyBefore)
+	event, err := scanEvent(s.db.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrEventNotFound
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get event: %w", err)
 	}
 
+	if err := s.loadEventAttendees(ctx, &event); err != nil {
+		return nil, err
+	}
+
 	return &event, nil
 }
 
-func (s *Storage) UpdateEvent(ctx context.Context, event storage.Event) error {
+// UpdateEvent replaces the event and increments its version.
+// If event.Version is not zero, the event is updated only if it still has this version.
+func (s *Storage) UpdateEvent(ctx context.Context, event storage.Event) (*storage.Event, error) {
+	recurrenceEnd, err := seriesEnd(event.RecurrenceRule, event.StartTime, event.EndTime, event.RecurrenceExceptions,
+		event.Location())
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
 		UPDATE events
 		SET title = $2,
@@ -56,26 +88,196 @@ func (s *Storage) UpdateEvent(ctx context.Context, event storage.Event) error {
 		end_time = $4,
 		description = $5,
 		owner_id = $6,
-		notify_before = $7
-		WHERE id = $1`
+		notify_before = $7,
+		rrule = $8,
+		exdates = $9,
+		recurrence_end = $10,
+		all_day = $12,
+		time_zone = $13,
+		version = version + 1
+		WHERE id = $1 AND deleted_at IS NULL AND ($11::BIGINT = 0 OR version = $11)
+		RETURNING version`
 
-	result, err := s.db.Exec(ctx, query, event.ID, event.Title, event.StartTime, event.EndTime, event.Description,
-		event.OwnerID, event.NotifyBefore)
+	err = s.db.QueryRow(ctx, query, event.ID, event.Title, event.StartTime, event.EndTime, event.Description,
+		event.OwnerID, event.NotifyBefore, event.RecurrenceRule, event.RecurrenceExceptions, recurrenceEnd,
+		event.Version, event.AllDay, event.TimeZone).Scan(&event.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, s.updateFailure(ctx, event.ID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update event: %w", err)
+	}
+
+	event.Attendees = nil
+	if err := s.loadEventAttendees(ctx, &event); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// updateFailure tells whether a conditional update failed because the event is missing or has another version.
+func (s *Storage) updateFailure(ctx context.Context, id string) error {
+	exists, err := s.eventExists(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to update event: %w", err)
 	}
 
+	if !exists {
+		return storage.ErrEventNotFound
+	}
+
+	return storage.ErrVersionConflict
+}
+
+// eventExists reports whether the event exists and is not in the trash.
+func (s *Storage) eventExists(ctx context.Context, id string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM events WHERE id = $1 AND deleted_at IS NULL)`
+
+	var exists bool
+	err := s.db.QueryRow(ctx, query, id).Scan(&exists)
+
+	return exists, err
+}
+
+// AddAttendee invites the user to the event, inviting an attendee again keeps the status.
+func (s *Storage) AddAttendee(ctx context.Context, eventID, userID string) error {
+	query := `
+		INSERT INTO event_attendees (event_id, user_id)
+		SELECT id, $2
+		FROM events
+		WHERE id = $1 AND deleted_at IS NULL
+		ON CONFLICT (event_id, user_id) DO NOTHING`
+
+	result, err := s.db.Exec(ctx, query, eventID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to add attendee: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return s.attendeeFailure(ctx, eventID, nil)
+	}
+
+	return nil
+}
+
+func (s *Storage) RemoveAttendee(ctx context.Context, eventID, userID string) error {
+	query := `
+		DELETE FROM event_attendees a
+		USING events e
+		WHERE a.event_id = $1 AND a.user_id = $2
+		AND e.id = a.event_id AND e.deleted_at IS NULL`
+
+	result, err := s.db.Exec(ctx, query, eventID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove attendee: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return s.attendeeFailure(ctx, eventID, storage.ErrAttendeeNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) SetAttendeeStatus(
+	ctx context.Context,
+	eventID, userID string,
+	status storage.AttendeeStatus,
+) error {
+	query := `
+		UPDATE event_attendees a
+		SET status = $3
+		FROM events e
+		WHERE a.event_id = $1 AND a.user_id = $2
+		AND e.id = a.event_id AND e.deleted_at IS NULL`
+
+	result, err := s.db.Exec(ctx, query, eventID, userID, string(status))
+	if err != nil {
+		return fmt.Errorf("failed to set attendee status: %w", err)
+	}
+
 	if result.RowsAffected() == 0 {
+		return s.attendeeFailure(ctx, eventID, storage.ErrAttendeeNotFound)
+	}
+
+	return nil
+}
+
+// attendeeFailure returns ErrEventNotFound if the event is missing, otherwise errIfExists.
+func (s *Storage) attendeeFailure(ctx context.Context, eventID string, errIfExists error) error {
+	exists, err := s.eventExists(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to check event: %w", err)
+	}
+
+	if !exists {
 		return storage.ErrEventNotFound
 	}
 
+	return errIfExists
+}
+
+func (s *Storage) loadEventAttendees(ctx context.Context, event *storage.Event) error {
+	events := []storage.Event{*event}
+	if err := s.loadAttendees(ctx, events); err != nil {
+		return err
+	}
+
+	event.Attendees = events[0].Attendees
+	return nil
+}
+
+// loadAttendees fills attendees of the events with one query.
+func (s *Storage) loadAttendees(ctx context.Context, events []storage.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(events))
+	index := make(map[string]int, len(events))
+	for i, e := range events {
+		ids[i] = e.ID
+		index[e.ID] = i
+	}
+
+	query := `
+		SELECT event_id, user_id, status
+		FROM event_attendees
+		WHERE event_id = ANY($1)
+		ORDER BY invited_at, user_id`
+
+	rows, err := s.db.Query(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get attendees: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var eventID, userID, status string
+		if err := rows.Scan(&eventID, &userID, &status); err != nil {
+			return fmt.Errorf("failed to scan attendee: %w", err)
+		}
+
+		i := index[eventID]
+		events[i].Attendees = append(events[i].Attendees,
+			storage.Attendee{UserID: userID, Status: storage.AttendeeStatus(status)})
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows error: %w", err)
+	}
+
 	return nil
 }
 
+// DeleteEvent moves the event to the trash.
 func (s *Storage) DeleteEvent(ctx context.Context, id string) error {
 	query := `
-		DELETE FROM events
-		WHERE id = $1`
+		UPDATE events
+		SET deleted_at = NOW(),
+		version = version + 1
+		WHERE id = $1 AND deleted_at IS NULL`
 
 	result, err := s.db.Exec(ctx, query, id)
 	if err != nil {
@@ -89,52 +291,379 @@ func (s *Storage) DeleteEvent(ctx context.Context, id string) error {
 	return nil
 }
 
-func (s *Storage) GetAllEvents(ctx context.Context) ([]storage.Event, error) {
+// GetDeletedEvent returns the event if it is in the trash.
+func (s *Storage) GetDeletedEvent(ctx context.Context, id string) (*storage.Event, error) {
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	event, err := scanEvent(s.db.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted event: %w", err)
+	}
+
+	if err := s.loadEventAttendees(ctx, &event); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// GetDeletedEvents returns events in the trash of the owner, or of all owners if ownerID is empty.
+// Recently deleted events go first.
+func (s *Storage) GetDeletedEvents(ctx context.Context, ownerID string) ([]storage.Event, error) {
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE deleted_at IS NOT NULL`
+
+	var args []any
+	if ownerID != "" {
+		query += `
+		AND owner_id = $1`
+		args = append(args, ownerID)
+	}
+
+	query += `
+		ORDER BY deleted_at DESC`
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted events: %w", err)
+	}
+	defer rows.Close()
+
+	return s.collectEventsWithAttendees(ctx, rows)
+}
+
+// RestoreEvent moves the event out of the trash.
+func (s *Storage) RestoreEvent(ctx context.Context, id string) (*storage.Event, error) {
+	query := `
+		UPDATE events
+		SET deleted_at = NULL,
+		version = version + 1
+		WHERE id = $1 AND deleted_at IS NOT NULL
+		RETURNING ` + eventColumns
+
+	event, err := scanEvent(s.db.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore event: %w", err)
+	}
+
+	if err := s.loadEventAttendees(ctx, &event); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// PurgeDeletedEvents removes events moved to the trash before t.
+func (s *Storage) PurgeDeletedEvents(ctx context.Context, t time.Time) (int64, error) {
+	query := `
+		DELETE FROM events
+		WHERE deleted_at < $1`
+
+	result, err := s.db.Exec(ctx, query, t)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted events: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// GetAllEvents returns events of the owner, or events of all owners if ownerID is empty.
+func (s *Storage) GetAllEvents(ctx context.Context, ownerID string) ([]storage.Event, error) {
 	query := `
-		SELECT id, title, start_time, end_time, description, owner_id, notify_before
-		FROM events`
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE deleted_at IS NULL`
+
+	var args []any
+	if ownerID != "" {
+		query += `
+		AND owner_id = $1`
+		args = append(args, ownerID)
+	}
 
-	rows, err := s.db.Query(ctx, query)
+	rows, err := s.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get events: %w", err)
 	}
 	defer rows.Close()
 
-	var events []storage.Event
-	for rows.Next() {
-		var event storage.Event
-		if err := rows.Scan(&event.ID, &event.Title, &event.StartTime, &event.EndTime, &event.Description,
-			&event.OwnerID, &event.NotifyBefore); err != nil {
-			return nil, fmt.Errorf("failed to scan event: %w", err)
+	return s.collectEventsWithAttendees(ctx, rows)
+}
+
+// ListEvents returns a page of events using keyset pagination on the sort key and ID.
+// Titles are compared byte-wise so that pages do not depend on the database collation.
+func (s *Storage) ListEvents(ctx context.Context, query storage.ListEventsQuery) (storage.EventPage, error) {
+	cursor, err := query.DecodeCursor()
+	if err != nil {
+		return storage.EventPage{}, err
+	}
+
+	var (
+		conditions = []string{"deleted_at IS NULL"}
+		args       []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if query.OwnerID != "" {
+		conditions = append(conditions, "owner_id = "+arg(query.OwnerID))
+	}
+	if query.From != nil {
+		conditions = append(conditions, "start_time >= "+arg(*query.From))
+	}
+	if query.To != nil {
+		conditions = append(conditions, "start_time < "+arg(*query.To))
+	}
+	if query.Title != "" {
+		conditions = append(conditions, `title ILIKE '%' || `+arg(escapeLike(query.Title))+` || '%'`)
+	}
+
+	sortKey := "start_time"
+	if query.SortBy == storage.SortByTitle {
+		sortKey = `title COLLATE "C"`
+	}
+
+	order, cmp := "ASC", ">"
+	if query.Descending {
+		order, cmp = "DESC", "<"
+	}
+
+	if cursor != nil {
+		var key string
+		if query.SortBy == storage.SortByTitle {
+			key = arg(*cursor.Title) + ` COLLATE "C"`
+		} else {
+			key = arg(*cursor.StartTime)
 		}
-		events = append(events, event)
+		conditions = append(conditions, fmt.Sprintf("(%s, id) %s (%s, %s)", sortKey, cmp, key, arg(cursor.ID)))
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("rows error: %w", err)
+	sql := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE ` + strings.Join(conditions, " AND ")
+	sql += fmt.Sprintf(`
+		ORDER BY %s %s, id %s
+		LIMIT %s`, sortKey, order, order, arg(query.PageSize+1))
+
+	rows, err := s.db.Query(ctx, sql, args...)
+	if err != nil {
+		return storage.EventPage{}, fmt.Errorf("failed to list events: %w", err)
 	}
+	defer rows.Close()
 
-	return events, nil
+	events, err := s.collectEventsWithAttendees(ctx, rows)
+	if err != nil {
+		return storage.EventPage{}, err
+	}
+
+	page := storage.EventPage{Events: events}
+	if len(events) > query.PageSize {
+		page.Events = events[:query.PageSize]
+		page.NextCursor = query.NewCursor(page.Events[query.PageSize-1])
+	}
+
+	return page, nil
 }
 
-func (s *Storage) GetEventsByPeriod(ctx context.Context, start, end time.Time) ([]storage.Event, error) {
+// GetEventsByPeriod returns single events intersecting [start, end) and recurring events
+// which may have occurrences there. Recurring events are not expanded.
+// Events owned or attended by the user with ownerID are returned, or events of all owners if ownerID is empty.
+func (s *Storage) GetEventsByPeriod(
+	ctx context.Context,
+	ownerID string,
+	start, end time.Time,
+) ([]storage.Event, error) {
 	query := `
-        SELECT id, title, start_time, end_time, description, owner_id, notify_before
+        SELECT ` + eventColumns + `
         FROM events
-        WHERE start_time >= $1 AND start_time < $2
-        ORDER BY start_time`
+        WHERE deleted_at IS NULL
+        AND span && TSTZRANGE($1, $2)`
+
+	args := []any{start, end}
+	if ownerID != "" {
+		query += `
+        AND (owner_id = $3 OR EXISTS (
+            SELECT 1 FROM event_attendees a
+            WHERE a.event_id = events.id AND a.user_id = $3 AND a.status <> 'declined'))`
+		args = append(args, ownerID)
+	}
+
+	query += `
+        ORDER BY start_time, id`
 
-	rows, err := s.db.Query(ctx, query, start, end)
+	rows, err := s.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get events by period: %w", err)
 	}
 	defer rows.Close()
 
+	return s.collectEventsWithAttendees(ctx, rows)
+}
+
+// GetOverlappingEvents returns single events of the owner intersecting [start, end) and recurring events
+// of the owner which may have occurrences there. Recurring events are not expanded.
+// Period queries match the span column, see the migration adding it.
+func (s *Storage) GetOverlappingEvents(
+	ctx context.Context,
+	ownerID string,
+	start, end time.Time,
+) ([]storage.Event, error) {
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE owner_id = $1
+		AND deleted_at IS NULL
+		AND span && TSTZRANGE($2, $3)
+		ORDER BY start_time, id`
+
+	rows, err := s.db.Query(ctx, query, ownerID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overlapping events: %w", err)
+	}
+	defer rows.Close()
+
+	return s.collectEventsWithAttendees(ctx, rows)
+}
+
+// GetBusyEvents returns events owned or attended by any of the users which intersect [start, end)
+// with a single query. Recurring events which may have occurrences there are returned unexpanded,
+// attendees are not loaded.
+func (s *Storage) GetBusyEvents(
+	ctx context.Context,
+	userIDs []string,
+	start, end time.Time,
+) ([]storage.Event, error) {
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE deleted_at IS NULL
+		AND span && TSTZRANGE($2, $3)
+		AND (owner_id = ANY($1::UUID[]) OR EXISTS (
+			SELECT 1 FROM event_attendees a
+			WHERE a.event_id = events.id AND a.user_id = ANY($1::UUID[]) AND a.status <> 'declined'))
+		ORDER BY start_time, id`
+
+	rows, err := s.db.Query(ctx, query, userIDs, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get busy events: %w", err)
+	}
+	defer rows.Close()
+
+	return collectEvents(rows)
+}
+
+// GetEventsToNotify returns single events to notify about in [from, to) and recurring events
+// with notifications which may have occurrences there. Recurring events are not expanded.
+func (s *Storage) GetEventsToNotify(ctx context.Context, from, to time.Time) ([]storage.Event, error) {
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE notify_before IS NOT NULL
+		AND deleted_at IS NULL
+		AND (
+			(rrule IS NULL AND start_time - notify_before >= $1 AND start_time - notify_before < $2)
+			OR (rrule IS NOT NULL AND start_time - notify_before < $2
+				AND (recurrence_end IS NULL OR recurrence_end > $1))
+		)
+		ORDER BY start_time`
+
+	rows, err := s.db.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events to notify: %w", err)
+	}
+	defer rows.Close()
+
+	return s.collectEventsWithAttendees(ctx, rows)
+}
+
+func (s *Storage) DeleteEventsBefore(ctx context.Context, t time.Time) (int64, error) {
+	query := `
+		DELETE FROM events
+		WHERE (rrule IS NULL AND end_time < $1)
+		OR (rrule IS NOT NULL AND recurrence_end < $1)`
+
+	result, err := s.db.Exec(ctx, query, t)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete events: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// GetTimeZone returns the default time zone of the user, empty if it is not set.
+func (s *Storage) GetTimeZone(ctx context.Context, userID string) (string, error) {
+	query := `
+		SELECT time_zone
+		FROM user_settings
+		WHERE user_id = $1`
+
+	var timeZone string
+	err := s.db.QueryRow(ctx, query, userID).Scan(&timeZone)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get time zone: %w", err)
+	}
+
+	return timeZone, nil
+}
+
+func (s *Storage) SetTimeZone(ctx context.Context, userID, timeZone string) error {
+	query := `
+		INSERT INTO user_settings (user_id, time_zone)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET time_zone = EXCLUDED.time_zone`
+
+	if _, err := s.db.Exec(ctx, query, userID, timeZone); err != nil {
+		return fmt.Errorf("failed to set time zone: %w", err)
+	}
+
+	return nil
+}
+
+func scanEvent(row pgx.Row) (storage.Event, error) {
+	var event storage.Event
+	err := row.Scan(&event.ID, &event.Title, &event.StartTime, &event.EndTime, &event.Description, &event.OwnerID,
+		&event.NotifyBefore, &event.RecurrenceRule, &event.RecurrenceExceptions, &event.Version,
+		&event.DeletedAt, &event.AllDay, &event.TimeZone)
+
+	return event, err
+}
+
+// collectEventsWithAttendees reads all events and then loads their attendees.
+func (s *Storage) collectEventsWithAttendees(ctx context.Context, rows pgx.Rows) ([]storage.Event, error) {
+	events, err := collectEvents(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.loadAttendees(ctx, events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func collectEvents(rows pgx.Rows) ([]storage.Event, error) {
 	var events []storage.Event
 	for rows.Next() {
-		var event storage.Event
-		if err := rows.Scan(&event.ID, &event.Title, &event.StartTime, &event.EndTime, &event.Description,
-			&event.OwnerID, &event.NotifyBefore); err != nil {
+		event, err := scanEvent(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan event: %w", err)
 		}
 		events = append(events, event)
@@ -146,3 +675,17 @@ func (s *Storage) GetEventsByPeriod(ctx context.Context, start, end time.Time) (
 
 	return events, nil
 }
+
+// seriesEnd returns the end of the last occurrence of the series recurring in loc, nil for endless series
+// and events which do not recur.
+func seriesEnd(rule *string, start, end time.Time, exdates []time.Time, loc *time.Location) (*time.Time, error) {
+	if rule == nil {
+		return nil, nil
+	}
+
+	return recurrence.SeriesEnd(*rule, start.In(loc), end, exdates)
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,71 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Время хранится в микросекундах Unix, длительность - в наносекундах
+CREATE TABLE events (
+    id TEXT PRIMARY KEY,
+    title TEXT NOT NULL,
+    start_time INTEGER NOT NULL,
+    end_time INTEGER NOT NULL,
+    description TEXT,
+    owner_id TEXT NOT NULL,
+    notify_before INTEGER,
+    rrule TEXT,
+    exdates TEXT,
+    recurrence_end INTEGER,
+    version INTEGER NOT NULL DEFAULT 1,
+    deleted_at INTEGER,
+    all_day INTEGER NOT NULL DEFAULT 0,
+    -- Конец времени, занятого событием, NULL для бесконечных серий.
+    -- У событий без длительности совпадает с началом
+    span_end INTEGER GENERATED ALWAYS AS (
+        CASE
+            WHEN rrule IS NULL THEN MAX(start_time, end_time)
+            WHEN recurrence_end IS NULL THEN NULL
+            ELSE MAX(start_time, recurrence_end)
+        END
+    ) STORED
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX idx_events_owner_start_id ON events(owner_id, start_time, id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX idx_events_start ON events(start_time) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE event_attendees (
+    event_id TEXT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'needs-action'
+        CHECK (status IN ('needs-action', 'accepted', 'declined', 'tentative')),
+    invited_at INTEGER NOT NULL,
+    PRIMARY KEY (event_id, user_id)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX idx_event_attendees_user ON event_attendees(user_id, event_id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE user_settings (
+    user_id TEXT PRIMARY KEY,
+    time_zone TEXT NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE user_settings;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE event_attendees;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE events;
+-- +goose StatementEnd
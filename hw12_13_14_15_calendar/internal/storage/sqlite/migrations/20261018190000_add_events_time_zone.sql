@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- time_zone - часовой пояс IANA, в котором повторяется серия, пустая строка означает UTC.
+-- Существующим событиям без повторений назначается часовой пояс владельца. Серии остаются в UTC,
+-- иначе их recurrence_end и границы пришлось бы пересчитать
+ALTER TABLE events ADD COLUMN time_zone TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+UPDATE events SET time_zone = COALESCE(
+    (SELECT time_zone FROM user_settings WHERE user_settings.user_id = events.owner_id), ''
+) WHERE all_day = 0 AND rrule IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE events DROP COLUMN time_zone;
+-- +goose StatementEnd
@@ -0,0 +1,7 @@
+// Package migrations contains SQLite migrations of the event storage.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
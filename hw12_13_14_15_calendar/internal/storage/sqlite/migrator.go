@@ -0,0 +1,66 @@
+package sqlitestorage
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/migrate"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/sqlite/migrations"
+	"github.com/pressly/goose/v3"
+	"modernc.org/sqlite"
+)
+
+func init() {
+	// SQLite lower() folds ASCII letters only, titles are searched ignoring case of all letters.
+	sqlite.MustRegisterDeterministicScalarFunction("unicode_lower", 1, unicodeLower)
+}
+
+// Open opens the database file in WAL mode, so readers are not blocked by the writer.
+// Transactions take the write lock at once and wait for it instead of failing with SQLITE_BUSY.
+func Open(path string) (*sql.DB, error) {
+	params := url.Values{}
+	for _, pragma := range []string{"journal_mode(WAL)", "synchronous(NORMAL)", "foreign_keys(ON)", "busy_timeout(5000)"} {
+		params.Add("_pragma", pragma)
+	}
+	params.Set("_txlock", "immediate")
+
+	db, err := sql.Open("sqlite", "file:"+path+"?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	return db, nil
+}
+
+// NewMigrator creates a migrator of the embedded migrations.
+func NewMigrator(db *sql.DB) (*migrate.Migrator, error) {
+	return migrate.New(db, goose.DialectSQLite3, migrations.FS)
+}
+
+// Migrate applies all pending migrations.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	m, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.Up(ctx)
+	return err
+}
+
+func unicodeLower(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+	if s, ok := args[0].(string); ok {
+		return strings.ToLower(s), nil
+	}
+
+	return args[0], nil
+}
@@ -0,0 +1,787 @@
+package sqlitestorage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/recurrence"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+	"github.com/google/uuid"
+)
+
+const eventColumns = `id, title, start_time, end_time, description, owner_id, notify_before, rrule, exdates, version,
+	deleted_at, all_day, time_zone`
+
+// inPeriod matches events intersecting [:start, :end), see span_end in the migration.
+// Events without duration intersect periods they start in.
+const inPeriod = `start_time < :end
+	AND (span_end IS NULL OR span_end > :start OR (span_end = start_time AND start_time >= :start))`
+
+type Storage struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Storage {
+	return &Storage{db: db}
+}
+
+func (s *Storage) CreateEvent(ctx context.Context, params storage.CreateOrUpdateEventParams) (*storage.Event, error) {
+	recurrenceEnd, err := seriesEnd(params.RecurrenceRule, params.StartTime, params.EndTime, params.RecurrenceExceptions,
+		storage.SeriesLocation(params.TimeZone, params.AllDay))
+	if err != nil {
+		return nil, err
+	}
+
+	exdates, err := encodeTimes(params.RecurrenceExceptions)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO events (id, title, start_time, end_time, description, owner_id, notify_before, rrule, exdates,
+			recurrence_end, all_day, time_zone)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING ` + eventColumns
+
+	event, err := scanEvent(s.db.QueryRowContext(ctx, query, uuid.New().String(), params.Title,
+		params.StartTime.UnixMicro(), params.EndTime.UnixMicro(), params.Description, params.OwnerID,
+		durationValue(params.NotifyBefore), params.RecurrenceRule, exdates, timeValue(recurrenceEnd), params.AllDay,
+		params.TimeZone))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create event: %w", err)
+	}
+
+	return &event, nil
+}
+
+func (s *Storage) GetEvent(ctx context.Context, id string) (*storage.Event, error) {
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE id = ? AND deleted_at IS NULL`
+
+	event, err := scanEvent(s.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if err := s.loadEventAttendees(ctx, &event); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// UpdateEvent replaces the event and increments its version.
+// If event.Version is not zero, the event is updated only if it still has this version.
+func (s *Storage) UpdateEvent(ctx context.Context, event storage.Event) (*storage.Event, error) {
+	recurrenceEnd, err := seriesEnd(event.RecurrenceRule, event.StartTime, event.EndTime, event.RecurrenceExceptions,
+		event.Location())
+	if err != nil {
+		return nil, err
+	}
+
+	exdates, err := encodeTimes(event.RecurrenceExceptions)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE events
+		SET title = :title,
+		start_time = :start_time,
+		end_time = :end_time,
+		description = :description,
+		owner_id = :owner_id,
+		notify_before = :notify_before,
+		rrule = :rrule,
+		exdates = :exdates,
+		recurrence_end = :recurrence_end,
+		all_day = :all_day,
+		time_zone = :time_zone,
+		version = version + 1
+		WHERE id = :id AND deleted_at IS NULL AND (:version = 0 OR version = :version)
+		RETURNING version`
+
+	err = s.db.QueryRowContext(ctx, query,
+		sql.Named("id", event.ID),
+		sql.Named("title", event.Title),
+		sql.Named("start_time", event.StartTime.UnixMicro()),
+		sql.Named("end_time", event.EndTime.UnixMicro()),
+		sql.Named("description", event.Description),
+		sql.Named("owner_id", event.OwnerID),
+		sql.Named("notify_before", durationValue(event.NotifyBefore)),
+		sql.Named("rrule", event.RecurrenceRule),
+		sql.Named("exdates", exdates),
+		sql.Named("recurrence_end", timeValue(recurrenceEnd)),
+		sql.Named("all_day", event.AllDay),
+		sql.Named("time_zone", event.TimeZone),
+		sql.Named("version", event.Version),
+	).Scan(&event.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, s.updateFailure(ctx, event.ID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update event: %w", err)
+	}
+
+	event.Attendees = nil
+	if err := s.loadEventAttendees(ctx, &event); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// updateFailure tells whether a conditional update failed because the event is missing or has another version.
+func (s *Storage) updateFailure(ctx context.Context, id string) error {
+	exists, err := s.eventExists(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to update event: %w", err)
+	}
+
+	if !exists {
+		return storage.ErrEventNotFound
+	}
+
+	return storage.ErrVersionConflict
+}
+
+// eventExists reports whether the event exists and is not in the trash.
+func (s *Storage) eventExists(ctx context.Context, id string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM events WHERE id = ? AND deleted_at IS NULL)`
+
+	var exists bool
+	err := s.db.QueryRowContext(ctx, query, id).Scan(&exists)
+
+	return exists, err
+}
+
+// AddAttendee invites the user to the event, inviting an attendee again keeps the status.
+func (s *Storage) AddAttendee(ctx context.Context, eventID, userID string) error {
+	query := `
+		INSERT INTO event_attendees (event_id, user_id, invited_at)
+		SELECT id, ?, ?
+		FROM events
+		WHERE id = ? AND deleted_at IS NULL
+		ON CONFLICT (event_id, user_id) DO NOTHING`
+
+	result, err := s.db.ExecContext(ctx, query, userID, time.Now().UnixMicro(), eventID)
+	if err != nil {
+		return fmt.Errorf("failed to add attendee: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to add attendee: %w", err)
+	}
+
+	if affected == 0 {
+		return s.attendeeFailure(ctx, eventID, nil)
+	}
+
+	return nil
+}
+
+func (s *Storage) RemoveAttendee(ctx context.Context, eventID, userID string) error {
+	query := `
+		DELETE FROM event_attendees
+		WHERE event_id = ? AND user_id = ?
+		AND EXISTS (SELECT 1 FROM events e WHERE e.id = event_attendees.event_id AND e.deleted_at IS NULL)`
+
+	result, err := s.db.ExecContext(ctx, query, eventID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove attendee: %w", err)
+	}
+
+	return s.attendeeResult(ctx, eventID, result)
+}
+
+func (s *Storage) SetAttendeeStatus(
+	ctx context.Context,
+	eventID, userID string,
+	status storage.AttendeeStatus,
+) error {
+	query := `
+		UPDATE event_attendees
+		SET status = ?
+		WHERE event_id = ? AND user_id = ?
+		AND EXISTS (SELECT 1 FROM events e WHERE e.id = event_attendees.event_id AND e.deleted_at IS NULL)`
+
+	result, err := s.db.ExecContext(ctx, query, string(status), eventID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to set attendee status: %w", err)
+	}
+
+	return s.attendeeResult(ctx, eventID, result)
+}
+
+// attendeeResult returns ErrAttendeeNotFound or ErrEventNotFound if no attendee was changed.
+func (s *Storage) attendeeResult(ctx context.Context, eventID string, result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to change attendee: %w", err)
+	}
+
+	if affected == 0 {
+		return s.attendeeFailure(ctx, eventID, storage.ErrAttendeeNotFound)
+	}
+
+	return nil
+}
+
+// attendeeFailure returns ErrEventNotFound if the event is missing, otherwise errIfExists.
+func (s *Storage) attendeeFailure(ctx context.Context, eventID string, errIfExists error) error {
+	exists, err := s.eventExists(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to check event: %w", err)
+	}
+
+	if !exists {
+		return storage.ErrEventNotFound
+	}
+
+	return errIfExists
+}
+
+func (s *Storage) loadEventAttendees(ctx context.Context, event *storage.Event) error {
+	events := []storage.Event{*event}
+	if err := s.loadAttendees(ctx, events); err != nil {
+		return err
+	}
+
+	event.Attendees = events[0].Attendees
+	return nil
+}
+
+// loadAttendees fills attendees of the events with one query.
+func (s *Storage) loadAttendees(ctx context.Context, events []storage.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(events))
+	index := make(map[string]int, len(events))
+	for i, e := range events {
+		ids[i] = e.ID
+		index[e.ID] = i
+	}
+
+	idList, err := json.Marshal(ids)
+	if err != nil {
+		return fmt.Errorf("failed to encode event IDs: %w", err)
+	}
+
+	query := `
+		SELECT event_id, user_id, status
+		FROM event_attendees
+		WHERE event_id IN (SELECT value FROM json_each(?))
+		ORDER BY invited_at, user_id`
+
+	rows, err := s.db.QueryContext(ctx, query, string(idList))
+	if err != nil {
+		return fmt.Errorf("failed to get attendees: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var eventID, userID, status string
+		if err := rows.Scan(&eventID, &userID, &status); err != nil {
+			return fmt.Errorf("failed to scan attendee: %w", err)
+		}
+
+		i := index[eventID]
+		events[i].Attendees = append(events[i].Attendees,
+			storage.Attendee{UserID: userID, Status: storage.AttendeeStatus(status)})
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteEvent moves the event to the trash.
+func (s *Storage) DeleteEvent(ctx context.Context, id string) error {
+	query := `
+		UPDATE events
+		SET deleted_at = ?,
+		version = version + 1
+		WHERE id = ? AND deleted_at IS NULL`
+
+	result, err := s.db.ExecContext(ctx, query, time.Now().UnixMicro(), id)
+	if err != nil {
+		return fmt.Errorf("failed to delete event: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete event: %w", err)
+	}
+
+	if affected == 0 {
+		return storage.ErrEventNotFound
+	}
+
+	return nil
+}
+
+// GetDeletedEvent returns the event if it is in the trash.
+func (s *Storage) GetDeletedEvent(ctx context.Context, id string) (*storage.Event, error) {
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE id = ? AND deleted_at IS NOT NULL`
+
+	event, err := scanEvent(s.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted event: %w", err)
+	}
+
+	if err := s.loadEventAttendees(ctx, &event); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// GetDeletedEvents returns events in the trash of the owner, or of all owners if ownerID is empty.
+// Recently deleted events go first.
+func (s *Storage) GetDeletedEvents(ctx context.Context, ownerID string) ([]storage.Event, error) {
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE deleted_at IS NOT NULL`
+
+	var args []any
+	if ownerID != "" {
+		query += `
+		AND owner_id = ?`
+		args = append(args, ownerID)
+	}
+
+	query += `
+		ORDER BY deleted_at DESC`
+
+	return s.queryEventsWithAttendees(ctx, "failed to get deleted events", query, args...)
+}
+
+// RestoreEvent moves the event out of the trash.
+func (s *Storage) RestoreEvent(ctx context.Context, id string) (*storage.Event, error) {
+	query := `
+		UPDATE events
+		SET deleted_at = NULL,
+		version = version + 1
+		WHERE id = ? AND deleted_at IS NOT NULL
+		RETURNING ` + eventColumns
+
+	event, err := scanEvent(s.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore event: %w", err)
+	}
+
+	if err := s.loadEventAttendees(ctx, &event); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// PurgeDeletedEvents removes events moved to the trash before t.
+func (s *Storage) PurgeDeletedEvents(ctx context.Context, t time.Time) (int64, error) {
+	query := `
+		DELETE FROM events
+		WHERE deleted_at < ?`
+
+	return s.exec(ctx, "failed to purge deleted events", query, t.UnixMicro())
+}
+
+// GetAllEvents returns events of the owner, or events of all owners if ownerID is empty.
+func (s *Storage) GetAllEvents(ctx context.Context, ownerID string) ([]storage.Event, error) {
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE deleted_at IS NULL`
+
+	var args []any
+	if ownerID != "" {
+		query += `
+		AND owner_id = ?`
+		args = append(args, ownerID)
+	}
+
+	return s.queryEventsWithAttendees(ctx, "failed to get events", query, args...)
+}
+
+// ListEvents returns a page of events using keyset pagination on the sort key and ID.
+// Titles are compared byte-wise, as in the other storages.
+func (s *Storage) ListEvents(ctx context.Context, query storage.ListEventsQuery) (storage.EventPage, error) {
+	cursor, err := query.DecodeCursor()
+	if err != nil {
+		return storage.EventPage{}, err
+	}
+
+	var (
+		conditions = []string{"deleted_at IS NULL"}
+		args       []any
+	)
+
+	if query.OwnerID != "" {
+		conditions = append(conditions, "owner_id = ?")
+		args = append(args, query.OwnerID)
+	}
+	if query.From != nil {
+		conditions = append(conditions, "start_time >= ?")
+		args = append(args, query.From.UnixMicro())
+	}
+	if query.To != nil {
+		conditions = append(conditions, "start_time < ?")
+		args = append(args, query.To.UnixMicro())
+	}
+	if query.Title != "" {
+		conditions = append(conditions, "instr(unicode_lower(title), ?) > 0")
+		args = append(args, strings.ToLower(query.Title))
+	}
+
+	sortKey := "start_time"
+	if query.SortBy == storage.SortByTitle {
+		sortKey = "title"
+	}
+
+	order, cmp := "ASC", ">"
+	if query.Descending {
+		order, cmp = "DESC", "<"
+	}
+
+	if cursor != nil {
+		conditions = append(conditions, fmt.Sprintf("(%s, id) %s (?, ?)", sortKey, cmp))
+		if query.SortBy == storage.SortByTitle {
+			args = append(args, *cursor.Title, cursor.ID)
+		} else {
+			args = append(args, cursor.StartTime.UnixMicro(), cursor.ID)
+		}
+	}
+
+	statement := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE ` + strings.Join(conditions, " AND ")
+	statement += fmt.Sprintf(`
+		ORDER BY %s %s, id %s
+		LIMIT ?`, sortKey, order, order)
+	args = append(args, query.PageSize+1)
+
+	events, err := s.queryEventsWithAttendees(ctx, "failed to list events", statement, args...)
+	if err != nil {
+		return storage.EventPage{}, err
+	}
+
+	page := storage.EventPage{Events: events}
+	if len(events) > query.PageSize {
+		page.Events = events[:query.PageSize]
+		page.NextCursor = query.NewCursor(page.Events[query.PageSize-1])
+	}
+
+	return page, nil
+}
+
+// GetEventsByPeriod returns single events intersecting [start, end) and recurring events
+// which may have occurrences there. Recurring events are not expanded.
+// Events owned or attended by the user with ownerID are returned, or events of all owners if ownerID is empty.
+func (s *Storage) GetEventsByPeriod(
+	ctx context.Context,
+	ownerID string,
+	start, end time.Time,
+) ([]storage.Event, error) {
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE deleted_at IS NULL
+		AND ` + inPeriod
+
+	if ownerID != "" {
+		query += `
+		AND (owner_id = :owner OR EXISTS (
+			SELECT 1 FROM event_attendees a
+			WHERE a.event_id = events.id AND a.user_id = :owner AND a.status <> 'declined'))`
+	}
+
+	query += `
+		ORDER BY start_time, id`
+
+	return s.queryEventsWithAttendees(ctx, "failed to get events by period", query,
+		sql.Named("start", start.UnixMicro()), sql.Named("end", end.UnixMicro()), sql.Named("owner", ownerID))
+}
+
+// GetOverlappingEvents returns single events of the owner intersecting [start, end) and recurring events
+// of the owner which may have occurrences there. Recurring events are not expanded.
+func (s *Storage) GetOverlappingEvents(
+	ctx context.Context,
+	ownerID string,
+	start, end time.Time,
+) ([]storage.Event, error) {
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE owner_id = :owner
+		AND deleted_at IS NULL
+		AND ` + inPeriod + `
+		ORDER BY start_time, id`
+
+	return s.queryEventsWithAttendees(ctx, "failed to get overlapping events", query,
+		sql.Named("start", start.UnixMicro()), sql.Named("end", end.UnixMicro()), sql.Named("owner", ownerID))
+}
+
+// GetBusyEvents returns events owned or attended by any of the users which intersect [start, end)
+// with a single query. Recurring events which may have occurrences there are returned unexpanded,
+// attendees are not loaded.
+func (s *Storage) GetBusyEvents(
+	ctx context.Context,
+	userIDs []string,
+	start, end time.Time,
+) ([]storage.Event, error) {
+	users, err := json.Marshal(userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode user IDs: %w", err)
+	}
+
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE deleted_at IS NULL
+		AND ` + inPeriod + `
+		AND (owner_id IN (SELECT value FROM json_each(:users)) OR EXISTS (
+			SELECT 1 FROM event_attendees a
+			WHERE a.event_id = events.id AND a.user_id IN (SELECT value FROM json_each(:users))
+			AND a.status <> 'declined'))
+		ORDER BY start_time, id`
+
+	rows, err := s.db.QueryContext(ctx, query,
+		sql.Named("start", start.UnixMicro()), sql.Named("end", end.UnixMicro()), sql.Named("users", string(users)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get busy events: %w", err)
+	}
+	defer rows.Close()
+
+	return collectEvents(rows)
+}
+
+// GetEventsToNotify returns single events to notify about in [from, to) and recurring events
+// with notifications which may have occurrences there. Recurring events are not expanded.
+func (s *Storage) GetEventsToNotify(ctx context.Context, from, to time.Time) ([]storage.Event, error) {
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE notify_before IS NOT NULL
+		AND deleted_at IS NULL
+		AND (
+			(rrule IS NULL AND start_time - notify_before / 1000 >= :from AND start_time - notify_before / 1000 < :to)
+			OR (rrule IS NOT NULL AND start_time - notify_before / 1000 < :to
+				AND (recurrence_end IS NULL OR recurrence_end > :from))
+		)
+		ORDER BY start_time`
+
+	return s.queryEventsWithAttendees(ctx, "failed to get events to notify", query,
+		sql.Named("from", from.UnixMicro()), sql.Named("to", to.UnixMicro()))
+}
+
+func (s *Storage) DeleteEventsBefore(ctx context.Context, t time.Time) (int64, error) {
+	query := `
+		DELETE FROM events
+		WHERE (rrule IS NULL AND end_time < :t)
+		OR (rrule IS NOT NULL AND recurrence_end < :t)`
+
+	return s.exec(ctx, "failed to delete events", query, sql.Named("t", t.UnixMicro()))
+}
+
+// GetTimeZone returns the default time zone of the user, empty if it is not set.
+func (s *Storage) GetTimeZone(ctx context.Context, userID string) (string, error) {
+	query := `
+		SELECT time_zone
+		FROM user_settings
+		WHERE user_id = ?`
+
+	var timeZone string
+	err := s.db.QueryRowContext(ctx, query, userID).Scan(&timeZone)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get time zone: %w", err)
+	}
+
+	return timeZone, nil
+}
+
+func (s *Storage) SetTimeZone(ctx context.Context, userID, timeZone string) error {
+	query := `
+		INSERT INTO user_settings (user_id, time_zone)
+		VALUES (?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET time_zone = excluded.time_zone`
+
+	if _, err := s.db.ExecContext(ctx, query, userID, timeZone); err != nil {
+		return fmt.Errorf("failed to set time zone: %w", err)
+	}
+
+	return nil
+}
+
+// exec runs the statement and returns the number of affected rows.
+func (s *Storage) exec(ctx context.Context, failure, query string, args ...any) (int64, error) {
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", failure, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", failure, err)
+	}
+
+	return affected, nil
+}
+
+// queryEventsWithAttendees reads all events and then loads their attendees.
+func (s *Storage) queryEventsWithAttendees(
+	ctx context.Context,
+	failure, query string,
+	args ...any,
+) ([]storage.Event, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", failure, err)
+	}
+	defer rows.Close()
+
+	events, err := collectEvents(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.loadAttendees(ctx, events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanEvent(row scanner) (storage.Event, error) {
+	var (
+		event                      storage.Event
+		start, end                 int64
+		description, rule, exdates sql.NullString
+		notifyBefore, deletedAt    sql.NullInt64
+	)
+
+	err := row.Scan(&event.ID, &event.Title, &start, &end, &description, &event.OwnerID,
+		&notifyBefore, &rule, &exdates, &event.Version, &deletedAt, &event.AllDay, &event.TimeZone)
+	if err != nil {
+		return event, err
+	}
+
+	event.StartTime = fromMicros(start)
+	event.EndTime = fromMicros(end)
+	if description.Valid {
+		event.Description = &description.String
+	}
+	if notifyBefore.Valid {
+		d := time.Duration(notifyBefore.Int64)
+		event.NotifyBefore = &d
+	}
+	if rule.Valid {
+		event.RecurrenceRule = &rule.String
+	}
+	if deletedAt.Valid {
+		t := fromMicros(deletedAt.Int64)
+		event.DeletedAt = &t
+	}
+
+	if exdates.Valid {
+		if err := json.Unmarshal([]byte(exdates.String), &event.RecurrenceExceptions); err != nil {
+			return event, fmt.Errorf("failed to decode recurrence exceptions: %w", err)
+		}
+	}
+
+	return event, nil
+}
+
+func collectEvents(rows *sql.Rows) ([]storage.Event, error) {
+	var events []storage.Event
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return events, nil
+}
+
+// seriesEnd returns the end of the last occurrence of the series recurring in loc, nil for endless series
+// and events which do not recur.
+func seriesEnd(rule *string, start, end time.Time, exdates []time.Time, loc *time.Location) (*time.Time, error) {
+	if rule == nil {
+		return nil, nil
+	}
+
+	return recurrence.SeriesEnd(*rule, start.In(loc), end, exdates)
+}
+
+// fromMicros converts stored Unix microseconds to time in UTC.
+func fromMicros(us int64) time.Time {
+	return time.UnixMicro(us).UTC()
+}
+
+func timeValue(t *time.Time) sql.NullInt64 {
+	if t == nil {
+		return sql.NullInt64{}
+	}
+
+	return sql.NullInt64{Int64: t.UnixMicro(), Valid: true}
+}
+
+func durationValue(d *time.Duration) sql.NullInt64 {
+	if d == nil {
+		return sql.NullInt64{}
+	}
+
+	return sql.NullInt64{Int64: int64(*d), Valid: true}
+}
+
+// encodeTimes stores recurrence exceptions as a JSON array, NULL if there are none.
+func encodeTimes(times []time.Time) (sql.NullString, error) {
+	if len(times) == 0 {
+		return sql.NullString{}, nil
+	}
+
+	data, err := json.Marshal(times)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to encode recurrence exceptions: %w", err)
+	}
+
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
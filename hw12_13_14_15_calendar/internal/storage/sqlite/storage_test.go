@@ -0,0 +1,88 @@
+package sqlitestorage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/app"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/storagetest"
+)
+
+func TestStorage_Contract(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) app.Storage {
+		t.Helper()
+
+		db, err := Open(filepath.Join(t.TempDir(), "calendar.db"))
+		if err != nil {
+			t.Fatalf("Open() error = %v, want nil", err)
+		}
+		t.Cleanup(func() { db.Close() })
+
+		if err := Migrate(context.Background(), db); err != nil {
+			t.Fatalf("Migrate() error = %v, want nil", err)
+		}
+
+		return New(db)
+	})
+}
+
+func TestOpen_WAL(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "calendar.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v, want nil", err)
+	}
+	defer db.Close()
+
+	var mode string
+	if err := db.QueryRow("PRAGMA journal_mode").Scan(&mode); err != nil {
+		t.Fatalf("PRAGMA journal_mode error = %v, want nil", err)
+	}
+	if mode != "wal" {
+		t.Errorf("journal_mode = %q, want %q", mode, "wal")
+	}
+}
+
+func TestMigrate_TimeZoneBackfill(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := Open(filepath.Join(t.TempDir(), "calendar.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v, want nil", err)
+	}
+	defer db.Close()
+
+	m, err := NewMigrator(db)
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v, want nil", err)
+	}
+	if _, err := m.To(ctx, 20261018180000); err != nil {
+		t.Fatalf("To() error = %v, want nil", err)
+	}
+
+	// Series keep UTC, so that their stored recurrence_end stays valid.
+	if _, err := db.Exec(`
+		INSERT INTO user_settings (user_id, time_zone) VALUES ('owner', 'Europe/Berlin');
+		INSERT INTO events (id, title, start_time, end_time, owner_id, rrule, recurrence_end, all_day) VALUES
+			('single', 'Single', 0, 1, 'owner', NULL, NULL, 0),
+			('series', 'Series', 0, 1, 'owner', 'FREQ=DAILY;COUNT=2', 86400000001, 0),
+			('holiday', 'Holiday', 0, 86400000000, 'owner', NULL, NULL, 1),
+			('stranger', 'Stranger', 0, 1, 'other', NULL, NULL, 0)`); err != nil {
+		t.Fatalf("failed to insert events: %v", err)
+	}
+
+	if err := Migrate(ctx, db); err != nil {
+		t.Fatalf("Migrate() error = %v, want nil", err)
+	}
+
+	want := map[string]string{"single": "Europe/Berlin", "series": "", "holiday": "", "stranger": ""}
+	for id, timeZone := range want {
+		var got string
+		if err := db.QueryRow("SELECT time_zone FROM events WHERE id = ?", id).Scan(&got); err != nil {
+			t.Fatalf("failed to select event %s: %v", id, err)
+		}
+		if got != timeZone {
+			t.Errorf("time zone of %s = %q, want %q", id, got, timeZone)
+		}
+	}
+}
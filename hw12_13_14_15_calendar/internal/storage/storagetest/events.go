@@ -0,0 +1,506 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/app"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+var baseTime = time.Date(2025, time.May, 10, 10, 0, 0, 0, time.UTC)
+
+func testCreateGet(t *testing.T, s app.Storage) {
+	t.Helper()
+
+	ctx := context.Background()
+	notifyBefore := 15 * time.Minute
+	params := storage.CreateOrUpdateEventParams{
+		Title:                "Standup",
+		StartTime:            baseTime,
+		EndTime:              baseTime.Add(30 * time.Minute),
+		Description:          stringPtr("Daily sync"),
+		OwnerID:              OwnerID,
+		NotifyBefore:         &notifyBefore,
+		RecurrenceRule:       stringPtr("FREQ=DAILY;COUNT=5"),
+		RecurrenceExceptions: []time.Time{baseTime.AddDate(0, 0, 2)},
+		TimeZone:             "Europe/Berlin",
+	}
+
+	created := create(ctx, t, s, params)
+	if created.Version != 1 {
+		t.Errorf("CreateEvent() version = %d, want 1", created.Version)
+	}
+	expectParams(t, "CreateEvent()", *created, params)
+
+	got, err := s.GetEvent(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetEvent() error = %v, want nil", err)
+	}
+	if got.ID != created.ID || got.Version != created.Version {
+		t.Errorf("GetEvent() = %s v%d, want %s v%d", got.ID, got.Version, created.ID, created.Version)
+	}
+	expectParams(t, "GetEvent()", *got, params)
+
+	_, err = s.GetEvent(ctx, MissingID)
+	expectError(t, "GetEvent() of missing event", err, storage.ErrEventNotFound)
+}
+
+func testUpdate(t *testing.T, s app.Storage) {
+	t.Helper()
+
+	ctx := context.Background()
+	event := create(ctx, t, s, storage.CreateOrUpdateEventParams{
+		Title: "Review", StartTime: baseTime, EndTime: baseTime.Add(time.Hour), OwnerID: OwnerID,
+	})
+
+	changed := *event
+	changed.Title = "Design review"
+	changed.EndTime = baseTime.Add(2 * time.Hour)
+	updated, err := s.UpdateEvent(ctx, changed)
+	if err != nil {
+		t.Fatalf("UpdateEvent() error = %v, want nil", err)
+	}
+	if updated.Version != event.Version+1 {
+		t.Errorf("UpdateEvent() version = %d, want %d", updated.Version, event.Version+1)
+	}
+
+	got, err := s.GetEvent(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("GetEvent() error = %v, want nil", err)
+	}
+	if got.Title != "Design review" || !got.EndTime.Equal(changed.EndTime) || got.Version != updated.Version {
+		t.Errorf("GetEvent() = %q until %v v%d, want %q until %v v%d",
+			got.Title, got.EndTime, got.Version, "Design review", changed.EndTime, updated.Version)
+	}
+
+	_, err = s.UpdateEvent(ctx, changed)
+	expectError(t, "UpdateEvent() with stale version", err, storage.ErrVersionConflict)
+
+	changed.Version = 0
+	if _, err := s.UpdateEvent(ctx, changed); err != nil {
+		t.Errorf("UpdateEvent() without version error = %v, want nil", err)
+	}
+
+	changed.ID = MissingID
+	_, err = s.UpdateEvent(ctx, changed)
+	expectError(t, "UpdateEvent() of missing event", err, storage.ErrEventNotFound)
+}
+
+func testTrash(t *testing.T, s app.Storage) {
+	t.Helper()
+
+	ctx := context.Background()
+	event := create(ctx, t, s, storage.CreateOrUpdateEventParams{
+		Title: "Lunch", StartTime: baseTime, EndTime: baseTime.Add(time.Hour), OwnerID: OwnerID,
+	})
+
+	_, err := s.GetDeletedEvent(ctx, event.ID)
+	expectError(t, "GetDeletedEvent() of live event", err, storage.ErrEventNotFound)
+
+	if err := s.DeleteEvent(ctx, event.ID); err != nil {
+		t.Fatalf("DeleteEvent() error = %v, want nil", err)
+	}
+
+	_, err = s.GetEvent(ctx, event.ID)
+	expectError(t, "GetEvent() of deleted event", err, storage.ErrEventNotFound)
+	_, err = s.UpdateEvent(ctx, *event)
+	expectError(t, "UpdateEvent() of deleted event", err, storage.ErrEventNotFound)
+	expectError(t, "DeleteEvent() of deleted event", s.DeleteEvent(ctx, event.ID), storage.ErrEventNotFound)
+
+	deleted, err := s.GetDeletedEvent(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("GetDeletedEvent() error = %v, want nil", err)
+	}
+	if deleted.DeletedAt == nil {
+		t.Error("GetDeletedEvent() DeletedAt = nil, want deletion time")
+	}
+
+	trash, err := s.GetDeletedEvents(ctx, OwnerID)
+	if err != nil {
+		t.Fatalf("GetDeletedEvents() error = %v, want nil", err)
+	}
+	expectTitles(t, "GetDeletedEvents()", trash, []string{"Lunch"})
+
+	trash, err = s.GetDeletedEvents(ctx, OtherOwnerID)
+	if err != nil {
+		t.Fatalf("GetDeletedEvents() error = %v, want nil", err)
+	}
+	expectTitles(t, "GetDeletedEvents() of another owner", trash, nil)
+
+	restored, err := s.RestoreEvent(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("RestoreEvent() error = %v, want nil", err)
+	}
+	if restored.DeletedAt != nil || restored.Version <= event.Version {
+		t.Errorf("RestoreEvent() = deleted at %v v%d, want live event newer than v%d",
+			restored.DeletedAt, restored.Version, event.Version)
+	}
+
+	if _, err := s.GetEvent(ctx, event.ID); err != nil {
+		t.Errorf("GetEvent() of restored event error = %v, want nil", err)
+	}
+	_, err = s.RestoreEvent(ctx, event.ID)
+	expectError(t, "RestoreEvent() of live event", err, storage.ErrEventNotFound)
+}
+
+func testPurge(t *testing.T, s app.Storage) {
+	t.Helper()
+
+	ctx := context.Background()
+	kept := create(ctx, t, s, storage.CreateOrUpdateEventParams{
+		Title: "Kept", StartTime: baseTime, EndTime: baseTime.Add(time.Hour), OwnerID: OwnerID,
+	})
+	trashed := create(ctx, t, s, storage.CreateOrUpdateEventParams{
+		Title: "Trashed", StartTime: baseTime, EndTime: baseTime.Add(time.Hour), OwnerID: OwnerID,
+	})
+	if err := s.DeleteEvent(ctx, trashed.ID); err != nil {
+		t.Fatalf("DeleteEvent() error = %v, want nil", err)
+	}
+
+	purged, err := s.PurgeDeletedEvents(ctx, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("PurgeDeletedEvents() error = %v, want nil", err)
+	}
+	if purged != 0 {
+		t.Errorf("PurgeDeletedEvents() before deletion = %d, want 0", purged)
+	}
+
+	purged, err = s.PurgeDeletedEvents(ctx, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("PurgeDeletedEvents() error = %v, want nil", err)
+	}
+	if purged != 1 {
+		t.Errorf("PurgeDeletedEvents() = %d, want 1", purged)
+	}
+
+	_, err = s.GetDeletedEvent(ctx, trashed.ID)
+	expectError(t, "GetDeletedEvent() of purged event", err, storage.ErrEventNotFound)
+	if _, err := s.GetEvent(ctx, kept.ID); err != nil {
+		t.Errorf("GetEvent() of live event error = %v, want nil", err)
+	}
+}
+
+func testAttendees(t *testing.T, s app.Storage) {
+	t.Helper()
+
+	ctx := context.Background()
+	event := create(ctx, t, s, storage.CreateOrUpdateEventParams{
+		Title: "Planning", StartTime: baseTime, EndTime: baseTime.Add(time.Hour), OwnerID: OwnerID,
+	})
+
+	if err := s.AddAttendee(ctx, event.ID, OtherOwnerID); err != nil {
+		t.Fatalf("AddAttendee() error = %v, want nil", err)
+	}
+	if err := s.SetAttendeeStatus(ctx, event.ID, OtherOwnerID, storage.AttendeeAccepted); err != nil {
+		t.Fatalf("SetAttendeeStatus() error = %v, want nil", err)
+	}
+	if err := s.AddAttendee(ctx, event.ID, OtherOwnerID); err != nil {
+		t.Fatalf("AddAttendee() again error = %v, want nil", err)
+	}
+	expectAttendees(ctx, t, s, event.ID, []storage.Attendee{{UserID: OtherOwnerID, Status: storage.AttendeeAccepted}})
+
+	attended, err := s.GetEventsByPeriod(ctx, OtherOwnerID, baseTime, baseTime.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetEventsByPeriod() error = %v, want nil", err)
+	}
+	expectTitles(t, "GetEventsByPeriod() of attendee", attended, []string{"Planning"})
+
+	if err := s.SetAttendeeStatus(ctx, event.ID, OtherOwnerID, storage.AttendeeDeclined); err != nil {
+		t.Fatalf("SetAttendeeStatus() error = %v, want nil", err)
+	}
+	attended, err = s.GetEventsByPeriod(ctx, OtherOwnerID, baseTime, baseTime.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetEventsByPeriod() error = %v, want nil", err)
+	}
+	expectTitles(t, "GetEventsByPeriod() of declined attendee", attended, nil)
+
+	if err := s.RemoveAttendee(ctx, event.ID, OtherOwnerID); err != nil {
+		t.Fatalf("RemoveAttendee() error = %v, want nil", err)
+	}
+	expectAttendees(ctx, t, s, event.ID, nil)
+
+	expectError(t, "RemoveAttendee() of missing attendee",
+		s.RemoveAttendee(ctx, event.ID, OtherOwnerID), storage.ErrAttendeeNotFound)
+	expectError(t, "SetAttendeeStatus() of missing attendee",
+		s.SetAttendeeStatus(ctx, event.ID, OtherOwnerID, storage.AttendeeAccepted), storage.ErrAttendeeNotFound)
+	expectError(t, "AddAttendee() to missing event",
+		s.AddAttendee(ctx, MissingID, OtherOwnerID), storage.ErrEventNotFound)
+	expectError(t, "RemoveAttendee() from missing event",
+		s.RemoveAttendee(ctx, MissingID, OtherOwnerID), storage.ErrEventNotFound)
+	expectError(t, "SetAttendeeStatus() in missing event",
+		s.SetAttendeeStatus(ctx, MissingID, OtherOwnerID, storage.AttendeeAccepted), storage.ErrEventNotFound)
+}
+
+func testList(t *testing.T, s app.Storage) {
+	t.Helper()
+
+	ctx := context.Background()
+	titles := []string{"Echo", "alpha", "Delta", "bravo", "Charlie"}
+	for i, title := range titles {
+		start := baseTime.Add(time.Duration(i) * time.Hour)
+		create(ctx, t, s, storage.CreateOrUpdateEventParams{
+			Title: title, StartTime: start, EndTime: start.Add(time.Hour), OwnerID: OwnerID,
+		})
+	}
+	create(ctx, t, s, storage.CreateOrUpdateEventParams{
+		Title: "Foreign", StartTime: baseTime, EndTime: baseTime.Add(time.Hour), OwnerID: OtherOwnerID,
+	})
+
+	tests := []struct {
+		name  string
+		query storage.ListEventsQuery
+		want  []string
+	}{
+		{
+			name:  "by start",
+			query: storage.ListEventsQuery{OwnerID: OwnerID, SortBy: storage.SortByStartTime, PageSize: 2},
+			want:  titles,
+		},
+		{
+			name: "by start descending",
+			query: storage.ListEventsQuery{
+				OwnerID: OwnerID, SortBy: storage.SortByStartTime, Descending: true, PageSize: 2,
+			},
+			want: []string{"Charlie", "bravo", "Delta", "alpha", "Echo"},
+		},
+		{
+			name:  "by title is case sensitive",
+			query: storage.ListEventsQuery{OwnerID: OwnerID, SortBy: storage.SortByTitle, PageSize: 3},
+			want:  []string{"Charlie", "Delta", "Echo", "alpha", "bravo"},
+		},
+		{
+			name: "title filter ignores case",
+			query: storage.ListEventsQuery{
+				OwnerID: OwnerID, Title: "HA", SortBy: storage.SortByStartTime, PageSize: 10,
+			},
+			want: []string{"alpha", "Charlie"},
+		},
+		{
+			name: "from and to",
+			query: storage.ListEventsQuery{
+				From: timePtr(baseTime.Add(time.Hour)), To: timePtr(baseTime.Add(3 * time.Hour)),
+				SortBy: storage.SortByStartTime, PageSize: 10,
+			},
+			want: []string{"alpha", "Delta"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := listAll(ctx, t, s, tt.query)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ListEvents() = %q, want %q", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("ListEvents() = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+
+	forged := storage.ListEventsQuery{SortBy: storage.SortByStartTime, PageSize: 2}
+	forged.Cursor = forged.NewCursor(storage.Event{ID: "not-a-uuid", StartTime: baseTime})
+	_, err := s.ListEvents(ctx, forged)
+	expectError(t, "ListEvents() with cursor of invalid ID", err, storage.ErrInvalidCursor)
+}
+
+// listAll follows the page cursors and returns titles of all listed events.
+func listAll(ctx context.Context, t *testing.T, s app.Storage, query storage.ListEventsQuery) []string {
+	t.Helper()
+
+	var titles []string
+	for {
+		page, err := s.ListEvents(ctx, query)
+		if err != nil {
+			t.Fatalf("ListEvents() error = %v, want nil", err)
+		}
+		if len(page.Events) > query.PageSize {
+			t.Fatalf("ListEvents() returned %d events, want at most %d", len(page.Events), query.PageSize)
+		}
+
+		for _, event := range page.Events {
+			titles = append(titles, event.Title)
+		}
+
+		if page.NextCursor == "" {
+			return titles
+		}
+		query.Cursor = page.NextCursor
+	}
+}
+
+func testEventsToNotify(t *testing.T, s app.Storage) {
+	t.Helper()
+
+	ctx := context.Background()
+	hour := time.Hour
+	create(ctx, t, s, storage.CreateOrUpdateEventParams{
+		Title: "Notified", StartTime: baseTime, EndTime: baseTime.Add(time.Hour), OwnerID: OwnerID,
+		NotifyBefore: &hour,
+	})
+	create(ctx, t, s, storage.CreateOrUpdateEventParams{
+		Title: "Silent", StartTime: baseTime, EndTime: baseTime.Add(time.Hour), OwnerID: OwnerID,
+	})
+	create(ctx, t, s, storage.CreateOrUpdateEventParams{
+		Title: "Weekly", StartTime: baseTime.AddDate(0, 0, -7), EndTime: baseTime.AddDate(0, 0, -7).Add(time.Hour),
+		OwnerID: OwnerID, NotifyBefore: &hour, RecurrenceRule: stringPtr("FREQ=WEEKLY"),
+	})
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     []string
+	}{
+		{name: "notification time", from: baseTime.Add(-time.Hour), to: baseTime, want: []string{"Notified", "Weekly"}},
+		{name: "after notification", from: baseTime.Add(-30 * time.Minute), to: baseTime, want: []string{"Weekly"}},
+		{name: "before series", from: baseTime.AddDate(0, 0, -9), to: baseTime.AddDate(0, 0, -8)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := s.GetEventsToNotify(ctx, tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("GetEventsToNotify() error = %v, want nil", err)
+			}
+			expectTitles(t, "GetEventsToNotify()", events, tt.want)
+		})
+	}
+}
+
+func testDeleteEventsBefore(t *testing.T, s app.Storage) {
+	t.Helper()
+
+	ctx := context.Background()
+	old := create(ctx, t, s, storage.CreateOrUpdateEventParams{
+		Title: "Old", StartTime: baseTime.AddDate(0, 0, -2), EndTime: baseTime.AddDate(0, 0, -2).Add(time.Hour),
+		OwnerID: OwnerID,
+	})
+	create(ctx, t, s, storage.CreateOrUpdateEventParams{
+		Title: "Finished series", StartTime: baseTime.AddDate(0, 0, -5), EndTime: baseTime.AddDate(0, 0, -5).Add(time.Hour),
+		OwnerID: OwnerID, RecurrenceRule: stringPtr("FREQ=DAILY;COUNT=2"),
+	})
+	create(ctx, t, s, storage.CreateOrUpdateEventParams{
+		Title: "Endless series", StartTime: baseTime.AddDate(0, 0, -5), EndTime: baseTime.AddDate(0, 0, -5).Add(time.Hour),
+		OwnerID: OwnerID, RecurrenceRule: stringPtr("FREQ=DAILY"),
+	})
+	create(ctx, t, s, storage.CreateOrUpdateEventParams{
+		Title: "Upcoming", StartTime: baseTime, EndTime: baseTime.Add(time.Hour), OwnerID: OwnerID,
+	})
+
+	deleted, err := s.DeleteEventsBefore(ctx, baseTime.AddDate(0, 0, -1))
+	if err != nil {
+		t.Fatalf("DeleteEventsBefore() error = %v, want nil", err)
+	}
+	if deleted != 2 {
+		t.Errorf("DeleteEventsBefore() = %d, want 2", deleted)
+	}
+
+	_, err = s.GetEvent(ctx, old.ID)
+	expectError(t, "GetEvent() of deleted event", err, storage.ErrEventNotFound)
+
+	page, err := s.ListEvents(ctx, storage.ListEventsQuery{SortBy: storage.SortByStartTime, PageSize: 10})
+	if err != nil {
+		t.Fatalf("ListEvents() error = %v, want nil", err)
+	}
+	expectTitles(t, "ListEvents()", page.Events, []string{"Endless series", "Upcoming"})
+}
+
+func testTimeZone(t *testing.T, s app.Storage) {
+	t.Helper()
+
+	ctx := context.Background()
+	expectTimeZone := func(want string) {
+		t.Helper()
+
+		got, err := s.GetTimeZone(ctx, OwnerID)
+		if err != nil {
+			t.Fatalf("GetTimeZone() error = %v, want nil", err)
+		}
+		if got != want {
+			t.Errorf("GetTimeZone() = %q, want %q", got, want)
+		}
+	}
+
+	expectTimeZone("")
+
+	for _, name := range []string{"Europe/Moscow", "America/New_York"} {
+		if err := s.SetTimeZone(ctx, OwnerID, name); err != nil {
+			t.Fatalf("SetTimeZone() error = %v, want nil", err)
+		}
+		expectTimeZone(name)
+	}
+}
+
+// expectParams checks that the stored event has the fields it was created with.
+func expectParams(t *testing.T, name string, event storage.Event, params storage.CreateOrUpdateEventParams) {
+	t.Helper()
+
+	if event.Title != params.Title || event.OwnerID != params.OwnerID || event.AllDay != params.AllDay {
+		t.Errorf("%s = %q of %s all day %v, want %q of %s all day %v", name,
+			event.Title, event.OwnerID, event.AllDay, params.Title, params.OwnerID, params.AllDay)
+	}
+	if event.TimeZone != params.TimeZone {
+		t.Errorf("%s time zone = %q, want %q", name, event.TimeZone, params.TimeZone)
+	}
+	if !event.StartTime.Equal(params.StartTime) || !event.EndTime.Equal(params.EndTime) {
+		t.Errorf("%s period = [%v, %v), want [%v, %v)", name,
+			event.StartTime, event.EndTime, params.StartTime, params.EndTime)
+	}
+	if !equalPtr(event.Description, params.Description) || !equalPtr(event.RecurrenceRule, params.RecurrenceRule) {
+		t.Errorf("%s description, rule = %v, %v, want %v, %v", name,
+			deref(event.Description), deref(event.RecurrenceRule), deref(params.Description), deref(params.RecurrenceRule))
+	}
+	if !equalPtr(event.NotifyBefore, params.NotifyBefore) {
+		t.Errorf("%s notify before = %v, want %v", name, deref(event.NotifyBefore), deref(params.NotifyBefore))
+	}
+
+	if len(event.RecurrenceExceptions) != len(params.RecurrenceExceptions) {
+		t.Fatalf("%s exceptions = %v, want %v", name, event.RecurrenceExceptions, params.RecurrenceExceptions)
+	}
+	for i, exception := range params.RecurrenceExceptions {
+		if !event.RecurrenceExceptions[i].Equal(exception) {
+			t.Errorf("%s exceptions = %v, want %v", name, event.RecurrenceExceptions, params.RecurrenceExceptions)
+		}
+	}
+}
+
+func expectAttendees(ctx context.Context, t *testing.T, s app.Storage, eventID string, want []storage.Attendee) {
+	t.Helper()
+
+	event, err := s.GetEvent(ctx, eventID)
+	if err != nil {
+		t.Fatalf("GetEvent() error = %v, want nil", err)
+	}
+
+	if len(event.Attendees) != len(want) {
+		t.Fatalf("GetEvent() attendees = %v, want %v", event.Attendees, want)
+	}
+	for i := range want {
+		if event.Attendees[i] != want[i] {
+			t.Errorf("GetEvent() attendees = %v, want %v", event.Attendees, want)
+		}
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func deref[T any](p *T) any {
+	if p == nil {
+		return nil
+	}
+
+	return *p
+}
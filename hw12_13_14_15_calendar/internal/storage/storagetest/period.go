@@ -0,0 +1,127 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/app"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+// testPeriodQueries checks that all period queries return events intersecting [10:00, 12:00).
+func testPeriodQueries(t *testing.T, s app.Storage) {
+	t.Helper()
+
+	ctx := context.Background()
+	day := time.Date(2025, time.May, 10, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time {
+		return day.Add(time.Duration(hour) * time.Hour)
+	}
+	start, end := at(10), at(12)
+
+	events := []storage.CreateOrUpdateEventParams{
+		{Title: "Before", StartTime: at(8), EndTime: at(9)},
+		{Title: "Ending at start", StartTime: at(9), EndTime: at(10)},
+		{Title: "Crossing start", StartTime: at(9), EndTime: at(11)},
+		{Title: "Inside", StartTime: at(10).Add(30 * time.Minute), EndTime: at(11)},
+		{Title: "Covering", StartTime: at(9), EndTime: at(13)},
+		{Title: "Crossing end", StartTime: at(11), EndTime: at(13)},
+		{Title: "Starting at end", StartTime: at(12), EndTime: at(13)},
+		{Title: "Instant at start", StartTime: at(10), EndTime: at(10)},
+		{Title: "Instant at end", StartTime: at(12), EndTime: at(12)},
+		{Title: "Yesterday night", StartTime: at(-1), EndTime: at(11)},
+		{Title: "Endless series", StartTime: at(-7 * 24), EndTime: at(-7*24 + 1), RecurrenceRule: stringPtr("FREQ=DAILY")},
+		{
+			Title: "Finished series", StartTime: at(-10 * 24), EndTime: at(-10*24 + 1),
+			RecurrenceRule: stringPtr("FREQ=DAILY;COUNT=3"),
+		},
+		{
+			Title: "Series ending at start", StartTime: at(-24 + 9), EndTime: at(-24 + 10),
+			RecurrenceRule: stringPtr("FREQ=DAILY;COUNT=2"),
+		},
+		{Title: "Future series", StartTime: at(12), EndTime: at(13), RecurrenceRule: stringPtr("FREQ=DAILY")},
+	}
+	for _, params := range events {
+		params.OwnerID = OwnerID
+		create(ctx, t, s, params)
+	}
+	create(ctx, t, s, storage.CreateOrUpdateEventParams{
+		Title: "Foreign", StartTime: at(10), EndTime: at(11), OwnerID: OtherOwnerID,
+	})
+
+	deleted := create(ctx, t, s, storage.CreateOrUpdateEventParams{
+		Title: "Deleted", StartTime: at(10), EndTime: at(11), OwnerID: OwnerID,
+	})
+	if err := s.DeleteEvent(ctx, deleted.ID); err != nil {
+		t.Fatalf("DeleteEvent() error = %v, want nil", err)
+	}
+
+	want := []string{
+		"Covering", "Crossing end", "Crossing start", "Endless series", "Inside", "Instant at start", "Yesterday night",
+	}
+
+	byPeriod, err := s.GetEventsByPeriod(ctx, OwnerID, start, end)
+	if err != nil {
+		t.Fatalf("GetEventsByPeriod() error = %v, want nil", err)
+	}
+	expectTitles(t, "GetEventsByPeriod()", byPeriod, want)
+
+	overlapping, err := s.GetOverlappingEvents(ctx, OwnerID, start, end)
+	if err != nil {
+		t.Fatalf("GetOverlappingEvents() error = %v, want nil", err)
+	}
+	expectTitles(t, "GetOverlappingEvents()", overlapping, want)
+
+	busy, err := s.GetBusyEvents(ctx, []string{OwnerID}, start, end)
+	if err != nil {
+		t.Fatalf("GetBusyEvents() error = %v, want nil", err)
+	}
+	expectTitles(t, "GetBusyEvents()", busy, want)
+
+	all, err := s.GetEventsByPeriod(ctx, "", start, end)
+	if err != nil {
+		t.Fatalf("GetEventsByPeriod() error = %v, want nil", err)
+	}
+	expectTitles(t, "GetEventsByPeriod() of all owners", all, append([]string{"Foreign"}, want...))
+}
+
+// testPeriodAfterChanges checks that period queries follow moved, deleted and restored events.
+func testPeriodAfterChanges(t *testing.T, s app.Storage) {
+	t.Helper()
+
+	ctx := context.Background()
+	start := time.Date(2025, time.May, 10, 10, 0, 0, 0, time.UTC)
+	tomorrow := start.AddDate(0, 0, 1)
+
+	event := create(ctx, t, s, storage.CreateOrUpdateEventParams{
+		Title: "Moving", StartTime: start, EndTime: start.Add(time.Hour), OwnerID: OwnerID,
+	})
+
+	event.StartTime, event.EndTime = tomorrow, tomorrow.Add(time.Hour)
+	if _, err := s.UpdateEvent(ctx, *event); err != nil {
+		t.Fatalf("UpdateEvent() error = %v, want nil", err)
+	}
+	expectPeriod(ctx, t, s, start, start.Add(time.Hour), nil)
+	expectPeriod(ctx, t, s, tomorrow, tomorrow.Add(time.Hour), []string{"Moving"})
+
+	if err := s.DeleteEvent(ctx, event.ID); err != nil {
+		t.Fatalf("DeleteEvent() error = %v, want nil", err)
+	}
+	expectPeriod(ctx, t, s, tomorrow, tomorrow.Add(time.Hour), nil)
+
+	if _, err := s.RestoreEvent(ctx, event.ID); err != nil {
+		t.Fatalf("RestoreEvent() error = %v, want nil", err)
+	}
+	expectPeriod(ctx, t, s, tomorrow, tomorrow.Add(time.Hour), []string{"Moving"})
+}
+
+func expectPeriod(ctx context.Context, t *testing.T, s app.Storage, start, end time.Time, want []string) {
+	t.Helper()
+
+	events, err := s.GetEventsByPeriod(ctx, OwnerID, start, end)
+	if err != nil {
+		t.Fatalf("GetEventsByPeriod() error = %v, want nil", err)
+	}
+	expectTitles(t, "GetEventsByPeriod()", events, want)
+}
@@ -0,0 +1,87 @@
+// Package storagetest contains contract tests shared by storage implementations.
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/app"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+)
+
+const (
+	OwnerID      = "123e4567-e89b-12d3-a456-426614174000"
+	OtherOwnerID = "223e4567-e89b-12d3-a456-426614174000"
+	// MissingID is a well-formed ID of an event which is never created.
+	MissingID = "923e4567-e89b-12d3-a456-426614174000"
+)
+
+// Run runs the contract tests, newStorage must return an empty storage.
+func Run(t *testing.T, newStorage func(t *testing.T) app.Storage) {
+	t.Helper()
+
+	t.Run("CreateGet", func(t *testing.T) { testCreateGet(t, newStorage(t)) })
+	t.Run("Update", func(t *testing.T) { testUpdate(t, newStorage(t)) })
+	t.Run("Trash", func(t *testing.T) { testTrash(t, newStorage(t)) })
+	t.Run("Purge", func(t *testing.T) { testPurge(t, newStorage(t)) })
+	t.Run("Attendees", func(t *testing.T) { testAttendees(t, newStorage(t)) })
+	t.Run("List", func(t *testing.T) { testList(t, newStorage(t)) })
+	t.Run("EventsToNotify", func(t *testing.T) { testEventsToNotify(t, newStorage(t)) })
+	t.Run("DeleteEventsBefore", func(t *testing.T) { testDeleteEventsBefore(t, newStorage(t)) })
+	t.Run("TimeZone", func(t *testing.T) { testTimeZone(t, newStorage(t)) })
+	t.Run("PeriodQueries", func(t *testing.T) { testPeriodQueries(t, newStorage(t)) })
+	t.Run("PeriodAfterChanges", func(t *testing.T) { testPeriodAfterChanges(t, newStorage(t)) })
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func create(ctx context.Context, t *testing.T, s app.Storage, params storage.CreateOrUpdateEventParams) *storage.Event {
+	t.Helper()
+
+	event, err := s.CreateEvent(ctx, params)
+	if err != nil {
+		t.Fatalf("CreateEvent(%q) error = %v, want nil", params.Title, err)
+	}
+	if event == nil || event.ID == "" {
+		t.Fatalf("CreateEvent(%q) = %+v, want event with ID", params.Title, event)
+	}
+
+	return event
+}
+
+func expectError(t *testing.T, name string, err, want error) {
+	t.Helper()
+
+	if !errors.Is(err, want) {
+		t.Errorf("%s error = %v, want %v", name, err, want)
+	}
+}
+
+// expectTitles checks that events are sorted by start and have the titles in any order.
+func expectTitles(t *testing.T, name string, events []storage.Event, want []string) {
+	t.Helper()
+
+	got := make([]string, len(events))
+	for i, event := range events {
+		got[i] = event.Title
+		if i > 0 && event.StartTime.Before(events[i-1].StartTime) {
+			t.Errorf("%s is not sorted by start: %q goes after %q", name, event.Title, events[i-1].Title)
+		}
+	}
+
+	sort.Strings(got)
+	sort.Strings(want)
+	if len(got) != len(want) {
+		t.Fatalf("%s = %q, want %q", name, got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%s = %q, want %q", name, got, want)
+			return
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/app"
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	eventIDKey   = attribute.Key("calendar.event.id")
+	ownerIDKey   = attribute.Key("calendar.owner.id")
+	userIDKey    = attribute.Key("calendar.user.id")
+	userIDsKey   = attribute.Key("calendar.user.ids")
+	periodKey    = attribute.Key("calendar.period")
+	beforeKey    = attribute.Key("calendar.before")
+	pageSizeKey  = attribute.Key("calendar.page_size")
+	resultLenKey = attribute.Key("calendar.result.count")
+)
+
+// Storage starts a child span for every operation of the wrapped storage.
+// Queries of a storage on PostgreSQL get their own spans from QueryTracer.
+type Storage struct {
+	next app.Storage
+}
+
+var _ app.Storage = (*Storage)(nil)
+
+func NewStorage(next app.Storage) *Storage {
+	return &Storage{next: next}
+}
+
+func (s *Storage) start(
+	ctx context.Context,
+	operation string,
+	attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, "storage."+operation,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(attrs...))
+}
+
+func period(start, end time.Time) attribute.KeyValue {
+	return periodKey.String(start.UTC().Format(time.RFC3339) + "/" + end.UTC().Format(time.RFC3339))
+}
+
+func (s *Storage) CreateEvent(ctx context.Context, params storage.CreateOrUpdateEventParams) (*storage.Event, error) {
+	ctx, span := s.start(ctx, "CreateEvent", ownerIDKey.String(params.OwnerID))
+	event, err := s.next.CreateEvent(ctx, params)
+	if err == nil {
+		span.SetAttributes(eventIDKey.String(event.ID))
+	}
+	End(span, err)
+
+	return event, err
+}
+
+func (s *Storage) GetEvent(ctx context.Context, id string) (*storage.Event, error) {
+	ctx, span := s.start(ctx, "GetEvent", eventIDKey.String(id))
+	event, err := s.next.GetEvent(ctx, id)
+	End(span, err)
+
+	return event, err
+}
+
+func (s *Storage) UpdateEvent(ctx context.Context, event storage.Event) (*storage.Event, error) {
+	ctx, span := s.start(ctx, "UpdateEvent", eventIDKey.String(event.ID))
+	updated, err := s.next.UpdateEvent(ctx, event)
+	End(span, err)
+
+	return updated, err
+}
+
+func (s *Storage) DeleteEvent(ctx context.Context, id string) error {
+	ctx, span := s.start(ctx, "DeleteEvent", eventIDKey.String(id))
+	err := s.next.DeleteEvent(ctx, id)
+	End(span, err)
+
+	return err
+}
+
+func (s *Storage) GetDeletedEvent(ctx context.Context, id string) (*storage.Event, error) {
+	ctx, span := s.start(ctx, "GetDeletedEvent", eventIDKey.String(id))
+	event, err := s.next.GetDeletedEvent(ctx, id)
+	End(span, err)
+
+	return event, err
+}
+
+func (s *Storage) GetDeletedEvents(ctx context.Context, ownerID string) ([]storage.Event, error) {
+	ctx, span := s.start(ctx, "GetDeletedEvents", ownerIDKey.String(ownerID))
+	events, err := s.next.GetDeletedEvents(ctx, ownerID)
+	span.SetAttributes(resultLenKey.Int(len(events)))
+	End(span, err)
+
+	return events, err
+}
+
+func (s *Storage) RestoreEvent(ctx context.Context, id string) (*storage.Event, error) {
+	ctx, span := s.start(ctx, "RestoreEvent", eventIDKey.String(id))
+	event, err := s.next.RestoreEvent(ctx, id)
+	End(span, err)
+
+	return event, err
+}
+
+func (s *Storage) PurgeDeletedEvents(ctx context.Context, t time.Time) (int64, error) {
+	ctx, span := s.start(ctx, "PurgeDeletedEvents", beforeKey.String(t.UTC().Format(time.RFC3339)))
+	purged, err := s.next.PurgeDeletedEvents(ctx, t)
+	span.SetAttributes(resultLenKey.Int64(purged))
+	End(span, err)
+
+	return purged, err
+}
+
+func (s *Storage) AddAttendee(ctx context.Context, eventID, userID string) error {
+	ctx, span := s.start(ctx, "AddAttendee", eventIDKey.String(eventID), userIDKey.String(userID))
+	err := s.next.AddAttendee(ctx, eventID, userID)
+	End(span, err)
+
+	return err
+}
+
+func (s *Storage) RemoveAttendee(ctx context.Context, eventID, userID string) error {
+	ctx, span := s.start(ctx, "RemoveAttendee", eventIDKey.String(eventID), userIDKey.String(userID))
+	err := s.next.RemoveAttendee(ctx, eventID, userID)
+	End(span, err)
+
+	return err
+}
+
+func (s *Storage) SetAttendeeStatus(
+	ctx context.Context,
+	eventID, userID string,
+	status storage.AttendeeStatus,
+) error {
+	ctx, span := s.start(ctx, "SetAttendeeStatus", eventIDKey.String(eventID), userIDKey.String(userID))
+	err := s.next.SetAttendeeStatus(ctx, eventID, userID, status)
+	End(span, err)
+
+	return err
+}
+
+func (s *Storage) ListEvents(ctx context.Context, query storage.ListEventsQuery) (storage.EventPage, error) {
+	ctx, span := s.start(ctx, "ListEvents", ownerIDKey.String(query.OwnerID), pageSizeKey.Int(query.PageSize))
+	page, err := s.next.ListEvents(ctx, query)
+	span.SetAttributes(resultLenKey.Int(len(page.Events)))
+	End(span, err)
+
+	return page, err
+}
+
+func (s *Storage) GetEventsByPeriod(
+	ctx context.Context,
+	ownerID string,
+	start, end time.Time,
+) ([]storage.Event, error) {
+	ctx, span := s.start(ctx, "GetEventsByPeriod", ownerIDKey.String(ownerID), period(start, end))
+	events, err := s.next.GetEventsByPeriod(ctx, ownerID, start, end)
+	span.SetAttributes(resultLenKey.Int(len(events)))
+	End(span, err)
+
+	return events, err
+}
+
+func (s *Storage) GetOverlappingEvents(
+	ctx context.Context,
+	ownerID string,
+	start, end time.Time,
+) ([]storage.Event, error) {
+	ctx, span := s.start(ctx, "GetOverlappingEvents", ownerIDKey.String(ownerID), period(start, end))
+	events, err := s.next.GetOverlappingEvents(ctx, ownerID, start, end)
+	span.SetAttributes(resultLenKey.Int(len(events)))
+	End(span, err)
+
+	return events, err
+}
+
+func (s *Storage) GetBusyEvents(
+	ctx context.Context,
+	userIDs []string,
+	start, end time.Time,
+) ([]storage.Event, error) {
+	ctx, span := s.start(ctx, "GetBusyEvents", userIDsKey.StringSlice(userIDs), period(start, end))
+	events, err := s.next.GetBusyEvents(ctx, userIDs, start, end)
+	span.SetAttributes(resultLenKey.Int(len(events)))
+	End(span, err)
+
+	return events, err
+}
+
+func (s *Storage) GetTimeZone(ctx context.Context, userID string) (string, error) {
+	ctx, span := s.start(ctx, "GetTimeZone", userIDKey.String(userID))
+	timeZone, err := s.next.GetTimeZone(ctx, userID)
+	End(span, err)
+
+	return timeZone, err
+}
+
+func (s *Storage) SetTimeZone(ctx context.Context, userID, timeZone string) error {
+	ctx, span := s.start(ctx, "SetTimeZone", userIDKey.String(userID))
+	err := s.next.SetTimeZone(ctx, userID, timeZone)
+	End(span, err)
+
+	return err
+}
+
+func (s *Storage) GetEventsToNotify(ctx context.Context, from, to time.Time) ([]storage.Event, error) {
+	ctx, span := s.start(ctx, "GetEventsToNotify", period(from, to))
+	events, err := s.next.GetEventsToNotify(ctx, from, to)
+	span.SetAttributes(resultLenKey.Int(len(events)))
+	End(span, err)
+
+	return events, err
+}
+
+func (s *Storage) DeleteEventsBefore(ctx context.Context, t time.Time) (int64, error) {
+	ctx, span := s.start(ctx, "DeleteEventsBefore", beforeKey.String(t.UTC().Format(time.RFC3339)))
+	deleted, err := s.next.DeleteEventsBefore(ctx, t)
+	span.SetAttributes(resultLenKey.Int64(deleted))
+	End(span, err)
+
+	return deleted, err
+}
@@ -0,0 +1,115 @@
+// Package tracing sets up OpenTelemetry tracing of the calendar service.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar"
+
+// Exporter tells where spans are sent.
+type Exporter string
+
+const (
+	// ExporterNone disables tracing, incoming trace context is still passed on.
+	ExporterNone Exporter = "none"
+	// ExporterStdout writes spans as JSON, which is handy for local runs.
+	ExporterStdout Exporter = "stdout"
+	// ExporterOTLP sends spans to an OpenTelemetry collector over gRPC.
+	ExporterOTLP Exporter = "otlp"
+)
+
+// ParseExporter checks that s names a known exporter.
+func ParseExporter(s string) (Exporter, error) {
+	switch e := Exporter(s); e {
+	case ExporterNone, ExporterStdout, ExporterOTLP:
+		return e, nil
+	default:
+		return "", fmt.Errorf("unknown tracing exporter: %s", s)
+	}
+}
+
+type Config struct {
+	Exporter Exporter
+	// Endpoint is host:port of the OTLP receiver.
+	Endpoint string
+	// Insecure disables TLS of the OTLP connection.
+	Insecure       bool
+	ServiceName    string
+	ServiceVersion string
+	// SampleRatio is the fraction of traces started by the service which are recorded.
+	// Traces of sampled callers are always recorded.
+	SampleRatio float64
+	// Output receives spans of ExporterStdout.
+	Output io.Writer
+}
+
+// Setup installs the global tracer provider and the W3C trace context propagator.
+// The returned function flushes buffered spans and must be called on shutdown.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+	switch cfg.Exporter {
+	case ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(cfg.Output))
+	case ExporterOTLP:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter: %s", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s exporter: %w", cfg.Exporter, err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName(cfg.ServiceName),
+			semconv.ServiceVersion(cfg.ServiceVersion),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of the service from the global provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// End ends the span, marking it failed if err is not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,179 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage"
+	memorystorage "github.com/AndreyNagorskiy/otus-go-hw/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+const ownerID = "123e4567-e89b-12d3-a456-426614174000"
+
+func TestParseExporter(t *testing.T) {
+	for _, s := range []string{"none", "stdout", "otlp"} {
+		if got, err := ParseExporter(s); err != nil || string(got) != s {
+			t.Errorf("ParseExporter(%q) = %q, %v, want %q, nil", s, got, err, s)
+		}
+	}
+
+	if _, err := ParseExporter("zipkin"); err == nil {
+		t.Errorf("ParseExporter(%q) error = nil, want error", "zipkin")
+	}
+}
+
+func TestSetup_Stdout(t *testing.T) {
+	restoreProvider(t)
+
+	var out bytes.Buffer
+	shutdown, err := Setup(context.Background(), Config{
+		Exporter:    ExporterStdout,
+		ServiceName: "calendar",
+		SampleRatio: 1,
+		Output:      &out,
+	})
+	if err != nil {
+		t.Fatalf("Setup() error = %v, want nil", err)
+	}
+
+	_, span := Tracer().Start(context.Background(), "test-span")
+	span.End()
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown() error = %v, want nil", err)
+	}
+
+	if !strings.Contains(out.String(), `"Name":"test-span"`) {
+		t.Errorf("stdout exporter output %q does not contain the span", out.String())
+	}
+}
+
+func TestSetup_UnknownExporter(t *testing.T) {
+	restoreProvider(t)
+
+	if _, err := Setup(context.Background(), Config{Exporter: "zipkin"}); err == nil {
+		t.Errorf("Setup() error = nil, want error")
+	}
+}
+
+func TestStorage(t *testing.T) {
+	recorder := recordSpans(t)
+	s := NewStorage(memorystorage.NewStorage())
+
+	ctx, parent := Tracer().Start(context.Background(), "request")
+	event, err := s.CreateEvent(ctx, storage.CreateOrUpdateEventParams{
+		Title:     "Meeting",
+		StartTime: time.Now(),
+		EndTime:   time.Now().Add(time.Hour),
+		OwnerID:   ownerID,
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v, want nil", err)
+	}
+	if err := s.DeleteEvent(ctx, event.ID); err != nil {
+		t.Fatalf("DeleteEvent() error = %v, want nil", err)
+	}
+	if _, err := s.GetEvent(ctx, event.ID); !errors.Is(err, storage.ErrEventNotFound) {
+		t.Fatalf("GetEvent() error = %v, want %v", err, storage.ErrEventNotFound)
+	}
+	parent.End()
+
+	spans := recorder.Ended()
+	wantNames := []string{"storage.CreateEvent", "storage.DeleteEvent", "storage.GetEvent", "request"}
+	if len(spans) != len(wantNames) {
+		t.Fatalf("ended %d spans, want %d", len(spans), len(wantNames))
+	}
+
+	for i, want := range wantNames {
+		span := spans[i]
+		if span.Name() != want {
+			t.Errorf("span %d name = %q, want %q", i, span.Name(), want)
+		}
+		if i < len(wantNames)-1 && span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("span %q is not a child of the request span", span.Name())
+		}
+	}
+
+	if got := spans[0].Attributes(); !hasAttribute(got, string(eventIDKey), event.ID) {
+		t.Errorf("CreateEvent span attributes = %v, want %s=%s", got, eventIDKey, event.ID)
+	}
+	if got := spans[1].Status().Code; got != codes.Unset {
+		t.Errorf("DeleteEvent span status = %v, want %v", got, codes.Unset)
+	}
+	if got := spans[2].Status().Code; got != codes.Error {
+		t.Errorf("GetEvent span status = %v, want %v", got, codes.Error)
+	}
+}
+
+func TestQueryTracer(t *testing.T) {
+	recorder := recordSpans(t)
+	tracer := QueryTracer{}
+	query := "SELECT id FROM events WHERE id = $1"
+
+	ctx, parent := Tracer().Start(context.Background(), "storage.GetEvent")
+	queryCtx := tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "\n\t" + query})
+	tracer.TraceQueryEnd(queryCtx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 1")})
+	failedCtx := tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "DELETE FROM events"})
+	tracer.TraceQueryEnd(failedCtx, nil, pgx.TraceQueryEndData{Err: errors.New("connection reset")})
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("ended %d spans, want 3", len(spans))
+	}
+
+	selectSpan, deleteSpan := spans[0], spans[1]
+	if selectSpan.Name() != "SELECT" || deleteSpan.Name() != "DELETE" {
+		t.Errorf("span names = %q, %q, want %q, %q", selectSpan.Name(), deleteSpan.Name(), "SELECT", "DELETE")
+	}
+	if selectSpan.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("query span is not a child of the storage span")
+	}
+	if got := selectSpan.Attributes(); !hasAttribute(got, string(semconv.DBQueryTextKey), "\n\t"+query) {
+		t.Errorf("query span attributes = %v, want %s", got, semconv.DBQueryTextKey)
+	}
+	if got := deleteSpan.Status().Code; got != codes.Error {
+		t.Errorf("failed query span status = %v, want %v", got, codes.Error)
+	}
+}
+
+// recordSpans installs a global provider recording all spans until the end of the test.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	restoreProvider(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	return recorder
+}
+
+func restoreProvider(t *testing.T) {
+	t.Helper()
+
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+}
+
+func hasAttribute(attrs []attribute.KeyValue, key, value string) bool {
+	for _, attr := range attrs {
+		if string(attr.Key) == key && attr.Value.AsString() == value {
+			return true
+		}
+	}
+
+	return false
+}
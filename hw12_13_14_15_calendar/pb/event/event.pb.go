@@ -23,15 +23,189 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type AttendeeStatus int32
+
+const (
+	AttendeeStatus_ATTENDEE_STATUS_NEEDS_ACTION AttendeeStatus = 0
+	AttendeeStatus_ATTENDEE_STATUS_ACCEPTED     AttendeeStatus = 1
+	AttendeeStatus_ATTENDEE_STATUS_DECLINED     AttendeeStatus = 2
+	AttendeeStatus_ATTENDEE_STATUS_TENTATIVE    AttendeeStatus = 3
+)
+
+// Enum value maps for AttendeeStatus.
+var (
+	AttendeeStatus_name = map[int32]string{
+		0: "ATTENDEE_STATUS_NEEDS_ACTION",
+		1: "ATTENDEE_STATUS_ACCEPTED",
+		2: "ATTENDEE_STATUS_DECLINED",
+		3: "ATTENDEE_STATUS_TENTATIVE",
+	}
+	AttendeeStatus_value = map[string]int32{
+		"ATTENDEE_STATUS_NEEDS_ACTION": 0,
+		"ATTENDEE_STATUS_ACCEPTED":     1,
+		"ATTENDEE_STATUS_DECLINED":     2,
+		"ATTENDEE_STATUS_TENTATIVE":    3,
+	}
+)
+
+func (x AttendeeStatus) Enum() *AttendeeStatus {
+	p := new(AttendeeStatus)
+	*p = x
+	return p
+}
+
+func (x AttendeeStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AttendeeStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_event_event_proto_enumTypes[0].Descriptor()
+}
+
+func (AttendeeStatus) Type() protoreflect.EnumType {
+	return &file_event_event_proto_enumTypes[0]
+}
+
+func (x AttendeeStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AttendeeStatus.Descriptor instead.
+func (AttendeeStatus) EnumDescriptor() ([]byte, []int) {
+	return file_event_event_proto_rawDescGZIP(), []int{0}
+}
+
+type Weekday int32
+
+const (
+	// The first day of week configured on the server.
+	Weekday_WEEKDAY_UNSPECIFIED Weekday = 0
+	Weekday_WEEKDAY_MONDAY      Weekday = 1
+	Weekday_WEEKDAY_TUESDAY     Weekday = 2
+	Weekday_WEEKDAY_WEDNESDAY   Weekday = 3
+	Weekday_WEEKDAY_THURSDAY    Weekday = 4
+	Weekday_WEEKDAY_FRIDAY      Weekday = 5
+	Weekday_WEEKDAY_SATURDAY    Weekday = 6
+	Weekday_WEEKDAY_SUNDAY      Weekday = 7
+)
+
+// Enum value maps for Weekday.
+var (
+	Weekday_name = map[int32]string{
+		0: "WEEKDAY_UNSPECIFIED",
+		1: "WEEKDAY_MONDAY",
+		2: "WEEKDAY_TUESDAY",
+		3: "WEEKDAY_WEDNESDAY",
+		4: "WEEKDAY_THURSDAY",
+		5: "WEEKDAY_FRIDAY",
+		6: "WEEKDAY_SATURDAY",
+		7: "WEEKDAY_SUNDAY",
+	}
+	Weekday_value = map[string]int32{
+		"WEEKDAY_UNSPECIFIED": 0,
+		"WEEKDAY_MONDAY":      1,
+		"WEEKDAY_TUESDAY":     2,
+		"WEEKDAY_WEDNESDAY":   3,
+		"WEEKDAY_THURSDAY":    4,
+		"WEEKDAY_FRIDAY":      5,
+		"WEEKDAY_SATURDAY":    6,
+		"WEEKDAY_SUNDAY":      7,
+	}
+)
+
+func (x Weekday) Enum() *Weekday {
+	p := new(Weekday)
+	*p = x
+	return p
+}
+
+func (x Weekday) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Weekday) Descriptor() protoreflect.EnumDescriptor {
+	return file_event_event_proto_enumTypes[1].Descriptor()
+}
+
+func (Weekday) Type() protoreflect.EnumType {
+	return &file_event_event_proto_enumTypes[1]
+}
+
+func (x Weekday) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Weekday.Descriptor instead.
+func (Weekday) EnumDescriptor() ([]byte, []int) {
+	return file_event_event_proto_rawDescGZIP(), []int{1}
+}
+
+type EventSortField int32
+
+const (
+	EventSortField_EVENT_SORT_FIELD_START_TIME EventSortField = 0
+	EventSortField_EVENT_SORT_FIELD_TITLE      EventSortField = 1
+)
+
+// Enum value maps for EventSortField.
+var (
+	EventSortField_name = map[int32]string{
+		0: "EVENT_SORT_FIELD_START_TIME",
+		1: "EVENT_SORT_FIELD_TITLE",
+	}
+	EventSortField_value = map[string]int32{
+		"EVENT_SORT_FIELD_START_TIME": 0,
+		"EVENT_SORT_FIELD_TITLE":      1,
+	}
+)
+
+func (x EventSortField) Enum() *EventSortField {
+	p := new(EventSortField)
+	*p = x
+	return p
+}
+
+func (x EventSortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventSortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_event_event_proto_enumTypes[2].Descriptor()
+}
+
+func (EventSortField) Type() protoreflect.EnumType {
+	return &file_event_event_proto_enumTypes[2]
+}
+
+func (x EventSortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventSortField.Descriptor instead.
+func (EventSortField) EnumDescriptor() ([]byte, []int) {
+	return file_event_event_proto_rawDescGZIP(), []int{2}
+}
+
 type Event struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	StartTime     *timestamp.Timestamp   `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	EndTime       *timestamp.Timestamp   `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
-	Description   *string                `protobuf:"bytes,5,opt,name=description,proto3,oneof" json:"description,omitempty"`
-	OwnerId       string                 `protobuf:"bytes,6,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
-	NotifyBefore  *duration.Duration     `protobuf:"bytes,7,opt,name=notify_before,json=notifyBefore,proto3,oneof" json:"notify_before,omitempty"`
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title                string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	StartTime            *timestamp.Timestamp   `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime              *timestamp.Timestamp   `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Description          *string                `protobuf:"bytes,5,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	OwnerId              string                 `protobuf:"bytes,6,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	NotifyBefore         *duration.Duration     `protobuf:"bytes,7,opt,name=notify_before,json=notifyBefore,proto3,oneof" json:"notify_before,omitempty"`
+	RecurrenceRule       *string                `protobuf:"bytes,8,opt,name=recurrence_rule,json=recurrenceRule,proto3,oneof" json:"recurrence_rule,omitempty"`
+	RecurrenceExceptions []*timestamp.Timestamp `protobuf:"bytes,9,rep,name=recurrence_exceptions,json=recurrenceExceptions,proto3" json:"recurrence_exceptions,omitempty"`
+	Version              int64                  `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`
+	// Set for events in the trash.
+	DeletedAt *timestamp.Timestamp `protobuf:"bytes,11,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	Attendees []*Attendee          `protobuf:"bytes,12,rep,name=attendees,proto3" json:"attendees,omitempty"`
+	// All-day events last whole dates, start_time and end_time are midnights of the first date
+	// and of the date after the last one in the time zone of the listing.
+	AllDay bool `protobuf:"varint,13,opt,name=all_day,json=allDay,proto3" json:"all_day,omitempty"`
+	// IANA time zone the event recurs in, empty for all-day events which recur in UTC.
+	TimeZone      string `protobuf:"bytes,14,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -115,22 +289,133 @@ func (x *Event) GetNotifyBefore() *duration.Duration {
 	return nil
 }
 
-type CreateOrUpdateEventRequest struct {
+func (x *Event) GetRecurrenceRule() string {
+	if x != nil && x.RecurrenceRule != nil {
+		return *x.RecurrenceRule
+	}
+	return ""
+}
+
+func (x *Event) GetRecurrenceExceptions() []*timestamp.Timestamp {
+	if x != nil {
+		return x.RecurrenceExceptions
+	}
+	return nil
+}
+
+func (x *Event) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Event) GetDeletedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Event) GetAttendees() []*Attendee {
+	if x != nil {
+		return x.Attendees
+	}
+	return nil
+}
+
+func (x *Event) GetAllDay() bool {
+	if x != nil {
+		return x.AllDay
+	}
+	return false
+}
+
+func (x *Event) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type Attendee struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	StartTime     *timestamp.Timestamp   `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	EndTime       *timestamp.Timestamp   `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
-	Description   *string                `protobuf:"bytes,5,opt,name=description,proto3,oneof" json:"description,omitempty"`
-	OwnerId       string                 `protobuf:"bytes,6,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
-	NotifyBefore  *duration.Duration     `protobuf:"bytes,7,opt,name=notify_before,json=notifyBefore,proto3,oneof" json:"notify_before,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        AttendeeStatus         `protobuf:"varint,2,opt,name=status,proto3,enum=event.AttendeeStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attendee) Reset() {
+	*x = Attendee{}
+	mi := &file_event_event_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attendee) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attendee) ProtoMessage() {}
+
+func (x *Attendee) ProtoReflect() protoreflect.Message {
+	mi := &file_event_event_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attendee.ProtoReflect.Descriptor instead.
+func (*Attendee) Descriptor() ([]byte, []int) {
+	return file_event_event_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Attendee) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Attendee) GetStatus() AttendeeStatus {
+	if x != nil {
+		return x.Status
+	}
+	return AttendeeStatus_ATTENDEE_STATUS_NEEDS_ACTION
+}
+
+type CreateOrUpdateEventRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	StartTime   *timestamp.Timestamp   `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime     *timestamp.Timestamp   `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Description *string                `protobuf:"bytes,5,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// Defaults to the calling user.
+	OwnerId              string                 `protobuf:"bytes,6,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	NotifyBefore         *duration.Duration     `protobuf:"bytes,7,opt,name=notify_before,json=notifyBefore,proto3,oneof" json:"notify_before,omitempty"`
+	RecurrenceRule       *string                `protobuf:"bytes,8,opt,name=recurrence_rule,json=recurrenceRule,proto3,oneof" json:"recurrence_rule,omitempty"`
+	RecurrenceExceptions []*timestamp.Timestamp `protobuf:"bytes,9,rep,name=recurrence_exceptions,json=recurrenceExceptions,proto3" json:"recurrence_exceptions,omitempty"`
+	// Update fails with ABORTED if the event has another version.
+	ExpectedVersion *int64 `protobuf:"varint,10,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	// For all-day events only UTC dates of start_time and end_time are used,
+	// an end_time after midnight includes its date.
+	AllDay bool `protobuf:"varint,11,opt,name=all_day,json=allDay,proto3" json:"all_day,omitempty"`
+	// IANA time zone recurring events keep their wall clock time in, defaults to the time zone of the owner.
+	TimeZone      string `protobuf:"bytes,12,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateOrUpdateEventRequest) Reset() {
 	*x = CreateOrUpdateEventRequest{}
-	mi := &file_event_event_proto_msgTypes[1]
+	mi := &file_event_event_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -142,7 +427,7 @@ func (x *CreateOrUpdateEventRequest) String() string {
 func (*CreateOrUpdateEventRequest) ProtoMessage() {}
 
 func (x *CreateOrUpdateEventRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_event_event_proto_msgTypes[1]
+	mi := &file_event_event_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -155,7 +440,7 @@ func (x *CreateOrUpdateEventRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateOrUpdateEventRequest.ProtoReflect.Descriptor instead.
 func (*CreateOrUpdateEventRequest) Descriptor() ([]byte, []int) {
-	return file_event_event_proto_rawDescGZIP(), []int{1}
+	return file_event_event_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CreateOrUpdateEventRequest) GetId() string {
@@ -207,71 +492,62 @@ func (x *CreateOrUpdateEventRequest) GetNotifyBefore() *duration.Duration {
 	return nil
 }
 
-type DeleteEventRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *CreateOrUpdateEventRequest) GetRecurrenceRule() string {
+	if x != nil && x.RecurrenceRule != nil {
+		return *x.RecurrenceRule
+	}
+	return ""
 }
 
-func (x *DeleteEventRequest) Reset() {
-	*x = DeleteEventRequest{}
-	mi := &file_event_event_proto_msgTypes[2]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *CreateOrUpdateEventRequest) GetRecurrenceExceptions() []*timestamp.Timestamp {
+	if x != nil {
+		return x.RecurrenceExceptions
+	}
+	return nil
 }
 
-func (x *DeleteEventRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *CreateOrUpdateEventRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
 }
 
-func (*DeleteEventRequest) ProtoMessage() {}
-
-func (x *DeleteEventRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_event_event_proto_msgTypes[2]
+func (x *CreateOrUpdateEventRequest) GetAllDay() bool {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.AllDay
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use DeleteEventRequest.ProtoReflect.Descriptor instead.
-func (*DeleteEventRequest) Descriptor() ([]byte, []int) {
-	return file_event_event_proto_rawDescGZIP(), []int{2}
+	return false
 }
 
-func (x *DeleteEventRequest) GetId() string {
+func (x *CreateOrUpdateEventRequest) GetTimeZone() string {
 	if x != nil {
-		return x.Id
+		return x.TimeZone
 	}
 	return ""
 }
 
-type GetEventRequest struct {
+type DeleteEventRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetEventRequest) Reset() {
-	*x = GetEventRequest{}
+func (x *DeleteEventRequest) Reset() {
+	*x = DeleteEventRequest{}
 	mi := &file_event_event_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetEventRequest) String() string {
+func (x *DeleteEventRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetEventRequest) ProtoMessage() {}
+func (*DeleteEventRequest) ProtoMessage() {}
 
-func (x *GetEventRequest) ProtoReflect() protoreflect.Message {
+func (x *DeleteEventRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_event_event_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -283,39 +559,39 @@ func (x *GetEventRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetEventRequest.ProtoReflect.Descriptor instead.
-func (*GetEventRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteEventRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEventRequest) Descriptor() ([]byte, []int) {
 	return file_event_event_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetEventRequest) GetId() string {
+func (x *DeleteEventRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-type DateRequest struct {
+type RestoreEventRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Date          *timestamp.Timestamp   `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DateRequest) Reset() {
-	*x = DateRequest{}
+func (x *RestoreEventRequest) Reset() {
+	*x = RestoreEventRequest{}
 	mi := &file_event_event_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DateRequest) String() string {
+func (x *RestoreEventRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DateRequest) ProtoMessage() {}
+func (*RestoreEventRequest) ProtoMessage() {}
 
-func (x *DateRequest) ProtoReflect() protoreflect.Message {
+func (x *RestoreEventRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_event_event_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -327,39 +603,38 @@ func (x *DateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DateRequest.ProtoReflect.Descriptor instead.
-func (*DateRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RestoreEventRequest.ProtoReflect.Descriptor instead.
+func (*RestoreEventRequest) Descriptor() ([]byte, []int) {
 	return file_event_event_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *DateRequest) GetDate() *timestamp.Timestamp {
+func (x *RestoreEventRequest) GetId() string {
 	if x != nil {
-		return x.Date
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type EventListResponse struct {
+type ListDeletedEventsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Events        []*Event               `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EventListResponse) Reset() {
-	*x = EventListResponse{}
+func (x *ListDeletedEventsRequest) Reset() {
+	*x = ListDeletedEventsRequest{}
 	mi := &file_event_event_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EventListResponse) String() string {
+func (x *ListDeletedEventsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EventListResponse) ProtoMessage() {}
+func (*ListDeletedEventsRequest) ProtoMessage() {}
 
-func (x *EventListResponse) ProtoReflect() protoreflect.Message {
+func (x *ListDeletedEventsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_event_event_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -371,38 +646,32 @@ func (x *EventListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EventListResponse.ProtoReflect.Descriptor instead.
-func (*EventListResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListDeletedEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListDeletedEventsRequest) Descriptor() ([]byte, []int) {
 	return file_event_event_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *EventListResponse) GetEvents() []*Event {
-	if x != nil {
-		return x.Events
-	}
-	return nil
-}
-
-type EmptyRequest struct {
+type GetEventRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EmptyRequest) Reset() {
-	*x = EmptyRequest{}
+func (x *GetEventRequest) Reset() {
+	*x = GetEventRequest{}
 	mi := &file_event_event_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EmptyRequest) String() string {
+func (x *GetEventRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EmptyRequest) ProtoMessage() {}
+func (*GetEventRequest) ProtoMessage() {}
 
-func (x *EmptyRequest) ProtoReflect() protoreflect.Message {
+func (x *GetEventRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_event_event_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -414,31 +683,43 @@ func (x *EmptyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EmptyRequest.ProtoReflect.Descriptor instead.
-func (*EmptyRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetEventRequest.ProtoReflect.Descriptor instead.
+func (*GetEventRequest) Descriptor() ([]byte, []int) {
 	return file_event_event_proto_rawDescGZIP(), []int{6}
 }
 
-type EmptyResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *GetEventRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only the UTC date is used, e.g. 2026-10-18T00:00:00Z is October 18 in every time_zone.
+	Date *timestamp.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	// IANA time zone of the period boundaries and returned event times,
+	// defaults to the time zone of the calling user.
+	TimeZone      string `protobuf:"bytes,2,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EmptyResponse) Reset() {
-	*x = EmptyResponse{}
+func (x *DateRequest) Reset() {
+	*x = DateRequest{}
 	mi := &file_event_event_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EmptyResponse) String() string {
+func (x *DateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EmptyResponse) ProtoMessage() {}
+func (*DateRequest) ProtoMessage() {}
 
-func (x *EmptyResponse) ProtoReflect() protoreflect.Message {
+func (x *DateRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_event_event_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -450,16 +731,1142 @@ func (x *EmptyResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
+// Deprecated: Use DateRequest.ProtoReflect.Descriptor instead.
+func (*DateRequest) Descriptor() ([]byte, []int) {
+	return file_event_event_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DateRequest) GetDate() *timestamp.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *DateRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+// The week is addressed either by a date in it or by iso_week, which can not be combined with week_start.
+type WeekRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only the UTC date is used, e.g. 2026-10-18T00:00:00Z is October 18 in every time_zone.
+	Date *timestamp.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	// IANA time zone of the period boundaries and returned event times,
+	// defaults to the time zone of the calling user.
+	TimeZone  string  `protobuf:"bytes,2,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	WeekStart Weekday `protobuf:"varint,3,opt,name=week_start,json=weekStart,proto3,enum=event.Weekday" json:"week_start,omitempty"`
+	// ISO 8601 week starting on Monday, e.g. 2026-W42.
+	IsoWeek       string `protobuf:"bytes,4,opt,name=iso_week,json=isoWeek,proto3" json:"iso_week,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WeekRequest) Reset() {
+	*x = WeekRequest{}
+	mi := &file_event_event_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WeekRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeekRequest) ProtoMessage() {}
+
+func (x *WeekRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_event_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeekRequest.ProtoReflect.Descriptor instead.
+func (*WeekRequest) Descriptor() ([]byte, []int) {
+	return file_event_event_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WeekRequest) GetDate() *timestamp.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *WeekRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *WeekRequest) GetWeekStart() Weekday {
+	if x != nil {
+		return x.WeekStart
+	}
+	return Weekday_WEEKDAY_UNSPECIFIED
+}
+
+func (x *WeekRequest) GetIsoWeek() string {
+	if x != nil {
+		return x.IsoWeek
+	}
+	return ""
+}
+
+type GetTimeZoneRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Defaults to the calling user.
+	UserId        string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTimeZoneRequest) Reset() {
+	*x = GetTimeZoneRequest{}
+	mi := &file_event_event_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTimeZoneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTimeZoneRequest) ProtoMessage() {}
+
+func (x *GetTimeZoneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_event_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTimeZoneRequest.ProtoReflect.Descriptor instead.
+func (*GetTimeZoneRequest) Descriptor() ([]byte, []int) {
+	return file_event_event_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetTimeZoneRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type TimeZone struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Defaults to the calling user.
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// IANA time zone name.
+	TimeZone      string `protobuf:"bytes,2,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeZone) Reset() {
+	*x = TimeZone{}
+	mi := &file_event_event_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeZone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeZone) ProtoMessage() {}
+
+func (x *TimeZone) ProtoReflect() protoreflect.Message {
+	mi := &file_event_event_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeZone.ProtoReflect.Descriptor instead.
+func (*TimeZone) Descriptor() ([]byte, []int) {
+	return file_event_event_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TimeZone) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TimeZone) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type AttendeeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttendeeRequest) Reset() {
+	*x = AttendeeRequest{}
+	mi := &file_event_event_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttendeeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttendeeRequest) ProtoMessage() {}
+
+func (x *AttendeeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_event_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttendeeRequest.ProtoReflect.Descriptor instead.
+func (*AttendeeRequest) Descriptor() ([]byte, []int) {
+	return file_event_event_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AttendeeRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *AttendeeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RespondToEventRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	EventId string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// Defaults to the calling user.
+	UserId        string         `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        AttendeeStatus `protobuf:"varint,3,opt,name=status,proto3,enum=event.AttendeeStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RespondToEventRequest) Reset() {
+	*x = RespondToEventRequest{}
+	mi := &file_event_event_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RespondToEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespondToEventRequest) ProtoMessage() {}
+
+func (x *RespondToEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_event_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespondToEventRequest.ProtoReflect.Descriptor instead.
+func (*RespondToEventRequest) Descriptor() ([]byte, []int) {
+	return file_event_event_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RespondToEventRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *RespondToEventRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RespondToEventRequest) GetStatus() AttendeeStatus {
+	if x != nil {
+		return x.Status
+	}
+	return AttendeeStatus_ATTENDEE_STATUS_NEEDS_ACTION
+}
+
+type ListEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Events starting in [from, to).
+	From *timestamp.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   *timestamp.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// Case-insensitive title substring.
+	Title      string         `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	SortBy     EventSortField `protobuf:"varint,4,opt,name=sort_by,json=sortBy,proto3,enum=event.EventSortField" json:"sort_by,omitempty"`
+	Descending bool           `protobuf:"varint,5,opt,name=descending,proto3" json:"descending,omitempty"`
+	PageSize   int32          `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page.
+	PageToken     string `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEventsRequest) Reset() {
+	*x = ListEventsRequest{}
+	mi := &file_event_event_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEventsRequest) ProtoMessage() {}
+
+func (x *ListEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_event_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListEventsRequest) Descriptor() ([]byte, []int) {
+	return file_event_event_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListEventsRequest) GetFrom() *timestamp.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListEventsRequest) GetTo() *timestamp.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ListEventsRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetSortBy() EventSortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return EventSortField_EVENT_SORT_FIELD_START_TIME
+}
+
+func (x *ListEventsRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *ListEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListEventsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type EventListResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Events []*Event               `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// Empty on the last page and for day, week and month listings.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Time zone of day, week and month periods.
+	TimeZone      string `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventListResponse) Reset() {
+	*x = EventListResponse{}
+	mi := &file_event_event_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventListResponse) ProtoMessage() {}
+
+func (x *EventListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_event_event_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventListResponse.ProtoReflect.Descriptor instead.
+func (*EventListResponse) Descriptor() ([]byte, []int) {
+	return file_event_event_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *EventListResponse) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *EventListResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *EventListResponse) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type FreeBusyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	From          *timestamp.Timestamp   `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamp.Timestamp   `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FreeBusyRequest) Reset() {
+	*x = FreeBusyRequest{}
+	mi := &file_event_event_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FreeBusyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FreeBusyRequest) ProtoMessage() {}
+
+func (x *FreeBusyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_event_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FreeBusyRequest.ProtoReflect.Descriptor instead.
+func (*FreeBusyRequest) Descriptor() ([]byte, []int) {
+	return file_event_event_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *FreeBusyRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *FreeBusyRequest) GetFrom() *timestamp.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *FreeBusyRequest) GetTo() *timestamp.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type TimeInterval struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         *timestamp.Timestamp   `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamp.Timestamp   `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeInterval) Reset() {
+	*x = TimeInterval{}
+	mi := &file_event_event_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeInterval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeInterval) ProtoMessage() {}
+
+func (x *TimeInterval) ProtoReflect() protoreflect.Message {
+	mi := &file_event_event_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeInterval.ProtoReflect.Descriptor instead.
+func (*TimeInterval) Descriptor() ([]byte, []int) {
+	return file_event_event_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TimeInterval) GetStart() *timestamp.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *TimeInterval) GetEnd() *timestamp.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+// Sorted non-overlapping intervals in which any of the users is busy.
+type FreeBusyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Busy          []*TimeInterval        `protobuf:"bytes,1,rep,name=busy,proto3" json:"busy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FreeBusyResponse) Reset() {
+	*x = FreeBusyResponse{}
+	mi := &file_event_event_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FreeBusyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FreeBusyResponse) ProtoMessage() {}
+
+func (x *FreeBusyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_event_event_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FreeBusyResponse.ProtoReflect.Descriptor instead.
+func (*FreeBusyResponse) Descriptor() ([]byte, []int) {
+	return file_event_event_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *FreeBusyResponse) GetBusy() []*TimeInterval {
+	if x != nil {
+		return x.Busy
+	}
+	return nil
+}
+
+// Offsets from the local midnight, unset start and end allow the whole day.
+type WorkingHours struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         *duration.Duration     `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           *duration.Duration     `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	WeekdaysOnly  bool                   `protobuf:"varint,3,opt,name=weekdays_only,json=weekdaysOnly,proto3" json:"weekdays_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkingHours) Reset() {
+	*x = WorkingHours{}
+	mi := &file_event_event_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkingHours) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkingHours) ProtoMessage() {}
+
+func (x *WorkingHours) ProtoReflect() protoreflect.Message {
+	mi := &file_event_event_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkingHours.ProtoReflect.Descriptor instead.
+func (*WorkingHours) Descriptor() ([]byte, []int) {
+	return file_event_event_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *WorkingHours) GetStart() *duration.Duration {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *WorkingHours) GetEnd() *duration.Duration {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *WorkingHours) GetWeekdaysOnly() bool {
+	if x != nil {
+		return x.WeekdaysOnly
+	}
+	return false
+}
+
+type FindSlotsRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	UserIds      []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	Duration     *duration.Duration     `protobuf:"bytes,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	From         *timestamp.Timestamp   `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To           *timestamp.Timestamp   `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	WorkingHours *WorkingHours          `protobuf:"bytes,5,opt,name=working_hours,json=workingHours,proto3" json:"working_hours,omitempty"`
+	// IANA time zones of the users for working hours, UTC by default.
+	TimeZones map[string]string `protobuf:"bytes,6,rep,name=time_zones,json=timeZones,proto3" json:"time_zones,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Alignment of slot starts, 30 minutes by default.
+	Step          *duration.Duration `protobuf:"bytes,7,opt,name=step,proto3" json:"step,omitempty"`
+	Limit         int32              `protobuf:"varint,8,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindSlotsRequest) Reset() {
+	*x = FindSlotsRequest{}
+	mi := &file_event_event_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindSlotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindSlotsRequest) ProtoMessage() {}
+
+func (x *FindSlotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_event_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindSlotsRequest.ProtoReflect.Descriptor instead.
+func (*FindSlotsRequest) Descriptor() ([]byte, []int) {
+	return file_event_event_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *FindSlotsRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *FindSlotsRequest) GetDuration() *duration.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *FindSlotsRequest) GetFrom() *timestamp.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *FindSlotsRequest) GetTo() *timestamp.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *FindSlotsRequest) GetWorkingHours() *WorkingHours {
+	if x != nil {
+		return x.WorkingHours
+	}
+	return nil
+}
+
+func (x *FindSlotsRequest) GetTimeZones() map[string]string {
+	if x != nil {
+		return x.TimeZones
+	}
+	return nil
+}
+
+func (x *FindSlotsRequest) GetStep() *duration.Duration {
+	if x != nil {
+		return x.Step
+	}
+	return nil
+}
+
+func (x *FindSlotsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// The earliest slots in which all users are free.
+type FindSlotsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slots         []*TimeInterval        `protobuf:"bytes,1,rep,name=slots,proto3" json:"slots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindSlotsResponse) Reset() {
+	*x = FindSlotsResponse{}
+	mi := &file_event_event_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindSlotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindSlotsResponse) ProtoMessage() {}
+
+func (x *FindSlotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_event_event_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindSlotsResponse.ProtoReflect.Descriptor instead.
+func (*FindSlotsResponse) Descriptor() ([]byte, []int) {
+	return file_event_event_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *FindSlotsResponse) GetSlots() []*TimeInterval {
+	if x != nil {
+		return x.Slots
+	}
+	return nil
+}
+
+type ExportICalendarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamp.Timestamp   `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamp.Timestamp   `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	OwnerId       *string                `protobuf:"bytes,3,opt,name=owner_id,json=ownerId,proto3,oneof" json:"owner_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportICalendarRequest) Reset() {
+	*x = ExportICalendarRequest{}
+	mi := &file_event_event_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportICalendarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportICalendarRequest) ProtoMessage() {}
+
+func (x *ExportICalendarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_event_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportICalendarRequest.ProtoReflect.Descriptor instead.
+func (*ExportICalendarRequest) Descriptor() ([]byte, []int) {
+	return file_event_event_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ExportICalendarRequest) GetFrom() *timestamp.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ExportICalendarRequest) GetTo() *timestamp.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ExportICalendarRequest) GetOwnerId() string {
+	if x != nil && x.OwnerId != nil {
+		return *x.OwnerId
+	}
+	return ""
+}
+
+type ICalendarChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ICalendarChunk) Reset() {
+	*x = ICalendarChunk{}
+	mi := &file_event_event_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ICalendarChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ICalendarChunk) ProtoMessage() {}
+
+func (x *ICalendarChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_event_event_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ICalendarChunk.ProtoReflect.Descriptor instead.
+func (*ICalendarChunk) Descriptor() ([]byte, []int) {
+	return file_event_event_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ICalendarChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// owner_id is taken from the first message of the stream and defaults to the calling user.
+type ImportICalendarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportICalendarRequest) Reset() {
+	*x = ImportICalendarRequest{}
+	mi := &file_event_event_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportICalendarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportICalendarRequest) ProtoMessage() {}
+
+func (x *ImportICalendarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_event_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportICalendarRequest.ProtoReflect.Descriptor instead.
+func (*ImportICalendarRequest) Descriptor() ([]byte, []int) {
+	return file_event_event_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ImportICalendarRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *ImportICalendarRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ImportICalendarError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int64                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Uid           string                 `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportICalendarError) Reset() {
+	*x = ImportICalendarError{}
+	mi := &file_event_event_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportICalendarError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportICalendarError) ProtoMessage() {}
+
+func (x *ImportICalendarError) ProtoReflect() protoreflect.Message {
+	mi := &file_event_event_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportICalendarError.ProtoReflect.Descriptor instead.
+func (*ImportICalendarError) Descriptor() ([]byte, []int) {
+	return file_event_event_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ImportICalendarError) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ImportICalendarError) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *ImportICalendarError) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ImportICalendarResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Imported      int64                   `protobuf:"varint,1,opt,name=imported,proto3" json:"imported,omitempty"`
+	Errors        []*ImportICalendarError `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportICalendarResponse) Reset() {
+	*x = ImportICalendarResponse{}
+	mi := &file_event_event_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportICalendarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportICalendarResponse) ProtoMessage() {}
+
+func (x *ImportICalendarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_event_event_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportICalendarResponse.ProtoReflect.Descriptor instead.
+func (*ImportICalendarResponse) Descriptor() ([]byte, []int) {
+	return file_event_event_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ImportICalendarResponse) GetImported() int64 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportICalendarResponse) GetErrors() []*ImportICalendarError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type EmptyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmptyResponse) Reset() {
+	*x = EmptyResponse{}
+	mi := &file_event_event_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmptyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmptyResponse) ProtoMessage() {}
+
+func (x *EmptyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_event_event_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
 // Deprecated: Use EmptyResponse.ProtoReflect.Descriptor instead.
 func (*EmptyResponse) Descriptor() ([]byte, []int) {
-	return file_event_event_proto_rawDescGZIP(), []int{7}
+	return file_event_event_proto_rawDescGZIP(), []int{26}
 }
 
 var File_event_event_proto protoreflect.FileDescriptor
 
 const file_event_event_proto_rawDesc = "" +
 	"\n" +
-	"\x11event/event.proto\x12\x05event\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\"\xc8\x02\n" +
+	"\x11event/event.proto\x12\x05event\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\"\x95\x05\n" +
 	"\x05Event\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x129\n" +
@@ -468,9 +1875,22 @@ const file_event_event_proto_rawDesc = "" +
 	"\bend_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12%\n" +
 	"\vdescription\x18\x05 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x19\n" +
 	"\bowner_id\x18\x06 \x01(\tR\aownerId\x12C\n" +
-	"\rnotify_before\x18\a \x01(\v2\x19.google.protobuf.DurationH\x01R\fnotifyBefore\x88\x01\x01B\x0e\n" +
+	"\rnotify_before\x18\a \x01(\v2\x19.google.protobuf.DurationH\x01R\fnotifyBefore\x88\x01\x01\x12,\n" +
+	"\x0frecurrence_rule\x18\b \x01(\tH\x02R\x0erecurrenceRule\x88\x01\x01\x12O\n" +
+	"\x15recurrence_exceptions\x18\t \x03(\v2\x1a.google.protobuf.TimestampR\x14recurrenceExceptions\x12\x18\n" +
+	"\aversion\x18\n" +
+	" \x01(\x03R\aversion\x129\n" +
+	"\n" +
+	"deleted_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12-\n" +
+	"\tattendees\x18\f \x03(\v2\x0f.event.AttendeeR\tattendees\x12\x17\n" +
+	"\aall_day\x18\r \x01(\bR\x06allDay\x12\x1b\n" +
+	"\ttime_zone\x18\x0e \x01(\tR\btimeZoneB\x0e\n" +
 	"\f_descriptionB\x10\n" +
-	"\x0e_notify_before\"\xdd\x02\n" +
+	"\x0e_notify_beforeB\x12\n" +
+	"\x10_recurrence_rule\"R\n" +
+	"\bAttendee\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12-\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x15.event.AttendeeStatusR\x06status\"\xeb\x04\n" +
 	"\x1aCreateOrUpdateEventRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x129\n" +
@@ -479,29 +1899,144 @@ const file_event_event_proto_rawDesc = "" +
 	"\bend_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12%\n" +
 	"\vdescription\x18\x05 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x19\n" +
 	"\bowner_id\x18\x06 \x01(\tR\aownerId\x12C\n" +
-	"\rnotify_before\x18\a \x01(\v2\x19.google.protobuf.DurationH\x01R\fnotifyBefore\x88\x01\x01B\x0e\n" +
+	"\rnotify_before\x18\a \x01(\v2\x19.google.protobuf.DurationH\x01R\fnotifyBefore\x88\x01\x01\x12,\n" +
+	"\x0frecurrence_rule\x18\b \x01(\tH\x02R\x0erecurrenceRule\x88\x01\x01\x12O\n" +
+	"\x15recurrence_exceptions\x18\t \x03(\v2\x1a.google.protobuf.TimestampR\x14recurrenceExceptions\x12.\n" +
+	"\x10expected_version\x18\n" +
+	" \x01(\x03H\x03R\x0fexpectedVersion\x88\x01\x01\x12\x17\n" +
+	"\aall_day\x18\v \x01(\bR\x06allDay\x12\x1b\n" +
+	"\ttime_zone\x18\f \x01(\tR\btimeZoneB\x0e\n" +
 	"\f_descriptionB\x10\n" +
-	"\x0e_notify_before\"$\n" +
+	"\x0e_notify_beforeB\x12\n" +
+	"\x10_recurrence_ruleB\x13\n" +
+	"\x11_expected_version\"$\n" +
 	"\x12DeleteEventRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"!\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"%\n" +
+	"\x13RestoreEventRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1a\n" +
+	"\x18ListDeletedEventsRequest\"!\n" +
 	"\x0fGetEventRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"=\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"Z\n" +
 	"\vDateRequest\x12.\n" +
-	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\"9\n" +
+	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x1b\n" +
+	"\ttime_zone\x18\x02 \x01(\tR\btimeZone\"\xa4\x01\n" +
+	"\vWeekRequest\x12.\n" +
+	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x1b\n" +
+	"\ttime_zone\x18\x02 \x01(\tR\btimeZone\x12-\n" +
+	"\n" +
+	"week_start\x18\x03 \x01(\x0e2\x0e.event.WeekdayR\tweekStart\x12\x19\n" +
+	"\biso_week\x18\x04 \x01(\tR\aisoWeek\"-\n" +
+	"\x12GetTimeZoneRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"@\n" +
+	"\bTimeZone\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\ttime_zone\x18\x02 \x01(\tR\btimeZone\"E\n" +
+	"\x0fAttendeeRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"z\n" +
+	"\x15RespondToEventRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12-\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x15.event.AttendeeStatusR\x06status\"\x91\x02\n" +
+	"\x11ListEventsRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12.\n" +
+	"\asort_by\x18\x04 \x01(\x0e2\x15.event.EventSortFieldR\x06sortBy\x12\x1e\n" +
+	"\n" +
+	"descending\x18\x05 \x01(\bR\n" +
+	"descending\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageToken\"~\n" +
 	"\x11EventListResponse\x12$\n" +
-	"\x06events\x18\x01 \x03(\v2\f.event.EventR\x06events\"\x0e\n" +
-	"\fEmptyRequest\"\x0f\n" +
-	"\rEmptyResponse2\x83\x04\n" +
-	"\x06Events\x12C\n" +
-	"\x06Create\x12!.event.CreateOrUpdateEventRequest\x1a\x14.event.EmptyResponse\"\x00\x12-\n" +
-	"\x03Get\x12\x16.event.GetEventRequest\x1a\f.event.Event\"\x00\x12C\n" +
-	"\x06Update\x12!.event.CreateOrUpdateEventRequest\x1a\x14.event.EmptyResponse\"\x00\x12;\n" +
-	"\x06Delete\x12\x19.event.DeleteEventRequest\x1a\x14.event.EmptyResponse\"\x00\x12=\n" +
+	"\x06events\x18\x01 \x03(\v2\f.event.EventR\x06events\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1b\n" +
+	"\ttime_zone\x18\x03 \x01(\tR\btimeZone\"\x88\x01\n" +
+	"\x0fFreeBusyRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"n\n" +
+	"\fTimeInterval\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\";\n" +
+	"\x10FreeBusyResponse\x12'\n" +
+	"\x04busy\x18\x01 \x03(\v2\x13.event.TimeIntervalR\x04busy\"\x91\x01\n" +
+	"\fWorkingHours\x12/\n" +
+	"\x05start\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x05start\x12+\n" +
+	"\x03end\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x03end\x12#\n" +
+	"\rweekdays_only\x18\x03 \x01(\bR\fweekdaysOnly\"\xc4\x03\n" +
+	"\x10FindSlotsRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\x125\n" +
+	"\bduration\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12.\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x128\n" +
+	"\rworking_hours\x18\x05 \x01(\v2\x13.event.WorkingHoursR\fworkingHours\x12E\n" +
+	"\n" +
+	"time_zones\x18\x06 \x03(\v2&.event.FindSlotsRequest.TimeZonesEntryR\ttimeZones\x12-\n" +
+	"\x04step\x18\a \x01(\v2\x19.google.protobuf.DurationR\x04step\x12\x14\n" +
+	"\x05limit\x18\b \x01(\x05R\x05limit\x1a<\n" +
+	"\x0eTimeZonesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\">\n" +
+	"\x11FindSlotsResponse\x12)\n" +
+	"\x05slots\x18\x01 \x03(\v2\x13.event.TimeIntervalR\x05slots\"\xa1\x01\n" +
+	"\x16ExportICalendarRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x1e\n" +
+	"\bowner_id\x18\x03 \x01(\tH\x00R\aownerId\x88\x01\x01B\v\n" +
+	"\t_owner_id\"$\n" +
+	"\x0eICalendarChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"G\n" +
+	"\x16ImportICalendarRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\tR\aownerId\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"T\n" +
+	"\x14ImportICalendarError\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\tR\x03uid\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"j\n" +
+	"\x17ImportICalendarResponse\x12\x1a\n" +
+	"\bimported\x18\x01 \x01(\x03R\bimported\x123\n" +
+	"\x06errors\x18\x02 \x03(\v2\x1b.event.ImportICalendarErrorR\x06errors\"\x0f\n" +
+	"\rEmptyResponse*\x8d\x01\n" +
+	"\x0eAttendeeStatus\x12 \n" +
+	"\x1cATTENDEE_STATUS_NEEDS_ACTION\x10\x00\x12\x1c\n" +
+	"\x18ATTENDEE_STATUS_ACCEPTED\x10\x01\x12\x1c\n" +
+	"\x18ATTENDEE_STATUS_DECLINED\x10\x02\x12\x1d\n" +
+	"\x19ATTENDEE_STATUS_TENTATIVE\x10\x03*\xb6\x01\n" +
+	"\aWeekday\x12\x17\n" +
+	"\x13WEEKDAY_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eWEEKDAY_MONDAY\x10\x01\x12\x13\n" +
+	"\x0fWEEKDAY_TUESDAY\x10\x02\x12\x15\n" +
+	"\x11WEEKDAY_WEDNESDAY\x10\x03\x12\x14\n" +
+	"\x10WEEKDAY_THURSDAY\x10\x04\x12\x12\n" +
+	"\x0eWEEKDAY_FRIDAY\x10\x05\x12\x14\n" +
+	"\x10WEEKDAY_SATURDAY\x10\x06\x12\x12\n" +
+	"\x0eWEEKDAY_SUNDAY\x10\a*M\n" +
+	"\x0eEventSortField\x12\x1f\n" +
+	"\x1bEVENT_SORT_FIELD_START_TIME\x10\x00\x12\x1a\n" +
+	"\x16EVENT_SORT_FIELD_TITLE\x10\x012\xe6\t\n" +
+	"\x06Events\x12;\n" +
+	"\x06Create\x12!.event.CreateOrUpdateEventRequest\x1a\f.event.Event\"\x00\x12-\n" +
+	"\x03Get\x12\x16.event.GetEventRequest\x1a\f.event.Event\"\x00\x12;\n" +
+	"\x06Update\x12!.event.CreateOrUpdateEventRequest\x1a\f.event.Event\"\x00\x12;\n" +
+	"\x06Delete\x12\x19.event.DeleteEventRequest\x1a\x14.event.EmptyResponse\"\x00\x125\n" +
+	"\aRestore\x12\x1a.event.RestoreEventRequest\x1a\f.event.Event\"\x00\x12P\n" +
+	"\x11ListDeletedEvents\x12\x1f.event.ListDeletedEventsRequest\x1a\x18.event.EventListResponse\"\x00\x12@\n" +
+	"\x0eInviteAttendee\x12\x16.event.AttendeeRequest\x1a\x14.event.EmptyResponse\"\x00\x12@\n" +
+	"\x0eRemoveAttendee\x12\x16.event.AttendeeRequest\x1a\x14.event.EmptyResponse\"\x00\x12F\n" +
+	"\x0eRespondToEvent\x12\x1c.event.RespondToEventRequest\x1a\x14.event.EmptyResponse\"\x00\x12=\n" +
+	"\bFreeBusy\x12\x16.event.FreeBusyRequest\x1a\x17.event.FreeBusyResponse\"\x00\x12@\n" +
+	"\tFindSlots\x12\x17.event.FindSlotsRequest\x1a\x18.event.FindSlotsResponse\"\x00\x12B\n" +
 	"\n" +
-	"ListEvents\x12\x13.event.EmptyRequest\x1a\x18.event.EventListResponse\"\x00\x12?\n" +
+	"ListEvents\x12\x18.event.ListEventsRequest\x1a\x18.event.EventListResponse\"\x00\x12?\n" +
 	"\rListDayEvents\x12\x12.event.DateRequest\x1a\x18.event.EventListResponse\"\x00\x12@\n" +
-	"\x0eListWeekEvents\x12\x12.event.DateRequest\x1a\x18.event.EventListResponse\"\x00\x12A\n" +
-	"\x0fListMonthEvents\x12\x12.event.DateRequest\x1a\x18.event.EventListResponse\"\x00B\aZ\x05./;pbb\x06proto3"
+	"\x0eListWeekEvents\x12\x12.event.WeekRequest\x1a\x18.event.EventListResponse\"\x00\x12A\n" +
+	"\x0fListMonthEvents\x12\x12.event.DateRequest\x1a\x18.event.EventListResponse\"\x00\x12;\n" +
+	"\vGetTimeZone\x12\x19.event.GetTimeZoneRequest\x1a\x0f.event.TimeZone\"\x00\x126\n" +
+	"\vSetTimeZone\x12\x0f.event.TimeZone\x1a\x14.event.EmptyResponse\"\x00\x12K\n" +
+	"\x0fExportICalendar\x12\x1d.event.ExportICalendarRequest\x1a\x15.event.ICalendarChunk\"\x000\x01\x12T\n" +
+	"\x0fImportICalendar\x12\x1d.event.ImportICalendarRequest\x1a\x1e.event.ImportICalendarResponse\"\x00(\x01B\aZ\x05./;pbb\x06proto3"
 
 var (
 	file_event_event_proto_rawDescOnce sync.Once
@@ -515,49 +2050,123 @@ func file_event_event_proto_rawDescGZIP() []byte {
 	return file_event_event_proto_rawDescData
 }
 
-var file_event_event_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_event_event_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_event_event_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_event_event_proto_goTypes = []any{
-	(*Event)(nil),                      // 0: event.Event
-	(*CreateOrUpdateEventRequest)(nil), // 1: event.CreateOrUpdateEventRequest
-	(*DeleteEventRequest)(nil),         // 2: event.DeleteEventRequest
-	(*GetEventRequest)(nil),            // 3: event.GetEventRequest
-	(*DateRequest)(nil),                // 4: event.DateRequest
-	(*EventListResponse)(nil),          // 5: event.EventListResponse
-	(*EmptyRequest)(nil),               // 6: event.EmptyRequest
-	(*EmptyResponse)(nil),              // 7: event.EmptyResponse
-	(*timestamp.Timestamp)(nil),        // 8: google.protobuf.Timestamp
-	(*duration.Duration)(nil),          // 9: google.protobuf.Duration
+	(AttendeeStatus)(0),                // 0: event.AttendeeStatus
+	(Weekday)(0),                       // 1: event.Weekday
+	(EventSortField)(0),                // 2: event.EventSortField
+	(*Event)(nil),                      // 3: event.Event
+	(*Attendee)(nil),                   // 4: event.Attendee
+	(*CreateOrUpdateEventRequest)(nil), // 5: event.CreateOrUpdateEventRequest
+	(*DeleteEventRequest)(nil),         // 6: event.DeleteEventRequest
+	(*RestoreEventRequest)(nil),        // 7: event.RestoreEventRequest
+	(*ListDeletedEventsRequest)(nil),   // 8: event.ListDeletedEventsRequest
+	(*GetEventRequest)(nil),            // 9: event.GetEventRequest
+	(*DateRequest)(nil),                // 10: event.DateRequest
+	(*WeekRequest)(nil),                // 11: event.WeekRequest
+	(*GetTimeZoneRequest)(nil),         // 12: event.GetTimeZoneRequest
+	(*TimeZone)(nil),                   // 13: event.TimeZone
+	(*AttendeeRequest)(nil),            // 14: event.AttendeeRequest
+	(*RespondToEventRequest)(nil),      // 15: event.RespondToEventRequest
+	(*ListEventsRequest)(nil),          // 16: event.ListEventsRequest
+	(*EventListResponse)(nil),          // 17: event.EventListResponse
+	(*FreeBusyRequest)(nil),            // 18: event.FreeBusyRequest
+	(*TimeInterval)(nil),               // 19: event.TimeInterval
+	(*FreeBusyResponse)(nil),           // 20: event.FreeBusyResponse
+	(*WorkingHours)(nil),               // 21: event.WorkingHours
+	(*FindSlotsRequest)(nil),           // 22: event.FindSlotsRequest
+	(*FindSlotsResponse)(nil),          // 23: event.FindSlotsResponse
+	(*ExportICalendarRequest)(nil),     // 24: event.ExportICalendarRequest
+	(*ICalendarChunk)(nil),             // 25: event.ICalendarChunk
+	(*ImportICalendarRequest)(nil),     // 26: event.ImportICalendarRequest
+	(*ImportICalendarError)(nil),       // 27: event.ImportICalendarError
+	(*ImportICalendarResponse)(nil),    // 28: event.ImportICalendarResponse
+	(*EmptyResponse)(nil),              // 29: event.EmptyResponse
+	nil,                                // 30: event.FindSlotsRequest.TimeZonesEntry
+	(*timestamp.Timestamp)(nil),        // 31: google.protobuf.Timestamp
+	(*duration.Duration)(nil),          // 32: google.protobuf.Duration
 }
 var file_event_event_proto_depIdxs = []int32{
-	8,  // 0: event.Event.start_time:type_name -> google.protobuf.Timestamp
-	8,  // 1: event.Event.end_time:type_name -> google.protobuf.Timestamp
-	9,  // 2: event.Event.notify_before:type_name -> google.protobuf.Duration
-	8,  // 3: event.CreateOrUpdateEventRequest.start_time:type_name -> google.protobuf.Timestamp
-	8,  // 4: event.CreateOrUpdateEventRequest.end_time:type_name -> google.protobuf.Timestamp
-	9,  // 5: event.CreateOrUpdateEventRequest.notify_before:type_name -> google.protobuf.Duration
-	8,  // 6: event.DateRequest.date:type_name -> google.protobuf.Timestamp
-	0,  // 7: event.EventListResponse.events:type_name -> event.Event
-	1,  // 8: event.Events.Create:input_type -> event.CreateOrUpdateEventRequest
-	3,  // 9: event.Events.Get:input_type -> event.GetEventRequest
-	1,  // 10: event.Events.Update:input_type -> event.CreateOrUpdateEventRequest
-	2,  // 11: event.Events.Delete:input_type -> event.DeleteEventRequest
-	6,  // 12: event.Events.ListEvents:input_type -> event.EmptyRequest
-	4,  // 13: event.Events.ListDayEvents:input_type -> event.DateRequest
-	4,  // 14: event.Events.ListWeekEvents:input_type -> event.DateRequest
-	4,  // 15: event.Events.ListMonthEvents:input_type -> event.DateRequest
-	7,  // 16: event.Events.Create:output_type -> event.EmptyResponse
-	0,  // 17: event.Events.Get:output_type -> event.Event
-	7,  // 18: event.Events.Update:output_type -> event.EmptyResponse
-	7,  // 19: event.Events.Delete:output_type -> event.EmptyResponse
-	5,  // 20: event.Events.ListEvents:output_type -> event.EventListResponse
-	5,  // 21: event.Events.ListDayEvents:output_type -> event.EventListResponse
-	5,  // 22: event.Events.ListWeekEvents:output_type -> event.EventListResponse
-	5,  // 23: event.Events.ListMonthEvents:output_type -> event.EventListResponse
-	16, // [16:24] is the sub-list for method output_type
-	8,  // [8:16] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	31, // 0: event.Event.start_time:type_name -> google.protobuf.Timestamp
+	31, // 1: event.Event.end_time:type_name -> google.protobuf.Timestamp
+	32, // 2: event.Event.notify_before:type_name -> google.protobuf.Duration
+	31, // 3: event.Event.recurrence_exceptions:type_name -> google.protobuf.Timestamp
+	31, // 4: event.Event.deleted_at:type_name -> google.protobuf.Timestamp
+	4,  // 5: event.Event.attendees:type_name -> event.Attendee
+	0,  // 6: event.Attendee.status:type_name -> event.AttendeeStatus
+	31, // 7: event.CreateOrUpdateEventRequest.start_time:type_name -> google.protobuf.Timestamp
+	31, // 8: event.CreateOrUpdateEventRequest.end_time:type_name -> google.protobuf.Timestamp
+	32, // 9: event.CreateOrUpdateEventRequest.notify_before:type_name -> google.protobuf.Duration
+	31, // 10: event.CreateOrUpdateEventRequest.recurrence_exceptions:type_name -> google.protobuf.Timestamp
+	31, // 11: event.DateRequest.date:type_name -> google.protobuf.Timestamp
+	31, // 12: event.WeekRequest.date:type_name -> google.protobuf.Timestamp
+	1,  // 13: event.WeekRequest.week_start:type_name -> event.Weekday
+	0,  // 14: event.RespondToEventRequest.status:type_name -> event.AttendeeStatus
+	31, // 15: event.ListEventsRequest.from:type_name -> google.protobuf.Timestamp
+	31, // 16: event.ListEventsRequest.to:type_name -> google.protobuf.Timestamp
+	2,  // 17: event.ListEventsRequest.sort_by:type_name -> event.EventSortField
+	3,  // 18: event.EventListResponse.events:type_name -> event.Event
+	31, // 19: event.FreeBusyRequest.from:type_name -> google.protobuf.Timestamp
+	31, // 20: event.FreeBusyRequest.to:type_name -> google.protobuf.Timestamp
+	31, // 21: event.TimeInterval.start:type_name -> google.protobuf.Timestamp
+	31, // 22: event.TimeInterval.end:type_name -> google.protobuf.Timestamp
+	19, // 23: event.FreeBusyResponse.busy:type_name -> event.TimeInterval
+	32, // 24: event.WorkingHours.start:type_name -> google.protobuf.Duration
+	32, // 25: event.WorkingHours.end:type_name -> google.protobuf.Duration
+	32, // 26: event.FindSlotsRequest.duration:type_name -> google.protobuf.Duration
+	31, // 27: event.FindSlotsRequest.from:type_name -> google.protobuf.Timestamp
+	31, // 28: event.FindSlotsRequest.to:type_name -> google.protobuf.Timestamp
+	21, // 29: event.FindSlotsRequest.working_hours:type_name -> event.WorkingHours
+	30, // 30: event.FindSlotsRequest.time_zones:type_name -> event.FindSlotsRequest.TimeZonesEntry
+	32, // 31: event.FindSlotsRequest.step:type_name -> google.protobuf.Duration
+	19, // 32: event.FindSlotsResponse.slots:type_name -> event.TimeInterval
+	31, // 33: event.ExportICalendarRequest.from:type_name -> google.protobuf.Timestamp
+	31, // 34: event.ExportICalendarRequest.to:type_name -> google.protobuf.Timestamp
+	27, // 35: event.ImportICalendarResponse.errors:type_name -> event.ImportICalendarError
+	5,  // 36: event.Events.Create:input_type -> event.CreateOrUpdateEventRequest
+	9,  // 37: event.Events.Get:input_type -> event.GetEventRequest
+	5,  // 38: event.Events.Update:input_type -> event.CreateOrUpdateEventRequest
+	6,  // 39: event.Events.Delete:input_type -> event.DeleteEventRequest
+	7,  // 40: event.Events.Restore:input_type -> event.RestoreEventRequest
+	8,  // 41: event.Events.ListDeletedEvents:input_type -> event.ListDeletedEventsRequest
+	14, // 42: event.Events.InviteAttendee:input_type -> event.AttendeeRequest
+	14, // 43: event.Events.RemoveAttendee:input_type -> event.AttendeeRequest
+	15, // 44: event.Events.RespondToEvent:input_type -> event.RespondToEventRequest
+	18, // 45: event.Events.FreeBusy:input_type -> event.FreeBusyRequest
+	22, // 46: event.Events.FindSlots:input_type -> event.FindSlotsRequest
+	16, // 47: event.Events.ListEvents:input_type -> event.ListEventsRequest
+	10, // 48: event.Events.ListDayEvents:input_type -> event.DateRequest
+	11, // 49: event.Events.ListWeekEvents:input_type -> event.WeekRequest
+	10, // 50: event.Events.ListMonthEvents:input_type -> event.DateRequest
+	12, // 51: event.Events.GetTimeZone:input_type -> event.GetTimeZoneRequest
+	13, // 52: event.Events.SetTimeZone:input_type -> event.TimeZone
+	24, // 53: event.Events.ExportICalendar:input_type -> event.ExportICalendarRequest
+	26, // 54: event.Events.ImportICalendar:input_type -> event.ImportICalendarRequest
+	3,  // 55: event.Events.Create:output_type -> event.Event
+	3,  // 56: event.Events.Get:output_type -> event.Event
+	3,  // 57: event.Events.Update:output_type -> event.Event
+	29, // 58: event.Events.Delete:output_type -> event.EmptyResponse
+	3,  // 59: event.Events.Restore:output_type -> event.Event
+	17, // 60: event.Events.ListDeletedEvents:output_type -> event.EventListResponse
+	29, // 61: event.Events.InviteAttendee:output_type -> event.EmptyResponse
+	29, // 62: event.Events.RemoveAttendee:output_type -> event.EmptyResponse
+	29, // 63: event.Events.RespondToEvent:output_type -> event.EmptyResponse
+	20, // 64: event.Events.FreeBusy:output_type -> event.FreeBusyResponse
+	23, // 65: event.Events.FindSlots:output_type -> event.FindSlotsResponse
+	17, // 66: event.Events.ListEvents:output_type -> event.EventListResponse
+	17, // 67: event.Events.ListDayEvents:output_type -> event.EventListResponse
+	17, // 68: event.Events.ListWeekEvents:output_type -> event.EventListResponse
+	17, // 69: event.Events.ListMonthEvents:output_type -> event.EventListResponse
+	13, // 70: event.Events.GetTimeZone:output_type -> event.TimeZone
+	29, // 71: event.Events.SetTimeZone:output_type -> event.EmptyResponse
+	25, // 72: event.Events.ExportICalendar:output_type -> event.ICalendarChunk
+	28, // 73: event.Events.ImportICalendar:output_type -> event.ImportICalendarResponse
+	55, // [55:74] is the sub-list for method output_type
+	36, // [36:55] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
 }
 
 func init() { file_event_event_proto_init() }
@@ -566,19 +2175,21 @@ func file_event_event_proto_init() {
 		return
 	}
 	file_event_event_proto_msgTypes[0].OneofWrappers = []any{}
-	file_event_event_proto_msgTypes[1].OneofWrappers = []any{}
+	file_event_event_proto_msgTypes[2].OneofWrappers = []any{}
+	file_event_event_proto_msgTypes[21].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_event_event_proto_rawDesc), len(file_event_event_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      3,
+			NumMessages:   28,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_event_event_proto_goTypes,
 		DependencyIndexes: file_event_event_proto_depIdxs,
+		EnumInfos:         file_event_event_proto_enumTypes,
 		MessageInfos:      file_event_event_proto_msgTypes,
 	}.Build()
 	File_event_event_proto = out.File
@@ -19,28 +19,54 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Events_Create_FullMethodName          = "/event.Events/Create"
-	Events_Get_FullMethodName             = "/event.Events/Get"
-	Events_Update_FullMethodName          = "/event.Events/Update"
-	Events_Delete_FullMethodName          = "/event.Events/Delete"
-	Events_ListEvents_FullMethodName      = "/event.Events/ListEvents"
-	Events_ListDayEvents_FullMethodName   = "/event.Events/ListDayEvents"
-	Events_ListWeekEvents_FullMethodName  = "/event.Events/ListWeekEvents"
-	Events_ListMonthEvents_FullMethodName = "/event.Events/ListMonthEvents"
+	Events_Create_FullMethodName            = "/event.Events/Create"
+	Events_Get_FullMethodName               = "/event.Events/Get"
+	Events_Update_FullMethodName            = "/event.Events/Update"
+	Events_Delete_FullMethodName            = "/event.Events/Delete"
+	Events_Restore_FullMethodName           = "/event.Events/Restore"
+	Events_ListDeletedEvents_FullMethodName = "/event.Events/ListDeletedEvents"
+	Events_InviteAttendee_FullMethodName    = "/event.Events/InviteAttendee"
+	Events_RemoveAttendee_FullMethodName    = "/event.Events/RemoveAttendee"
+	Events_RespondToEvent_FullMethodName    = "/event.Events/RespondToEvent"
+	Events_FreeBusy_FullMethodName          = "/event.Events/FreeBusy"
+	Events_FindSlots_FullMethodName         = "/event.Events/FindSlots"
+	Events_ListEvents_FullMethodName        = "/event.Events/ListEvents"
+	Events_ListDayEvents_FullMethodName     = "/event.Events/ListDayEvents"
+	Events_ListWeekEvents_FullMethodName    = "/event.Events/ListWeekEvents"
+	Events_ListMonthEvents_FullMethodName   = "/event.Events/ListMonthEvents"
+	Events_GetTimeZone_FullMethodName       = "/event.Events/GetTimeZone"
+	Events_SetTimeZone_FullMethodName       = "/event.Events/SetTimeZone"
+	Events_ExportICalendar_FullMethodName   = "/event.Events/ExportICalendar"
+	Events_ImportICalendar_FullMethodName   = "/event.Events/ImportICalendar"
 )
 
 // EventsClient is the client API for Events service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Every call must carry the ID of the calling user in the x-user-id metadata.
 type EventsClient interface {
-	Create(ctx context.Context, in *CreateOrUpdateEventRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// Create and Update return the stored event with its new version.
+	Create(ctx context.Context, in *CreateOrUpdateEventRequest, opts ...grpc.CallOption) (*Event, error)
 	Get(ctx context.Context, in *GetEventRequest, opts ...grpc.CallOption) (*Event, error)
-	Update(ctx context.Context, in *CreateOrUpdateEventRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	Update(ctx context.Context, in *CreateOrUpdateEventRequest, opts ...grpc.CallOption) (*Event, error)
+	// Delete moves the event to the trash.
 	Delete(ctx context.Context, in *DeleteEventRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
-	ListEvents(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*EventListResponse, error)
+	Restore(ctx context.Context, in *RestoreEventRequest, opts ...grpc.CallOption) (*Event, error)
+	ListDeletedEvents(ctx context.Context, in *ListDeletedEventsRequest, opts ...grpc.CallOption) (*EventListResponse, error)
+	InviteAttendee(ctx context.Context, in *AttendeeRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	RemoveAttendee(ctx context.Context, in *AttendeeRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	RespondToEvent(ctx context.Context, in *RespondToEventRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	FreeBusy(ctx context.Context, in *FreeBusyRequest, opts ...grpc.CallOption) (*FreeBusyResponse, error)
+	FindSlots(ctx context.Context, in *FindSlotsRequest, opts ...grpc.CallOption) (*FindSlotsResponse, error)
+	ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*EventListResponse, error)
 	ListDayEvents(ctx context.Context, in *DateRequest, opts ...grpc.CallOption) (*EventListResponse, error)
-	ListWeekEvents(ctx context.Context, in *DateRequest, opts ...grpc.CallOption) (*EventListResponse, error)
+	ListWeekEvents(ctx context.Context, in *WeekRequest, opts ...grpc.CallOption) (*EventListResponse, error)
 	ListMonthEvents(ctx context.Context, in *DateRequest, opts ...grpc.CallOption) (*EventListResponse, error)
+	GetTimeZone(ctx context.Context, in *GetTimeZoneRequest, opts ...grpc.CallOption) (*TimeZone, error)
+	SetTimeZone(ctx context.Context, in *TimeZone, opts ...grpc.CallOption) (*EmptyResponse, error)
+	ExportICalendar(ctx context.Context, in *ExportICalendarRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ICalendarChunk], error)
+	ImportICalendar(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportICalendarRequest, ImportICalendarResponse], error)
 }
 
 type eventsClient struct {
@@ -51,9 +77,9 @@ func NewEventsClient(cc grpc.ClientConnInterface) EventsClient {
 	return &eventsClient{cc}
 }
 
-func (c *eventsClient) Create(ctx context.Context, in *CreateOrUpdateEventRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+func (c *eventsClient) Create(ctx context.Context, in *CreateOrUpdateEventRequest, opts ...grpc.CallOption) (*Event, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(EmptyResponse)
+	out := new(Event)
 	err := c.cc.Invoke(ctx, Events_Create_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -71,9 +97,9 @@ func (c *eventsClient) Get(ctx context.Context, in *GetEventRequest, opts ...grp
 	return out, nil
 }
 
-func (c *eventsClient) Update(ctx context.Context, in *CreateOrUpdateEventRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+func (c *eventsClient) Update(ctx context.Context, in *CreateOrUpdateEventRequest, opts ...grpc.CallOption) (*Event, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(EmptyResponse)
+	out := new(Event)
 	err := c.cc.Invoke(ctx, Events_Update_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -91,7 +117,77 @@ func (c *eventsClient) Delete(ctx context.Context, in *DeleteEventRequest, opts
 	return out, nil
 }
 
-func (c *eventsClient) ListEvents(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*EventListResponse, error) {
+func (c *eventsClient) Restore(ctx context.Context, in *RestoreEventRequest, opts ...grpc.CallOption) (*Event, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Event)
+	err := c.cc.Invoke(ctx, Events_Restore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventsClient) ListDeletedEvents(ctx context.Context, in *ListDeletedEventsRequest, opts ...grpc.CallOption) (*EventListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EventListResponse)
+	err := c.cc.Invoke(ctx, Events_ListDeletedEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventsClient) InviteAttendee(ctx context.Context, in *AttendeeRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, Events_InviteAttendee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventsClient) RemoveAttendee(ctx context.Context, in *AttendeeRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, Events_RemoveAttendee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventsClient) RespondToEvent(ctx context.Context, in *RespondToEventRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, Events_RespondToEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventsClient) FreeBusy(ctx context.Context, in *FreeBusyRequest, opts ...grpc.CallOption) (*FreeBusyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FreeBusyResponse)
+	err := c.cc.Invoke(ctx, Events_FreeBusy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventsClient) FindSlots(ctx context.Context, in *FindSlotsRequest, opts ...grpc.CallOption) (*FindSlotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindSlotsResponse)
+	err := c.cc.Invoke(ctx, Events_FindSlots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventsClient) ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*EventListResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(EventListResponse)
 	err := c.cc.Invoke(ctx, Events_ListEvents_FullMethodName, in, out, cOpts...)
@@ -111,7 +207,7 @@ func (c *eventsClient) ListDayEvents(ctx context.Context, in *DateRequest, opts
 	return out, nil
 }
 
-func (c *eventsClient) ListWeekEvents(ctx context.Context, in *DateRequest, opts ...grpc.CallOption) (*EventListResponse, error) {
+func (c *eventsClient) ListWeekEvents(ctx context.Context, in *WeekRequest, opts ...grpc.CallOption) (*EventListResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(EventListResponse)
 	err := c.cc.Invoke(ctx, Events_ListWeekEvents_FullMethodName, in, out, cOpts...)
@@ -131,18 +227,85 @@ func (c *eventsClient) ListMonthEvents(ctx context.Context, in *DateRequest, opt
 	return out, nil
 }
 
+func (c *eventsClient) GetTimeZone(ctx context.Context, in *GetTimeZoneRequest, opts ...grpc.CallOption) (*TimeZone, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TimeZone)
+	err := c.cc.Invoke(ctx, Events_GetTimeZone_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventsClient) SetTimeZone(ctx context.Context, in *TimeZone, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, Events_SetTimeZone_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventsClient) ExportICalendar(ctx context.Context, in *ExportICalendarRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ICalendarChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Events_ServiceDesc.Streams[0], Events_ExportICalendar_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportICalendarRequest, ICalendarChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Events_ExportICalendarClient = grpc.ServerStreamingClient[ICalendarChunk]
+
+func (c *eventsClient) ImportICalendar(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportICalendarRequest, ImportICalendarResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Events_ServiceDesc.Streams[1], Events_ImportICalendar_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportICalendarRequest, ImportICalendarResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Events_ImportICalendarClient = grpc.ClientStreamingClient[ImportICalendarRequest, ImportICalendarResponse]
+
 // EventsServer is the server API for Events service.
 // All implementations must embed UnimplementedEventsServer
 // for forward compatibility.
+//
+// Every call must carry the ID of the calling user in the x-user-id metadata.
 type EventsServer interface {
-	Create(context.Context, *CreateOrUpdateEventRequest) (*EmptyResponse, error)
+	// Create and Update return the stored event with its new version.
+	Create(context.Context, *CreateOrUpdateEventRequest) (*Event, error)
 	Get(context.Context, *GetEventRequest) (*Event, error)
-	Update(context.Context, *CreateOrUpdateEventRequest) (*EmptyResponse, error)
+	Update(context.Context, *CreateOrUpdateEventRequest) (*Event, error)
+	// Delete moves the event to the trash.
 	Delete(context.Context, *DeleteEventRequest) (*EmptyResponse, error)
-	ListEvents(context.Context, *EmptyRequest) (*EventListResponse, error)
+	Restore(context.Context, *RestoreEventRequest) (*Event, error)
+	ListDeletedEvents(context.Context, *ListDeletedEventsRequest) (*EventListResponse, error)
+	InviteAttendee(context.Context, *AttendeeRequest) (*EmptyResponse, error)
+	RemoveAttendee(context.Context, *AttendeeRequest) (*EmptyResponse, error)
+	RespondToEvent(context.Context, *RespondToEventRequest) (*EmptyResponse, error)
+	FreeBusy(context.Context, *FreeBusyRequest) (*FreeBusyResponse, error)
+	FindSlots(context.Context, *FindSlotsRequest) (*FindSlotsResponse, error)
+	ListEvents(context.Context, *ListEventsRequest) (*EventListResponse, error)
 	ListDayEvents(context.Context, *DateRequest) (*EventListResponse, error)
-	ListWeekEvents(context.Context, *DateRequest) (*EventListResponse, error)
+	ListWeekEvents(context.Context, *WeekRequest) (*EventListResponse, error)
 	ListMonthEvents(context.Context, *DateRequest) (*EventListResponse, error)
+	GetTimeZone(context.Context, *GetTimeZoneRequest) (*TimeZone, error)
+	SetTimeZone(context.Context, *TimeZone) (*EmptyResponse, error)
+	ExportICalendar(*ExportICalendarRequest, grpc.ServerStreamingServer[ICalendarChunk]) error
+	ImportICalendar(grpc.ClientStreamingServer[ImportICalendarRequest, ImportICalendarResponse]) error
 	mustEmbedUnimplementedEventsServer()
 }
 
@@ -153,30 +316,63 @@ type EventsServer interface {
 // pointer dereference when methods are called.
 type UnimplementedEventsServer struct{}
 
-func (UnimplementedEventsServer) Create(context.Context, *CreateOrUpdateEventRequest) (*EmptyResponse, error) {
+func (UnimplementedEventsServer) Create(context.Context, *CreateOrUpdateEventRequest) (*Event, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
 }
 func (UnimplementedEventsServer) Get(context.Context, *GetEventRequest) (*Event, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
-func (UnimplementedEventsServer) Update(context.Context, *CreateOrUpdateEventRequest) (*EmptyResponse, error) {
+func (UnimplementedEventsServer) Update(context.Context, *CreateOrUpdateEventRequest) (*Event, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
 }
 func (UnimplementedEventsServer) Delete(context.Context, *DeleteEventRequest) (*EmptyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
-func (UnimplementedEventsServer) ListEvents(context.Context, *EmptyRequest) (*EventListResponse, error) {
+func (UnimplementedEventsServer) Restore(context.Context, *RestoreEventRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedEventsServer) ListDeletedEvents(context.Context, *ListDeletedEventsRequest) (*EventListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedEvents not implemented")
+}
+func (UnimplementedEventsServer) InviteAttendee(context.Context, *AttendeeRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteAttendee not implemented")
+}
+func (UnimplementedEventsServer) RemoveAttendee(context.Context, *AttendeeRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveAttendee not implemented")
+}
+func (UnimplementedEventsServer) RespondToEvent(context.Context, *RespondToEventRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RespondToEvent not implemented")
+}
+func (UnimplementedEventsServer) FreeBusy(context.Context, *FreeBusyRequest) (*FreeBusyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FreeBusy not implemented")
+}
+func (UnimplementedEventsServer) FindSlots(context.Context, *FindSlotsRequest) (*FindSlotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindSlots not implemented")
+}
+func (UnimplementedEventsServer) ListEvents(context.Context, *ListEventsRequest) (*EventListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListEvents not implemented")
 }
 func (UnimplementedEventsServer) ListDayEvents(context.Context, *DateRequest) (*EventListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListDayEvents not implemented")
 }
-func (UnimplementedEventsServer) ListWeekEvents(context.Context, *DateRequest) (*EventListResponse, error) {
+func (UnimplementedEventsServer) ListWeekEvents(context.Context, *WeekRequest) (*EventListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListWeekEvents not implemented")
 }
 func (UnimplementedEventsServer) ListMonthEvents(context.Context, *DateRequest) (*EventListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListMonthEvents not implemented")
 }
+func (UnimplementedEventsServer) GetTimeZone(context.Context, *GetTimeZoneRequest) (*TimeZone, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTimeZone not implemented")
+}
+func (UnimplementedEventsServer) SetTimeZone(context.Context, *TimeZone) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTimeZone not implemented")
+}
+func (UnimplementedEventsServer) ExportICalendar(*ExportICalendarRequest, grpc.ServerStreamingServer[ICalendarChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportICalendar not implemented")
+}
+func (UnimplementedEventsServer) ImportICalendar(grpc.ClientStreamingServer[ImportICalendarRequest, ImportICalendarResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportICalendar not implemented")
+}
 func (UnimplementedEventsServer) mustEmbedUnimplementedEventsServer() {}
 func (UnimplementedEventsServer) testEmbeddedByValue()                {}
 
@@ -270,8 +466,134 @@ func _Events_Delete_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Events_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventsServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Events_Restore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventsServer).Restore(ctx, req.(*RestoreEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Events_ListDeletedEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventsServer).ListDeletedEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Events_ListDeletedEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventsServer).ListDeletedEvents(ctx, req.(*ListDeletedEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Events_InviteAttendee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttendeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventsServer).InviteAttendee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Events_InviteAttendee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventsServer).InviteAttendee(ctx, req.(*AttendeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Events_RemoveAttendee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttendeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventsServer).RemoveAttendee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Events_RemoveAttendee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventsServer).RemoveAttendee(ctx, req.(*AttendeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Events_RespondToEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondToEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventsServer).RespondToEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Events_RespondToEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventsServer).RespondToEvent(ctx, req.(*RespondToEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Events_FreeBusy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreeBusyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventsServer).FreeBusy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Events_FreeBusy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventsServer).FreeBusy(ctx, req.(*FreeBusyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Events_FindSlots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindSlotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventsServer).FindSlots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Events_FindSlots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventsServer).FindSlots(ctx, req.(*FindSlotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Events_ListEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EmptyRequest)
+	in := new(ListEventsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -283,7 +605,7 @@ func _Events_ListEvents_Handler(srv interface{}, ctx context.Context, dec func(i
 		FullMethod: Events_ListEvents_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(EventsServer).ListEvents(ctx, req.(*EmptyRequest))
+		return srv.(EventsServer).ListEvents(ctx, req.(*ListEventsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -307,7 +629,7 @@ func _Events_ListDayEvents_Handler(srv interface{}, ctx context.Context, dec fun
 }
 
 func _Events_ListWeekEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DateRequest)
+	in := new(WeekRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -319,7 +641,7 @@ func _Events_ListWeekEvents_Handler(srv interface{}, ctx context.Context, dec fu
 		FullMethod: Events_ListWeekEvents_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(EventsServer).ListWeekEvents(ctx, req.(*DateRequest))
+		return srv.(EventsServer).ListWeekEvents(ctx, req.(*WeekRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -342,6 +664,60 @@ func _Events_ListMonthEvents_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Events_GetTimeZone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTimeZoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventsServer).GetTimeZone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Events_GetTimeZone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventsServer).GetTimeZone(ctx, req.(*GetTimeZoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Events_SetTimeZone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimeZone)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventsServer).SetTimeZone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Events_SetTimeZone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventsServer).SetTimeZone(ctx, req.(*TimeZone))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Events_ExportICalendar_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportICalendarRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventsServer).ExportICalendar(m, &grpc.GenericServerStream[ExportICalendarRequest, ICalendarChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Events_ExportICalendarServer = grpc.ServerStreamingServer[ICalendarChunk]
+
+func _Events_ImportICalendar_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EventsServer).ImportICalendar(&grpc.GenericServerStream[ImportICalendarRequest, ImportICalendarResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Events_ImportICalendarServer = grpc.ClientStreamingServer[ImportICalendarRequest, ImportICalendarResponse]
+
 // Events_ServiceDesc is the grpc.ServiceDesc for Events service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -365,6 +741,34 @@ var Events_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _Events_Delete_Handler,
 		},
+		{
+			MethodName: "Restore",
+			Handler:    _Events_Restore_Handler,
+		},
+		{
+			MethodName: "ListDeletedEvents",
+			Handler:    _Events_ListDeletedEvents_Handler,
+		},
+		{
+			MethodName: "InviteAttendee",
+			Handler:    _Events_InviteAttendee_Handler,
+		},
+		{
+			MethodName: "RemoveAttendee",
+			Handler:    _Events_RemoveAttendee_Handler,
+		},
+		{
+			MethodName: "RespondToEvent",
+			Handler:    _Events_RespondToEvent_Handler,
+		},
+		{
+			MethodName: "FreeBusy",
+			Handler:    _Events_FreeBusy_Handler,
+		},
+		{
+			MethodName: "FindSlots",
+			Handler:    _Events_FindSlots_Handler,
+		},
 		{
 			MethodName: "ListEvents",
 			Handler:    _Events_ListEvents_Handler,
@@ -381,7 +785,26 @@ var Events_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListMonthEvents",
 			Handler:    _Events_ListMonthEvents_Handler,
 		},
+		{
+			MethodName: "GetTimeZone",
+			Handler:    _Events_GetTimeZone_Handler,
+		},
+		{
+			MethodName: "SetTimeZone",
+			Handler:    _Events_SetTimeZone_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportICalendar",
+			Handler:       _Events_ExportICalendar_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportICalendar",
+			Handler:       _Events_ImportICalendar_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "event/event.proto",
 }